@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"io"
 	"itfest-2025/internal/handler/rest"
 	"itfest-2025/internal/repository"
 	"itfest-2025/internal/service"
@@ -8,9 +11,16 @@ import (
 	"itfest-2025/pkg/config"
 	"itfest-2025/pkg/database/mariadb"
 	"itfest-2025/pkg/jwt"
+	"itfest-2025/pkg/mail"
 	"itfest-2025/pkg/middleware"
-	"itfest-2025/pkg/supabase"
+	"itfest-2025/pkg/payment"
+	"itfest-2025/pkg/storage"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 )
 
@@ -20,7 +30,7 @@ func main() {
 		log.Fatal("Gagal load lokasi zona waktu:", err)
 	}
 	time.Local = loc
-	
+
 	config.LoadEnvironment()
 
 	db, err := mariadb.ConnectDatabase()
@@ -34,14 +44,33 @@ func main() {
 	}
 
 	repo := repository.NewRepository(db)
-	supabase := supabase.Init()
+	storage := storage.Init()
 	bcrypt := bcrypt.Init()
 	jwt := jwt.Init()
-	svc := service.NewService(repo, bcrypt, jwt, supabase)
+	paymentProvider := payment.Init()
+	mailer := mail.Init()
+	svc := service.NewService(repo, bcrypt, jwt, storage, paymentProvider, mailer)
+
+	// SIGINT/SIGTERM menghentikan server lalu menunggu worker email dan scheduler menuntaskan pekerjaannya
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+	svc.OutboxService.StartWorker(ctx, &wg)
+	svc.AnnouncementService.StartScheduler(ctx, &wg)
 	middleware := middleware.Init(svc, jwt)
 
 	r := rest.NewRest(svc, middleware)
 	r.MountEndpoint()
-	r.Run()
+	err = r.Run(ctx)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Println(err)
+	}
+
+	stop()
+	wg.Wait()
 
+	if closer, ok := mailer.(io.Closer); ok {
+		closer.Close()
+	}
 }
@@ -6,11 +6,24 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	AnnouncementDraft     = "draft"
+	AnnouncementScheduled = "scheduled"
+	AnnouncementPublished = "published"
+)
+
 type Announcement struct {
-	AnnouncementID uuid.UUID `json:"announcement_id" gorm:"varchar(36);primaryKey"`
-	Title          string    `json:"title" gorm:"varchar(255);not null;not null"`
-	Description    string    `json:"description" gorm:"text;not null"`
-	CompetitionID  int       `json:"competition_id" gorm:"default:null"`
-	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	AnnouncementID   uuid.UUID  `json:"announcement_id" gorm:"varchar(36);primaryKey"`
+	Title            string     `json:"title" gorm:"varchar(255);not null;not null"`
+	Description      string     `json:"description" gorm:"text;not null"`
+	CompetitionID    *int       `json:"competition_id" gorm:"default:null"`
+	StageID          *int       `json:"stage_id" gorm:"type:int;default:null"`
+	SubmissionStatus string     `json:"submission_status" gorm:"type:varchar(20)"`
+	PaymentStatus    string     `json:"payment_status" gorm:"type:varchar(30)"`
+	Status           string     `json:"status" gorm:"type:enum('draft', 'scheduled', 'published');not null;default:'published';index:idx_announcement_due,priority:1"`
+	PublishAt        *time.Time `json:"publish_at" gorm:"type:datetime;index:idx_announcement_due,priority:2"`
+	PublishedAt      *time.Time `json:"published_at" gorm:"type:datetime"`
+	RecipientCount   int        `json:"recipient_count" gorm:"type:int;not null;default:0"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 }
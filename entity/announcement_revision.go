@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RevisionCreated   = "created"
+	RevisionUpdated   = "updated"
+	RevisionPublished = "published"
+	RevisionDeleted   = "deleted"
+)
+
+// AnnouncementRevision menyimpan isi pengumuman setelah setiap perubahan, tetap ada walaupun pengumumannya dihapus.
+type AnnouncementRevision struct {
+	AnnouncementRevisionID int        `json:"announcement_revision_id" gorm:"type:int;primaryKey;autoIncrement"`
+	AnnouncementID         uuid.UUID  `json:"announcement_id" gorm:"type:varchar(36);not null;index"`
+	Action                 string     `json:"action" gorm:"type:enum('created', 'updated', 'published', 'deleted');not null"`
+	Title                  string     `json:"title" gorm:"type:varchar(255);not null"`
+	Description            string     `json:"description" gorm:"type:text;not null"`
+	CompetitionID          *int       `json:"competition_id" gorm:"type:int"`
+	StageID                *int       `json:"stage_id" gorm:"type:int"`
+	SubmissionStatus       string     `json:"submission_status" gorm:"type:varchar(20)"`
+	PaymentStatus          string     `json:"payment_status" gorm:"type:varchar(30)"`
+	Status                 string     `json:"status" gorm:"type:varchar(20);not null"`
+	PublishAt              *time.Time `json:"publish_at" gorm:"type:datetime"`
+	EmailResent            bool       `json:"email_resent" gorm:"not null;default:false"`
+	ChangedBy              *uuid.UUID `json:"changed_by" gorm:"type:varchar(36)"`
+	CreatedAt              time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -2,13 +2,25 @@ package entity
 
 import "time"
 
+// UnassignedCompetitionID adalah kompetisi placeholder untuk tim yang belum mendaftar lomba.
+const UnassignedCompetitionID = 1
+
 type Competition struct {
-	CompetitionID   int       `json:"competition_id" gorm:"type:int;primaryKey"`
-	CompetitionName string    `json:"competition_name" gorm:"type:varchar(70);not null"`
-	Description     string    `json:"description" gorm:"type:text;not null"`
-	Deadline        time.Time `json:"deadline" gorm:"type:datetime"`
-
-	Teams         []Team         `gorm:"foreignKey:CompetitionID"`
-	Announcements []Announcement `gorm:"foreignKey:CompetitionID"`
-	Stages        []Stages       `gorm:"foreignKey:CompetitionID"`
+	CompetitionID     int        `json:"competition_id" gorm:"type:int;primaryKey"`
+	CompetitionName   string     `json:"competition_name" gorm:"type:varchar(70);not null"`
+	Description       string     `json:"description" gorm:"type:text;not null"`
+	Deadline          time.Time  `json:"deadline" gorm:"type:datetime"`
+	RegistrationFee   int64      `json:"registration_fee" gorm:"type:bigint;not null;default:0"`
+	EarlyBirdFee      int64      `json:"early_bird_fee" gorm:"type:bigint;not null;default:0"`
+	EarlyBirdStart    *time.Time `json:"early_bird_start" gorm:"type:datetime"`
+	EarlyBirdEnd      *time.Time `json:"early_bird_end" gorm:"type:datetime"`
+	IsArchived        bool       `json:"is_archived" gorm:"type:boolean;not null;default:false"`
+	RegistrationOpen  *time.Time `json:"registration_open" gorm:"type:datetime"`
+	RegistrationClose *time.Time `json:"registration_close" gorm:"type:datetime"`
+	MaxTeams          int        `json:"max_teams" gorm:"type:int;not null;default:0"`
+
+	Teams         []Team           `gorm:"foreignKey:CompetitionID"`
+	Announcements []Announcement   `gorm:"foreignKey:CompetitionID"`
+	Stages        []Stages         `gorm:"foreignKey:CompetitionID"`
+	Rule          *CompetitionRule `json:"rule" gorm:"foreignKey:CompetitionID"`
 }
@@ -0,0 +1,9 @@
+package entity
+
+type CompetitionRule struct {
+	CompetitionID     int  `json:"competition_id" gorm:"type:int;primaryKey"`
+	PaymentRequired   bool `json:"payment_required" gorm:"type:boolean;not null;default:true"`
+	PaymentStageOrder int  `json:"payment_stage_order" gorm:"type:int;not null;default:1"`
+	MaxTeamMembers    int  `json:"max_team_members" gorm:"type:int;not null;default:2"`
+	FreeFirstStage    bool `json:"free_first_stage" gorm:"type:boolean;not null;default:false"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistPromoted  = "promoted"
+	WaitlistCancelled = "cancelled"
+)
+
+type CompetitionWaitlist struct {
+	WaitlistID    int        `json:"waitlist_id" gorm:"type:int;primaryKey;autoIncrement"`
+	CompetitionID int        `json:"competition_id" gorm:"type:int;not null;index"`
+	TeamID        uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;index"`
+	VoucherCode   string     `json:"voucher_code" gorm:"type:varchar(30)"`
+	Status        string     `json:"status" gorm:"type:enum('waiting', 'promoted', 'cancelled');not null;default:'waiting';index"`
+	PromotedAt    *time.Time `json:"promoted_at" gorm:"type:datetime"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Team        Team        `json:"team" gorm:"foreignKey:TeamID"`
+	Competition Competition `json:"competition" gorm:"foreignKey:CompetitionID"`
+}
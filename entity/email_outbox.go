@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+const (
+	EmailPending = "pending"
+	EmailSending = "sending"
+	EmailSent    = "sent"
+	EmailDead    = "dead"
+)
+
+type EmailOutbox struct {
+	EmailID       int        `json:"email_id" gorm:"type:int;primaryKey;autoIncrement"`
+	Recipient     string     `json:"recipient" gorm:"type:varchar(255);not null;index"`
+	Subject       string     `json:"subject" gorm:"type:varchar(255);not null"`
+	Template      string     `json:"template" gorm:"type:varchar(50)"`
+	HTMLBody      string     `json:"html_body" gorm:"type:longtext"`
+	TextBody      string     `json:"text_body" gorm:"type:longtext"`
+	Attachments   []byte     `json:"-" gorm:"type:longblob"`
+	Status        string     `json:"status" gorm:"type:enum('pending', 'sending', 'sent', 'dead');not null;default:'pending';index:idx_email_outbox_due,priority:1"`
+	Attempts      int        `json:"attempts" gorm:"type:int;not null;default:0"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"type:datetime;not null;index:idx_email_outbox_due,priority:2"`
+	SentAt        *time.Time `json:"sent_at" gorm:"type:datetime"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JudgeAssignment struct {
+	AssignmentID   uuid.UUID  `json:"assignment_id" gorm:"type:varchar(36);primaryKey"`
+	TeamProgressID int        `json:"team_progress_id" gorm:"type:int;not null;uniqueIndex:idx_assignment_judge"`
+	JudgeID        uuid.UUID  `json:"judge_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_assignment_judge"`
+	AssignedBy     uuid.UUID  `json:"assigned_by" gorm:"type:varchar(36);not null"`
+	ScoredVersion  int        `json:"scored_version" gorm:"type:int"`
+	Comment        string     `json:"comment" gorm:"type:text"`
+	SubmittedAt    *time.Time `json:"submitted_at" gorm:"type:datetime"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Scores []JudgeScore `json:"scores" gorm:"foreignKey:AssignmentID"`
+}
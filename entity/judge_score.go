@@ -0,0 +1,11 @@
+package entity
+
+import "github.com/google/uuid"
+
+type JudgeScore struct {
+	ScoreID      int       `json:"score_id" gorm:"type:int;primaryKey;autoIncrement"`
+	AssignmentID uuid.UUID `json:"assignment_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_score_criterion"`
+	CriterionID  int       `json:"criterion_id" gorm:"type:int;not null;uniqueIndex:idx_score_criterion"`
+	Score        float64   `json:"score" gorm:"type:decimal(6,2);not null"`
+	Comment      string    `json:"comment" gorm:"type:text"`
+}
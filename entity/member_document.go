@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DocumentKTM = "ktm"
+
+	DocumentOwnerLeader = "leader"
+	DocumentOwnerMember = "member"
+)
+
+// MemberDocument menyimpan dokumen tiap orang di tim. OwnerID berisi user_id untuk ketua
+// dan team_member_id untuk anggota.
+type MemberDocument struct {
+	DocumentID      uuid.UUID  `json:"document_id" gorm:"type:varchar(36);primaryKey"`
+	TeamID          uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;index"`
+	OwnerID         uuid.UUID  `json:"owner_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_member_document_owner"`
+	OwnerType       string     `json:"owner_type" gorm:"type:enum('leader', 'member');not null"`
+	DocumentType    string     `json:"document_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_member_document_owner"`
+	FileKey         string     `json:"file_key" gorm:"type:varchar(255);not null"`
+	Status          string     `json:"status" gorm:"type:enum('pending', 'approved', 'rejected');not null;default:'pending'"`
+	RejectionReason string     `json:"rejection_reason" gorm:"type:varchar(255)"`
+	UploadedBy      uuid.UUID  `json:"uploaded_by" gorm:"type:varchar(36);not null"`
+	ReviewedBy      *uuid.UUID `json:"reviewed_by" gorm:"type:varchar(36)"`
+	ReviewedAt      *time.Time `json:"reviewed_at" gorm:"type:datetime"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
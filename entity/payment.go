@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Payment struct {
+	PaymentID       uuid.UUID  `json:"payment_id" gorm:"type:varchar(36);primaryKey"`
+	TeamID          uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;uniqueIndex"`
+	CompetitionID   int        `json:"competition_id" gorm:"type:int;not null"`
+	ReferenceCode   string     `json:"reference_code" gorm:"type:varchar(12);uniqueIndex"`
+	Amount          int64      `json:"amount" gorm:"type:bigint;not null;default:0"`
+	ProofFile       string     `json:"proof_file" gorm:"type:text"`
+	Channel         string     `json:"channel" gorm:"type:varchar(50)"`
+	Method          string     `json:"method" gorm:"type:enum('manual', 'gateway');not null;default:'manual'"`
+	Status          string     `json:"status" gorm:"type:enum('belum terverifikasi', 'terverifikasi', 'ditolak', 'diproses');not null"`
+	SubmittedAt     *time.Time `json:"submitted_at" gorm:"type:datetime"`
+	VerifiedBy      *uuid.UUID `json:"verified_by" gorm:"type:varchar(36)"`
+	VerifiedAt      *time.Time `json:"verified_at" gorm:"type:datetime"`
+	RejectionReason string     `json:"rejection_reason" gorm:"type:text"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Histories []PaymentHistory `json:"histories" gorm:"foreignKey:PaymentID"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentCharge struct {
+	ChargeID     uuid.UUID  `json:"charge_id" gorm:"type:varchar(36);primaryKey"`
+	OrderID      string     `json:"order_id" gorm:"type:varchar(64);not null;uniqueIndex"`
+	PaymentID    uuid.UUID  `json:"payment_id" gorm:"type:varchar(36);not null;index"`
+	TeamID       uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;index"`
+	Provider     string     `json:"provider" gorm:"type:varchar(30);not null"`
+	Reference    string     `json:"reference" gorm:"type:varchar(100)"`
+	Amount       int64      `json:"amount" gorm:"type:bigint;not null"`
+	Status       string     `json:"status" gorm:"type:enum('pending', 'paid', 'failed', 'expired');not null"`
+	RedirectURL  string     `json:"redirect_url" gorm:"type:text"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"type:datetime"`
+	PaidAt       *time.Time `json:"paid_at" gorm:"type:datetime"`
+	Notification string     `json:"-" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentHistory struct {
+	PaymentHistoryID int        `json:"payment_history_id" gorm:"type:int;primaryKey;autoIncrement"`
+	PaymentID        uuid.UUID  `json:"payment_id" gorm:"type:varchar(36);not null;index"`
+	FromStatus       string     `json:"from_status" gorm:"type:varchar(30)"`
+	ToStatus         string     `json:"to_status" gorm:"type:varchar(30);not null"`
+	Amount           int64      `json:"amount" gorm:"type:bigint"`
+	Channel          string     `json:"channel" gorm:"type:varchar(50)"`
+	Reason           string     `json:"reason" gorm:"type:text"`
+	ChangedBy        *uuid.UUID `json:"changed_by" gorm:"type:varchar(36)"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+const (
+	PermissionTeamsRead           = "teams:read"
+	PermissionTeamsWrite          = "teams:write"
+	PermissionPaymentsRead        = "payments:read"
+	PermissionPaymentsVerify      = "payments:verify"
+	PermissionSubmissionsRead     = "submissions:read"
+	PermissionSubmissionsGrade    = "submissions:grade"
+	PermissionSubmissionsDecide   = "submissions:decide"
+	PermissionCompetitionsRead    = "competitions:read"
+	PermissionCompetitionsManage  = "competitions:manage"
+	PermissionAnnouncementsManage = "announcements:manage"
+	PermissionExportsRead         = "exports:read"
+	PermissionUsersManage         = "users:manage"
+	PermissionRolesManage         = "roles:manage"
+)
+
+type Permission struct {
+	PermissionID int    `json:"permission_id" gorm:"type:int;primaryKey"`
+	Name         string `json:"name" gorm:"type:varchar(50);not null;uniqueIndex"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Receipt menyimpan salinan data saat kuitansi diterbitkan agar PDF yang diunduh ulang tetap sama.
+type Receipt struct {
+	ReceiptID       uuid.UUID  `json:"receipt_id" gorm:"type:varchar(36);primaryKey"`
+	Number          string     `json:"number" gorm:"type:varchar(30);not null;uniqueIndex"`
+	Sequence        int64      `json:"sequence" gorm:"type:bigint;not null;uniqueIndex"`
+	TeamID          uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;index"`
+	PaymentID       uuid.UUID  `json:"payment_id" gorm:"type:varchar(36);not null;index"`
+	TeamName        string     `json:"team_name" gorm:"type:varchar(50);not null"`
+	LeaderName      string     `json:"leader_name" gorm:"type:varchar(100)"`
+	LeaderEmail     string     `json:"leader_email" gorm:"type:varchar(100)"`
+	CompetitionName string     `json:"competition_name" gorm:"type:varchar(70)"`
+	Amount          int64      `json:"amount" gorm:"type:bigint;not null"`
+	Method          string     `json:"method" gorm:"type:varchar(20)"`
+	VerifiedAt      time.Time  `json:"verified_at" gorm:"type:datetime"`
+	VerifiedBy      *uuid.UUID `json:"verified_by" gorm:"type:varchar(36)"`
+	VerifierName    string     `json:"verifier_name" gorm:"type:varchar(100)"`
+	IsVoid          bool       `json:"is_void" gorm:"type:boolean;not null;default:false"`
+	VoidedAt        *time.Time `json:"voided_at" gorm:"type:datetime"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,8 @@
+package entity
+
+// ReceiptSequence adalah penghitung nomor kuitansi, baris dikunci saat mengambil nomor berikutnya
+// agar nomor selalu berurutan dan tidak pernah dipakai ulang.
+type ReceiptSequence struct {
+	Name      string `json:"name" gorm:"type:varchar(30);primaryKey"`
+	LastValue int64  `json:"last_value" gorm:"type:bigint;not null;default:0"`
+}
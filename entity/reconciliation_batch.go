@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReconciliationBatch struct {
+	BatchID       uuid.UUID  `json:"batch_id" gorm:"type:varchar(36);primaryKey"`
+	FileName      string     `json:"file_name" gorm:"type:varchar(255);not null"`
+	Status        string     `json:"status" gorm:"type:enum('review', 'confirmed');not null;default:'review'"`
+	TotalRows     int        `json:"total_rows" gorm:"type:int;not null;default:0"`
+	MatchedRows   int        `json:"matched_rows" gorm:"type:int;not null;default:0"`
+	AmbiguousRows int        `json:"ambiguous_rows" gorm:"type:int;not null;default:0"`
+	ConfirmedRows int        `json:"confirmed_rows" gorm:"type:int;not null;default:0"`
+	UploadedBy    uuid.UUID  `json:"uploaded_by" gorm:"type:varchar(36);not null"`
+	ConfirmedBy   *uuid.UUID `json:"confirmed_by" gorm:"type:varchar(36)"`
+	ConfirmedAt   *time.Time `json:"confirmed_at" gorm:"type:datetime"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	Lines []ReconciliationLine `json:"lines" gorm:"foreignKey:BatchID"`
+}
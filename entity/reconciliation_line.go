@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReconciliationLine struct {
+	LineID        int        `json:"line_id" gorm:"type:int;primaryKey;autoIncrement"`
+	BatchID       uuid.UUID  `json:"batch_id" gorm:"type:varchar(36);not null;index"`
+	RowNumber     int        `json:"row_number" gorm:"type:int;not null"`
+	TransactionAt *time.Time `json:"transaction_at" gorm:"type:datetime"`
+	Amount        int64      `json:"amount" gorm:"type:bigint;not null"`
+	Description   string     `json:"description" gorm:"type:text"`
+	MatchStatus   string     `json:"match_status" gorm:"type:enum('matched', 'ambiguous', 'unmatched', 'confirmed', 'skipped');not null"`
+	MatchReason   string     `json:"match_reason" gorm:"type:varchar(100)"`
+	TeamID        *uuid.UUID `json:"team_id" gorm:"type:varchar(36)"`
+	Candidates    string     `json:"candidates" gorm:"type:text"`
+}
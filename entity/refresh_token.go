@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefreshToken struct {
+	TokenID   uuid.UUID  `json:"token_id" gorm:"type:varchar(36);primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:varchar(36);not null;index"`
+	SessionID uuid.UUID  `json:"session_id" gorm:"type:varchar(36);not null;index"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"type:datetime;not null"`
+	RevokedAt *time.Time `json:"revoked_at" gorm:"type:datetime"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RegistrationAnswer struct {
+	AnswerID  int       `json:"answer_id" gorm:"type:int;primaryKey;autoIncrement"`
+	TeamID    uuid.UUID `json:"team_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_registration_answer"`
+	FieldID   int       `json:"field_id" gorm:"type:int;not null;uniqueIndex:idx_registration_answer"`
+	Value     string    `json:"value" gorm:"type:text"`
+	FileName  string    `json:"file_name" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Field RegistrationField `json:"field" gorm:"foreignKey:FieldID"`
+}
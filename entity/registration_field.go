@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Tipe isian form registrasi yang dapat diatur admin untuk tiap lomba.
+const (
+	FieldText   = "text"
+	FieldSelect = "select"
+	FieldURL    = "url"
+	FieldFile   = "file"
+)
+
+type RegistrationField struct {
+	FieldID          int       `json:"field_id" gorm:"type:int;primaryKey;autoIncrement"`
+	CompetitionID    int       `json:"competition_id" gorm:"type:int;not null;uniqueIndex:idx_registration_field_key"`
+	FieldKey         string    `json:"field_key" gorm:"type:varchar(50);not null;uniqueIndex:idx_registration_field_key"`
+	Label            string    `json:"label" gorm:"type:varchar(100);not null"`
+	FieldType        string    `json:"field_type" gorm:"type:enum('text', 'select', 'url', 'file');not null"`
+	Required         bool      `json:"required" gorm:"type:boolean;not null;default:false"`
+	Options          string    `json:"options" gorm:"type:text"`
+	MaxLength        int       `json:"max_length" gorm:"type:int;not null;default:0"`
+	Pattern          string    `json:"pattern" gorm:"type:varchar(255)"`
+	AllowedMimeTypes string    `json:"allowed_mime_types" gorm:"type:varchar(255)"`
+	MaxFileSize      int64     `json:"max_file_size" gorm:"type:bigint;not null;default:0"`
+	FieldOrder       int       `json:"field_order" gorm:"type:int;not null;default:0"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
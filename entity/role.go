@@ -1,7 +1,16 @@
 package entity
 
+const (
+	RoleSuperAdmin      = 1
+	RoleParticipant     = 2
+	RolePaymentVerifier = 3
+	RoleJudge           = 4
+	RoleViewer          = 5
+)
+
 type Role struct {
-	RoleID   int    `json:"role_id" gorm:"type:int;primaryKey"`
-	RoleName string `json:"role_name" gorm:"type:varchar(20);not null"`
-	Users    []User `gorm:"foreignKey:RoleID"`
+	RoleID      int          `json:"role_id" gorm:"type:int;primaryKey"`
+	RoleName    string       `json:"role_name" gorm:"type:varchar(20);not null"`
+	Users       []User       `json:"-" gorm:"foreignKey:RoleID"`
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions;joinForeignKey:RoleID;joinReferences:PermissionID"`
 }
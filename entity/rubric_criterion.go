@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type RubricCriterion struct {
+	CriterionID int       `json:"criterion_id" gorm:"type:int;primaryKey;autoIncrement"`
+	StageID     int       `json:"stage_id" gorm:"type:int;not null;index"`
+	Name        string    `json:"name" gorm:"type:varchar(100);not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	Weight      float64   `json:"weight" gorm:"type:decimal(5,2);not null"`
+	MaxScore    int       `json:"max_score" gorm:"type:int;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
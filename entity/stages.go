@@ -2,12 +2,19 @@ package entity
 
 import "time"
 
+const (
+	DefaultSubmissionMimeTypes = "application/pdf,application/zip,image/png,image/jpeg"
+	DefaultSubmissionFileSize  = 10 << 20
+)
+
 type Stages struct {
-	StageID       int       `json:"stage_id" gorm:"type:int;primaryKey"`
-	StageName     string    `json:"stage_name" gorm:"type:varchar(20);not null"`
-	CompetitionID int       `json:"competition_id"`
-	StageOrder    int       `json:"stage_order" gorm:"type:int;not null"`
-	Deadline      time.Time `json:"deadline" gorm:"type:date;not null"`
+	StageID          int       `json:"stage_id" gorm:"type:int;primaryKey"`
+	StageName        string    `json:"stage_name" gorm:"type:varchar(20);not null"`
+	CompetitionID    int       `json:"competition_id"`
+	StageOrder       int       `json:"stage_order" gorm:"type:int;not null"`
+	Deadline         time.Time `json:"deadline" gorm:"type:date;not null"`
+	AllowedMimeTypes string    `json:"allowed_mime_types" gorm:"type:varchar(255)"`
+	MaxFileSize      int64     `json:"max_file_size" gorm:"type:bigint;not null;default:10485760"`
 
 	TeamProgresses TeamProgress `json:"team_progresses" gorm:"foreignKey:StageID;references:StageID"`
 }
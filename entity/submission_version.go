@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SubmissionVersion struct {
+	SubmissionVersionID int       `json:"submission_version_id" gorm:"type:int;primaryKey;autoIncrement"`
+	TeamProgressID      int       `json:"team_progress_id" gorm:"type:int;not null;uniqueIndex:idx_progress_version"`
+	Version             int       `json:"version" gorm:"type:int;not null;uniqueIndex:idx_progress_version"`
+	GdriveLink          string    `json:"gdrive_link" gorm:"type:varchar(255)"`
+	FileKey             string    `json:"-" gorm:"type:varchar(255)"`
+	FileName            string    `json:"file_name" gorm:"type:varchar(255)"`
+	FileType            string    `json:"file_type" gorm:"type:varchar(100)"`
+	FileSize            int64     `json:"file_size" gorm:"type:bigint"`
+	SubmittedBy         uuid.UUID `json:"submitted_by" gorm:"type:varchar(36);not null"`
+	CreatedAt           time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -1,13 +1,34 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tahapan siklus hidup tim. Tim withdrawn dan disqualified tidak dihitung pada daftar maupun rekap admin.
+const (
+	TeamDraft        = "draft"
+	TeamRegistered   = "registered"
+	TeamLocked       = "locked"
+	TeamWithdrawn    = "withdrawn"
+	TeamDisqualified = "disqualified"
+)
 
 type Team struct {
-	TeamID        uuid.UUID `json:"team_id" gorm:"type:varchar(36);primaryKey"`
-	TeamName      string    `json:"team_name" gorm:"type:varchar(50);not null"`
-	TeamStatus    string    `json:"team_status" gorm:"type:enum('belum terverifikasi', 'terverifikasi', 'ditolak', 'diproses');not null"`
-	UserID        uuid.UUID `json:"user_id"`
-	CompetitionID int       `json:"competition_id"`
+	TeamID             uuid.UUID  `json:"team_id" gorm:"type:varchar(36);primaryKey"`
+	TeamName           string     `json:"team_name" gorm:"type:varchar(50);not null"`
+	TeamStatus         string     `json:"team_status" gorm:"type:enum('belum terverifikasi', 'terverifikasi', 'ditolak', 'diproses');not null"`
+	UserID             uuid.UUID  `json:"user_id"`
+	CompetitionID      int        `json:"competition_id"`
+	BasePrice          int64      `json:"base_price" gorm:"type:bigint;not null;default:0"`
+	Discount           int64      `json:"discount" gorm:"type:bigint;not null;default:0"`
+	AppliedPrice       int64      `json:"applied_price" gorm:"type:bigint;not null;default:0"`
+	VoucherID          *int       `json:"voucher_id" gorm:"type:int"`
+	PricedAt           *time.Time `json:"priced_at" gorm:"type:datetime"`
+	LifecycleStatus    string     `json:"lifecycle_status" gorm:"type:enum('draft', 'registered', 'locked', 'withdrawn', 'disqualified');not null;default:'draft';index"`
+	LifecycleReason    string     `json:"lifecycle_reason" gorm:"type:text"`
+	LifecycleChangedAt *time.Time `json:"lifecycle_changed_at" gorm:"type:datetime"`
 
 	TeamMembers    []TeamMember   `json:"team_members" gorm:"foreignKey:TeamID"`
 	TeamProgresses []TeamProgress `json:"team_progresses" gorm:"foreignKey:TeamID"`
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TeamInvitation struct {
+	InvitationID uuid.UUID  `json:"invitation_id" gorm:"type:varchar(36);primaryKey"`
+	TeamID       uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;index"`
+	Email        string     `json:"email" gorm:"type:varchar(50);not null;index"`
+	Status       string     `json:"status" gorm:"type:enum('pending', 'accepted', 'declined', 'cancelled', 'expired');not null;default:'pending'"`
+	InvitedBy    uuid.UUID  `json:"invited_by" gorm:"type:varchar(36);not null"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"type:datetime;not null"`
+	RespondedAt  *time.Time `json:"responded_at" gorm:"type:datetime"`
+	AcceptedBy   *uuid.UUID `json:"accepted_by" gorm:"type:varchar(36)"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	Team Team `json:"team" gorm:"foreignKey:TeamID"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TeamLifecycleHistory struct {
+	TeamLifecycleHistoryID int        `json:"team_lifecycle_history_id" gorm:"type:int;primaryKey;autoIncrement"`
+	TeamID                 uuid.UUID  `json:"team_id" gorm:"type:varchar(36);not null;index"`
+	FromStatus             string     `json:"from_status" gorm:"type:varchar(20)"`
+	ToStatus               string     `json:"to_status" gorm:"type:varchar(20);not null"`
+	Reason                 string     `json:"reason" gorm:"type:text"`
+	ChangedBy              *uuid.UUID `json:"changed_by" gorm:"type:varchar(36)"`
+	CreatedAt              time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
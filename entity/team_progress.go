@@ -7,11 +7,22 @@ import (
 )
 
 type TeamProgress struct {
-	TeamProgressID int       `json:"team_progress_id" gorm:"int;primaryKey;autoIncrement"`
-	StageID        int       `json:"stage_id"`
-	Status         string    `json:"status" gorm:"type:enum('diproses', 'lolos', 'tidak lolos');not null"`
-	TeamID         uuid.UUID `json:"team_id"`
-	GdriveLink     string    `json:"gdrive_link" gorm:"varchar(100);not null"`
-	CreatedAt      time.Time `json:"created_at"  gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `json:"updated_at"  gorm:"autoUpdateTime"`
+	TeamProgressID int        `json:"team_progress_id" gorm:"int;primaryKey;autoIncrement"`
+	StageID        int        `json:"stage_id"`
+	Status         string     `json:"status" gorm:"type:enum('diproses', 'lolos', 'tidak lolos');not null"`
+	TeamID         uuid.UUID  `json:"team_id"`
+	GdriveLink     string     `json:"gdrive_link" gorm:"varchar(100)"`
+	FileKey        string     `json:"-" gorm:"type:varchar(255)"`
+	FileName       string     `json:"file_name" gorm:"type:varchar(255)"`
+	FileType       string     `json:"file_type" gorm:"type:varchar(100)"`
+	FileSize       int64      `json:"file_size" gorm:"type:bigint"`
+	CurrentVersion int        `json:"current_version" gorm:"type:int;not null;default:1"`
+	DecidedBy      *uuid.UUID `json:"decided_by" gorm:"type:varchar(36)"`
+	DecisionNote   string     `json:"decision_note" gorm:"type:text"`
+	DecidedAt      *time.Time `json:"decided_at" gorm:"type:datetime"`
+	CreatedAt      time.Time  `json:"created_at"  gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at"  gorm:"autoUpdateTime"`
+
+	Versions         []SubmissionVersion `json:"-" gorm:"foreignKey:TeamProgressID"`
+	JudgeAssignments []JudgeAssignment   `json:"-" gorm:"foreignKey:TeamProgressID"`
 }
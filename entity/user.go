@@ -20,9 +20,11 @@ type User struct {
 	University       string    `json:"university" gorm:"type:varchar(80);"`
 	Major            string    `json:"major" gorm:"type:varchar(80);"`
 	RoleID           int       `json:"role_id"`
+	TokenVersion     int       `json:"-" gorm:"type:int;not null;default:0"`
 	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 
 	Team    Team      `json:"team" gorm:"foreignKey:UserID"`
 	OtpCode []OtpCode `json:"otp_code" gorm:"foreignKey:UserID"`
+	Roles   []Role    `json:"roles" gorm:"many2many:user_roles;joinForeignKey:UserID;joinReferences:RoleID"`
 }
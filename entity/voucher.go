@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+type Voucher struct {
+	VoucherID           int        `json:"voucher_id" gorm:"type:int;primaryKey;autoIncrement"`
+	Code                string     `json:"code" gorm:"type:varchar(30);not null;uniqueIndex"`
+	CompetitionID       *int       `json:"competition_id" gorm:"type:int"`
+	DiscountType        string     `json:"discount_type" gorm:"type:enum('percent', 'fixed');not null"`
+	DiscountValue       int64      `json:"discount_value" gorm:"type:bigint;not null"`
+	MaxUses             int        `json:"max_uses" gorm:"type:int;not null;default:0"`
+	UsedCount           int        `json:"used_count" gorm:"type:int;not null;default:0"`
+	ExpiresAt           *time.Time `json:"expires_at" gorm:"type:datetime"`
+	AllowedUniversities string     `json:"allowed_universities" gorm:"type:text"`
+	IsActive            bool       `json:"is_active" gorm:"type:boolean;not null;default:true"`
+	CreatedBy           uuid.UUID  `json:"created_by" gorm:"type:varchar(36)"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Redemptions []VoucherRedemption `json:"redemptions" gorm:"foreignKey:VoucherID"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoucherRedemption struct {
+	RedemptionID  int       `json:"redemption_id" gorm:"type:int;primaryKey;autoIncrement"`
+	VoucherID     int       `json:"voucher_id" gorm:"type:int;not null;index"`
+	TeamID        uuid.UUID `json:"team_id" gorm:"type:varchar(36);not null;uniqueIndex"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:varchar(36);not null"`
+	CompetitionID int       `json:"competition_id" gorm:"type:int;not null"`
+	BasePrice     int64     `json:"base_price" gorm:"type:bigint;not null"`
+	Discount      int64     `json:"discount" gorm:"type:bigint;not null"`
+	FinalPrice    int64     `json:"final_price" gorm:"type:bigint;not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -2,11 +2,13 @@ package rest
 
 import (
 	"errors"
+	"itfest-2025/entity"
 	"itfest-2025/model"
 	"itfest-2025/pkg/response"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 func (r *Rest) GetAnnouncement(c *gin.Context) {
@@ -16,26 +18,111 @@ func (r *Rest) GetAnnouncement(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "success to get announcement", data)	
+	response.Success(c, http.StatusOK, "success to get announcement", data)
+}
+
+func (r *Rest) GetMyAnnouncements(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	data, err := r.service.AnnouncementService.GetMyAnnouncements(user.UserID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to get announcement", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get announcement", data)
 }
 
 func (r *Rest) CreateAnnouncement(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
 	var req model.RequestAnnouncement
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
 		return
 	}
-	
-	err = r.service.AnnouncementService.SendAnnouncement(req)
+
+	res, err := r.service.AnnouncementService.SendAnnouncement(user.UserID, req)
+	if err != nil {
+		announcementError(c, "failed to create announcement", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to send announcement", res)
+}
+
+func (r *Rest) UpdateAnnouncement(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	announcementID, err := uuid.Parse(c.Param("announcement_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "announcement ID is invalid", err)
+		return
+	}
+
+	var req model.UpdateAnnouncementRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.AnnouncementService.UpdateAnnouncement(user.UserID, announcementID, req)
 	if err != nil {
-		if errors.Is(err, model.ErrUserRecordNotFound) {
-			response.Error(c, http.StatusNotFound, "User not found", err)
-			return
-		}
-		response.Error(c, http.StatusInternalServerError, "failed to create announcement", err)
+		announcementError(c, "failed to update announcement", err)
 		return
 	}
 
-	response.Success(c, http.StatusOK, "success to send announcement", nil)
-}
\ No newline at end of file
+	response.Success(c, http.StatusOK, "success to update announcement", res)
+}
+
+func (r *Rest) DeleteAnnouncement(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	announcementID, err := uuid.Parse(c.Param("announcement_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "announcement ID is invalid", err)
+		return
+	}
+
+	err = r.service.AnnouncementService.DeleteAnnouncement(user.UserID, announcementID)
+	if err != nil {
+		announcementError(c, "failed to delete announcement", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to delete announcement", nil)
+}
+
+func (r *Rest) GetAnnouncementRevisions(c *gin.Context) {
+	announcementID, err := uuid.Parse(c.Param("announcement_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "announcement ID is invalid", err)
+		return
+	}
+
+	res, err := r.service.AnnouncementService.GetRevisions(announcementID)
+	if err != nil {
+		announcementError(c, "failed to get announcement revisions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get announcement revisions", res)
+}
+
+func announcementError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrCompetitionNotFound) || errors.Is(err, model.ErrStageNotFound) || errors.Is(err, model.ErrAnnouncementNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrAnnouncementPublished) || errors.Is(err, model.ErrAnnouncementTargetLocked) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrStageNotInCompetition) || errors.Is(err, model.ErrSubmissionStatusNeedsStage) ||
+		errors.Is(err, model.ErrPublishAtRequired) || errors.Is(err, model.ErrPublishAtInPast) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) RefreshToken(c *gin.Context) {
+	var param model.RefreshTokenRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.AuthService.RefreshToken(param)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidRefreshToken) || errors.Is(err, model.ErrSessionRevoked) {
+			response.Error(c, http.StatusUnauthorized, "failed to refresh token", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to refresh token", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to refresh token", res)
+}
+
+func (r *Rest) Logout(c *gin.Context) {
+	var param model.RefreshTokenRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.AuthService.Logout(param)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidRefreshToken) {
+			response.Error(c, http.StatusUnauthorized, "failed to logout", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to logout", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to logout", nil)
+}
+
+func (r *Rest) RevokeUserSessions(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "user ID is invalid", err)
+		return
+	}
+
+	err = r.service.AuthService.RevokeUserSessions(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, http.StatusNotFound, "user not found", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to revoke user sessions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to revoke user sessions", nil)
+}
@@ -1,8 +1,11 @@
 package rest
 
 import (
+	"errors"
+	"itfest-2025/model"
 	"itfest-2025/pkg/response"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,3 +19,190 @@ func (r *Rest) GetAllCompetitions(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "success to get all competitions", competition)
 }
+
+func (r *Rest) GetAllCompetitionsAdmin(c *gin.Context) {
+	competitions, err := r.service.CompetitionService.GetAllCompetitionsAdmin()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to get competitions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get all competitions", competitions)
+}
+
+func (r *Rest) CreateCompetition(c *gin.Context) {
+	var param model.CreateCompetitionRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.CompetitionService.CreateCompetition(param)
+	if err != nil {
+		competitionError(c, "failed to create competition", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success to create competition", res)
+}
+
+func (r *Rest) UpdateCompetition(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	var param model.UpdateCompetitionRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.CompetitionService.UpdateCompetition(competitionID, param)
+	if err != nil {
+		competitionError(c, "failed to update competition", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to update competition", res)
+}
+
+func (r *Rest) ArchiveCompetition(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	var param model.ArchiveCompetitionRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.CompetitionService.ArchiveCompetition(competitionID, param)
+	if err != nil {
+		competitionError(c, "failed to archive competition", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to archive competition", nil)
+}
+
+func (r *Rest) AddStage(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	var param model.StageRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.CompetitionService.AddStage(competitionID, param)
+	if err != nil {
+		competitionError(c, "failed to add stage", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success to add stage", res)
+}
+
+func (r *Rest) UpdateStage(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	stageID, err := strconv.Atoi(c.Param("stage_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert stage id", err)
+		return
+	}
+
+	var param model.UpdateStageRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.CompetitionService.UpdateStage(competitionID, stageID, param)
+	if err != nil {
+		competitionError(c, "failed to update stage", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to update stage", res)
+}
+
+func (r *Rest) ReorderStages(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	var param model.ReorderStagesRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.CompetitionService.ReorderStages(competitionID, param)
+	if err != nil {
+		competitionError(c, "failed to reorder stages", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to reorder stages", res)
+}
+
+func (r *Rest) UpdateCompetitionRule(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	var param model.CompetitionRuleRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.CompetitionService.UpdateCompetitionRule(competitionID, param)
+	if err != nil {
+		competitionError(c, "failed to update competition rule", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to update competition rule", res)
+}
+
+func competitionError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrCompetitionNotFound) || errors.Is(err, model.ErrStageNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrCompetitionArchived) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrDuplicateStageOrder) || errors.Is(err, model.ErrStageDeadlineOrder) || errors.Is(err, model.ErrStageReorderMismatch) ||
+		errors.Is(err, model.ErrEarlyBirdWindow) || errors.Is(err, model.ErrRegistrationWindow) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
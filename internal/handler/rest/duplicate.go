@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) GetDuplicateReport(c *gin.Context) {
+	competitionID := 0
+	if idStr := c.Query("competition_id"); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+			return
+		}
+		competitionID = id
+	}
+
+	res, err := r.service.DuplicateService.GetDuplicateReport(competitionID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to get duplicate report", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get duplicate report", res)
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) GetOutboxEmails(c *gin.Context) {
+	res, err := r.service.OutboxService.GetEmails(c.Query("status"))
+	if err != nil {
+		outboxError(c, "failed to get outbox emails", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get outbox emails", res)
+}
+
+func (r *Rest) RequeueEmail(c *gin.Context) {
+	emailID, err := strconv.Atoi(c.Param("email_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert email id", err)
+		return
+	}
+
+	res, err := r.service.OutboxService.RequeueEmail(emailID)
+	if err != nil {
+		outboxError(c, "failed to requeue email", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to requeue email", res)
+}
+
+func (r *Rest) RequeueDeadEmails(c *gin.Context) {
+	res, err := r.service.OutboxService.RequeueDeadEmails()
+	if err != nil {
+		outboxError(c, "failed to requeue emails", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to requeue emails", res)
+}
+
+func outboxError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrEmailNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrEmailNotRequeueable) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrInvalidEmailStatus) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/pkg/mail"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) GetEmailTemplates(c *gin.Context) {
+	res, err := r.service.EmailTemplateService.GetEmailTemplates()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to get email templates", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get email templates", res)
+}
+
+// PreviewEmailTemplate mengembalikan JSON secara default, format=html atau format=text menampilkan hasil render apa adanya.
+func (r *Rest) PreviewEmailTemplate(c *gin.Context) {
+	res, err := r.service.EmailTemplateService.PreviewEmailTemplate(c.Param("name"))
+	if err != nil {
+		if errors.Is(err, mail.ErrTemplateNotFound) {
+			response.Error(c, http.StatusNotFound, "failed to preview email template", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to preview email template", err)
+		return
+	}
+
+	switch c.Query("format") {
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(res.HTML))
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(res.Text))
+	default:
+		response.Success(c, http.StatusOK, "success to preview email template", res)
+	}
+}
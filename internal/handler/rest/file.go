@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"itfest-2025/pkg/storage"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) GetFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	file, err := r.service.FileService.OpenSignedFile(key, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidSignature) {
+			response.Error(c, http.StatusForbidden, "failed to get file", err)
+			return
+		} else if errors.Is(err, storage.ErrFileNotFound) {
+			response.Error(c, http.StatusNotFound, "failed to get file", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to get file", err)
+		return
+	}
+	defer file.Close()
+
+	// tipe file dibaca dari isinya karena ekstensi key berasal dari nama file yang diunggah user
+	reader := bufio.NewReader(file)
+	head, err := reader.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusInternalServerError, "failed to get file", err)
+		return
+	}
+
+	contentType := http.DetectContentType(head)
+	headers := map[string]string{
+		"X-Content-Type-Options": "nosniff",
+	}
+	if !slices.Contains(model.DocumentFileTypes, strings.Split(contentType, ";")[0]) {
+		headers["Content-Disposition"] = "attachment"
+	}
+
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, headers)
+}
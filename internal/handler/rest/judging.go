@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) GetRubric(c *gin.Context) {
+	stageID, err := strconv.Atoi(c.Param("stage_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert stage id", err)
+		return
+	}
+
+	res, err := r.service.JudgingService.GetRubric(stageID)
+	if err != nil {
+		judgingError(c, "failed to get rubric", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get rubric", res)
+}
+
+func (r *Rest) SetRubric(c *gin.Context) {
+	stageID, err := strconv.Atoi(c.Param("stage_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert stage id", err)
+		return
+	}
+
+	var param model.RubricRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.JudgingService.SetRubric(stageID, param)
+	if err != nil {
+		judgingError(c, "failed to set rubric", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to set rubric", res)
+}
+
+func (r *Rest) AssignJudge(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	teamProgressID, err := strconv.Atoi(c.Param("team_progress_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert submission id", err)
+		return
+	}
+
+	var param model.AssignJudgeRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.JudgingService.AssignJudge(actor, teamProgressID, param)
+	if err != nil {
+		judgingError(c, "failed to assign judge", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to assign judge", res)
+}
+
+func (r *Rest) UnassignJudge(c *gin.Context) {
+	teamProgressID, err := strconv.Atoi(c.Param("team_progress_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert submission id", err)
+		return
+	}
+
+	judgeID, err := uuid.Parse(c.Param("judge_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "judge ID is invalid", err)
+		return
+	}
+
+	err = r.service.JudgingService.UnassignJudge(teamProgressID, judgeID)
+	if err != nil {
+		judgingError(c, "failed to unassign judge", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to unassign judge", nil)
+}
+
+func (r *Rest) GetMyAssignments(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.JudgingService.GetMyAssignments(user.UserID)
+	if err != nil {
+		judgingError(c, "failed to get assignments", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get assignments", res)
+}
+
+func (r *Rest) SubmitScores(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	assignmentID, err := uuid.Parse(c.Param("assignment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "assignment ID is invalid", err)
+		return
+	}
+
+	var param model.ScoreSubmissionRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.JudgingService.SubmitScores(user.UserID, assignmentID, param)
+	if err != nil {
+		judgingError(c, "failed to submit scores", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to submit scores", res)
+}
+
+func (r *Rest) GetStageRanking(c *gin.Context) {
+	stageID, err := strconv.Atoi(c.Param("stage_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert stage id", err)
+		return
+	}
+
+	res, err := r.service.JudgingService.GetStageRanking(stageID)
+	if err != nil {
+		judgingError(c, "failed to get stage ranking", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get stage ranking", res)
+}
+
+func (r *Rest) PassTopTeams(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	stageID, err := strconv.Atoi(c.Param("stage_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert stage id", err)
+		return
+	}
+
+	var param model.PassTopRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.JudgingService.PassTopTeams(actor, stageID, param)
+	if err != nil {
+		judgingError(c, "failed to pass top teams", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to pass top teams", res)
+}
+
+func judgingError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrStageNotFound) || errors.Is(err, model.ErrSubmissionNotFound) ||
+		errors.Is(err, model.ErrAssignmentNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrRubricLocked) || errors.Is(err, model.ErrAssignmentScored) || errors.Is(err, model.ErrSubmissionDecided) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrRubricEmpty) || errors.Is(err, model.ErrNotJudge) ||
+		errors.Is(err, model.ErrIncompleteScores) || errors.Is(err, model.ErrInvalidScore) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
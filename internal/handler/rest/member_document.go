@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) UploadMemberKTM(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	teamMemberID, err := uuid.Parse(c.Param("team_member_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team member ID is invalid", err)
+		return
+	}
+
+	ktmFile, err := c.FormFile("ktm")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "ktm is required", err)
+		return
+	}
+
+	err = r.service.DocumentService.UploadMemberKTM(user.UserID, teamMemberID, ktmFile)
+	if err != nil {
+		documentError(c, "failed to upload ktm", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to upload ktm", nil)
+}
+
+func (r *Rest) GetMyTeamDocuments(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.DocumentService.GetMyTeamDocuments(user.UserID)
+	if err != nil {
+		documentError(c, "failed to get team documents", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get team documents", res)
+}
+
+func (r *Rest) GetTeamDocuments(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	res, err := r.service.DocumentService.GetTeamDocuments(teamID)
+	if err != nil {
+		documentError(c, "failed to get team documents", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get team documents", res)
+}
+
+func (r *Rest) ReviewDocument(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	documentID, err := uuid.Parse(c.Param("document_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "document ID is invalid", err)
+		return
+	}
+
+	var param model.ReviewDocumentRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.DocumentService.ReviewDocument(actor, documentID, param)
+	if err != nil {
+		documentError(c, "failed to review document", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to review document", nil)
+}
+
+func documentError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrDocumentNotFound) || errors.Is(err, model.ErrMemberNotFound) ||
+		errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrDocumentForbidden) || errors.Is(err, model.ErrTeamLocked) || errors.Is(err, model.ErrTeamInactive) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	} else if errors.Is(err, model.ErrDocumentLocked) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrDocumentTooLarge) || errors.Is(err, model.ErrRejectionReasonRequired) ||
+		errors.Is(err, model.ErrDocumentFileType) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
@@ -34,7 +34,7 @@ func (r *Rest) ResendOtp(c *gin.Context) {
 }
 
 func (r *Rest) ResendOtpChangePassword(c *gin.Context) {
-	var req model.GetOtp
+	var req model.ForgotPasswordRequest
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
@@ -0,0 +1,193 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/payment"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) UploadPayment(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	paymentFile, err := c.FormFile("payment")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "payment proof is required", err)
+		return
+	}
+
+	var param model.UploadPaymentRequest
+	err = c.ShouldBind(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.PaymentService.SubmitPayment(user.UserID, paymentFile, param)
+	if err != nil {
+		if errors.Is(err, model.ErrPaymentFileTooLarge) {
+			response.Error(c, http.StatusBadRequest, "please reduce the file size", err)
+			return
+		}
+		paymentError(c, "failed to upload payment", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to upload payment", res)
+}
+
+func (r *Rest) GetMyPayment(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.PaymentService.GetMyPayment(user.UserID)
+	if err != nil {
+		paymentError(c, "failed to get payment", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get payment", res)
+}
+
+func (r *Rest) GetTeamPayment(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	res, err := r.service.PaymentService.GetTeamPayment(teamID)
+	if err != nil {
+		paymentError(c, "failed to get payment", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get payment", res)
+}
+
+func (r *Rest) UpdateTeamStatus(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	var req model.ReqUpdateStatusTeam
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.PaymentService.VerifyPayment(actor, teamID, req)
+	if err != nil {
+		paymentError(c, "failed to update team status", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success update team status", nil)
+}
+
+func (r *Rest) CreatePaymentCharge(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.PaymentService.CreateCharge(user.UserID)
+	if err != nil {
+		paymentError(c, "failed to create payment charge", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success to create payment charge", res)
+}
+
+func (r *Rest) PaymentWebhook(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to read webhook body", err)
+		return
+	}
+
+	err = r.service.PaymentService.HandleWebhook(c.Request.Header, body)
+	if err != nil {
+		paymentError(c, "failed to handle payment webhook", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to handle payment webhook", nil)
+}
+
+func (r *Rest) GetPaymentSimulator(c *gin.Context) {
+	res, err := r.service.PaymentService.GetSimulatorCharge(c.Param("order_id"))
+	if err != nil {
+		paymentError(c, "failed to get payment simulator", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.String(http.StatusOK, fmt.Sprintf(`<!DOCTYPE html>
+<html lang="id">
+<head><title>Payment Simulator</title></head>
+<body style="font-family: Arial, sans-serif;">
+	<h2>Payment Simulator</h2>
+	<p>Order: %s</p>
+	<p>Amount: Rp%d</p>
+	<p>Status: %s</p>
+	<form method="POST"><input type="hidden" name="status" value="paid"><button type="submit">Bayar</button></form>
+	<form method="POST"><input type="hidden" name="status" value="failed"><button type="submit">Gagalkan</button></form>
+</body>
+</html>`, html.EscapeString(res.OrderID), res.Amount, html.EscapeString(res.Status)))
+}
+
+func (r *Rest) SimulatePayment(c *gin.Context) {
+	var param model.SimulatePaymentRequest
+	err := c.ShouldBind(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.PaymentService.SimulatePayment(c.Param("order_id"), param)
+	if err != nil {
+		paymentError(c, "failed to simulate payment", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to simulate payment", nil)
+}
+
+func paymentError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrPaymentNotFound) || errors.Is(err, model.ErrChargeNotFound) ||
+		errors.Is(err, model.ErrSimulatorDisabled) || errors.Is(err, model.ErrCompetitionNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, payment.ErrGatewayDisabled) {
+		response.Error(c, http.StatusServiceUnavailable, message, err)
+		return
+	} else if errors.Is(err, payment.ErrInvalidSignature) {
+		response.Error(c, http.StatusUnauthorized, message, err)
+		return
+	} else if errors.Is(err, model.ErrTeamInactive) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	} else if errors.Is(err, model.ErrRejectionReasonRequired) || errors.Is(err, model.ErrNoRegistrationFee) ||
+		errors.Is(err, model.ErrChargeAmountMismatch) || errors.Is(err, model.ErrPaymentProofRequired) ||
+		errors.Is(err, model.ErrPaymentAmountMismatch) || errors.Is(err, model.ErrPaymentTeamUnregistered) ||
+		errors.Is(err, model.ErrDocumentFileType) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	} else if errors.Is(err, model.ErrPaymentAlreadyVerified) || errors.Is(err, model.ErrPaymentTransition) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
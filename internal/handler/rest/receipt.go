@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) GetMyReceipt(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	receipt, err := r.service.ReceiptService.GetMyReceipt(user.UserID)
+	if err != nil {
+		receiptError(c, "failed to get receipt", err)
+		return
+	}
+
+	sendReceipt(c, receipt)
+}
+
+func (r *Rest) GetTeamReceipt(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	receipt, err := r.service.ReceiptService.GetTeamReceipt(teamID)
+	if err != nil {
+		receiptError(c, "failed to get receipt", err)
+		return
+	}
+
+	sendReceipt(c, receipt)
+}
+
+func sendReceipt(c *gin.Context, receipt *model.ReceiptFile) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", receipt.FileName))
+	c.Data(http.StatusOK, "application/pdf", receipt.Content)
+}
+
+func receiptError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrReceiptNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
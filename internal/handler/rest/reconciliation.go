@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (r *Rest) ImportBankStatement(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	statement, err := c.FormFile("statement")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "bank statement file is required", err)
+		return
+	}
+
+	res, err := r.service.ReconciliationService.ImportStatement(actor, statement)
+	if err != nil {
+		reconciliationError(c, "failed to import bank statement", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success to import bank statement", res)
+}
+
+func (r *Rest) GetReconciliations(c *gin.Context) {
+	res, err := r.service.ReconciliationService.GetReconciliations()
+	if err != nil {
+		reconciliationError(c, "failed to get reconciliations", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get reconciliations", res)
+}
+
+func (r *Rest) GetReconciliation(c *gin.Context) {
+	batchID, err := uuid.Parse(c.Param("batch_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "batch ID is invalid", err)
+		return
+	}
+
+	res, err := r.service.ReconciliationService.GetReconciliation(batchID)
+	if err != nil {
+		reconciliationError(c, "failed to get reconciliation", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get reconciliation", res)
+}
+
+func (r *Rest) ConfirmReconciliation(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	batchID, err := uuid.Parse(c.Param("batch_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "batch ID is invalid", err)
+		return
+	}
+
+	var param model.ConfirmReconciliationRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.ReconciliationService.ConfirmReconciliation(actor, batchID, param)
+	if err != nil {
+		reconciliationError(c, "failed to confirm reconciliation", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to confirm reconciliation", res)
+}
+
+func reconciliationError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrReconciliationNotFound) || errors.Is(err, model.ErrReconciliationLineNotFound) ||
+		errors.Is(err, model.ErrPaymentNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrReconciliationConfirmed) || errors.Is(err, model.ErrPaymentAlreadyVerified) ||
+		errors.Is(err, model.ErrReconciliationDuplicateTeam) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrStatementTooLarge) || errors.Is(err, model.ErrStatementFormat) ||
+		errors.Is(err, model.ErrStatementHeader) || errors.Is(err, model.ErrStatementEmpty) ||
+		errors.Is(err, model.ErrReconciliationNotCandidate) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) GetCompetitionForm(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	res, err := r.service.FormService.GetCompetitionForm(competitionID)
+	if err != nil {
+		formError(c, "failed to get registration form", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get registration form", res)
+}
+
+func (r *Rest) UpdateCompetitionForm(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	var param model.UpdateRegistrationFormRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.FormService.UpdateCompetitionForm(competitionID, param)
+	if err != nil {
+		formError(c, "failed to update registration form", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to update registration form", res)
+}
+
+func (r *Rest) GetMyRegistrationForm(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	res, err := r.service.FormService.GetMyRegistrationForm(user.UserID, competitionID)
+	if err != nil {
+		formError(c, "failed to get registration form", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get registration form", res)
+}
+
+func (r *Rest) UploadFormFile(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "file is required", err)
+		return
+	}
+
+	res, err := r.service.FormService.UploadFormFile(user.UserID, competitionID, c.Param("field_key"), file)
+	if err != nil {
+		formError(c, "failed to upload form file", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to upload form file", res)
+}
+
+func formError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrFormFieldNotFound) || errors.Is(err, model.ErrCompetitionNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrNotTeamLeader) || errors.Is(err, model.ErrTeamLocked) || errors.Is(err, model.ErrTeamInactive) ||
+		errors.Is(err, model.ErrFormTeamMismatch) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	} else if errors.Is(err, model.ErrCompetitionArchived) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrDuplicateFieldKey) || errors.Is(err, model.ErrInvalidFormField) || errors.Is(err, model.ErrFormFileNotAllowed) ||
+		errors.Is(err, model.ErrFormFileTooLarge) || errors.Is(err, model.ErrFormFieldNotAFile) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
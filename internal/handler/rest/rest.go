@@ -1,10 +1,15 @@
 package rest
 
 import (
+	"context"
 	"fmt"
+	"itfest-2025/entity"
 	"itfest-2025/internal/service"
 	"itfest-2025/pkg/middleware"
+	paymentProvider "itfest-2025/pkg/payment"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,12 +34,22 @@ func (r *Rest) MountEndpoint() {
 
 	routerGroup := r.router.Group("api/v1")
 	routerGroup.GET("/competitions", r.GetAllCompetitions)
+	routerGroup.GET("/files/*key", r.GetFile)
+
+	payment := routerGroup.Group("/payments")
+	payment.POST("/webhook", r.PaymentWebhook)
+	if paymentProvider.SimulatorEnabled() {
+		payment.GET("/simulator/:order_id", r.GetPaymentSimulator)
+		payment.POST("/simulator/:order_id", r.SimulatePayment)
+	}
 
 	auth := routerGroup.Group("/auth")
 	auth.POST("/register", r.Register)
 	auth.PATCH("/register", r.VerifyUser)
 	auth.PATCH("/register/resend", r.ResendOtp)
 	auth.POST("/login", r.Login)
+	auth.POST("/refresh", r.RefreshToken)
+	auth.POST("/logout", r.Logout)
 	auth.POST("/forgot-password", r.ChangePassword)
 	auth.POST("/verify-otp", r.VerifyOtpChangePassword)
 	auth.POST("/reset-password", r.ChangePasswordAfterVerify)
@@ -46,49 +61,167 @@ func (r *Rest) MountEndpoint() {
 	user.GET("/my-team-info", r.GetTeamInfo)
 	user.GET("/my-team-profile", r.GetMyTeamProfile)
 	user.GET("/progress", r.GetProgressByUserID)
-	user.GET("/announcement", r.GetAnnouncement)
+	user.GET("/announcement", r.GetMyAnnouncements)
+	user.GET("/payment", r.GetMyPayment)
+	user.GET("/payment/receipt", r.GetMyReceipt)
+	user.POST("/payment/charge", r.CreatePaymentCharge)
 	user.POST("/upload-payment", r.UploadPayment)
 	user.POST("/change-password", r.ChangePassword)
 	user.POST("/verify-token", r.VerifyOtpChangePassword)
 	user.PATCH("/update-profile", r.UpdateProfile)
 	user.PATCH("/upsert-team", r.UpsertTeam)
+	user.POST("/team/invitations", r.InviteMember)
+	user.GET("/team/invitations", r.GetTeamInvitations)
+	user.DELETE("/team/invitations/:invitation_id", r.CancelInvitation)
+	user.GET("/team/documents", r.GetMyTeamDocuments)
+	user.POST("/team/members/:team_member_id/ktm", r.UploadMemberKTM)
+	user.POST("/team/withdraw", r.WithdrawTeam)
+	user.POST("/team/transfer-leadership", r.TransferLeadership)
+	user.DELETE("/team", r.DissolveTeam)
+	user.GET("/invitations", r.GetMyInvitations)
+	user.POST("/invitations/:invitation_id/accept", r.AcceptInvitation)
+	user.POST("/invitations/:invitation_id/decline", r.DeclineInvitation)
 	user.PATCH("/change-password", r.ChangePasswordAfterVerify)
 
 	submission := routerGroup.Group("/submissions")
 	submission.Use(r.middleware.AuthenticateUser)
 	submission.GET("/", r.GetSubmission)
 	submission.GET("/stage", r.GetCurrentStage)
+	submission.GET("/history", r.GetSubmissionHistory)
 	submission.POST("/", r.CreateSubmission)
 
+	judge := routerGroup.Group("/judge")
+	judge.Use(r.middleware.AuthenticateUser, r.middleware.RequirePermission(entity.PermissionSubmissionsGrade))
+	judge.GET("/assignments", r.GetMyAssignments)
+	judge.PUT("/assignments/:assignment_id/scores", r.SubmitScores)
+
 	competition := routerGroup.Group("/competitions")
 	competition.Use(r.middleware.AuthenticateUser)
 	competition.POST("/upload-ktm", r.UploadKTM)
+	competition.GET("/price/:competition_id", r.GetRegistrationPrice)
 	competition.POST("/register/:competition_id", r.CompetitionRegistration)
+	competition.GET("/waitlist", r.GetMyWaitlist)
+	competition.DELETE("/waitlist", r.LeaveWaitlist)
+	competition.GET("/form/:competition_id", r.GetMyRegistrationForm)
+	competition.POST("/form/:competition_id/files/:field_key", r.UploadFormFile)
 
 	admin := routerGroup.Group("/admin")
-	admin.Use(r.middleware.AuthenticateUser, r.middleware.OnlyAdmin)
-	admin.GET("/payment-status", r.GetUserPaymentStatus)
-	admin.GET("/total-participants", r.GetTotalParticipant)
-	admin.GET("/count", r.GetCount)
-	admin.GET("/teams", r.GetAllTeam)
-	admin.GET("/teams/:team_id", r.GetTeamByID)
-	admin.GET("/teams/:team_id/progress", r.GetTeamByIDProgress)
-	admin.PATCH("/teams/:team_id/progress/:stage_id", r.UpdateStatusSubmission)
-	admin.PATCH("/teams/:team_id", r.UpdateTeamStatus)
-
-	announcement := admin.Group("/announcement")
+	admin.Use(r.middleware.AuthenticateUser)
+
+	adminTeam := admin.Group("", r.middleware.RequirePermission(entity.PermissionTeamsRead))
+	adminTeam.GET("/total-participants", r.GetTotalParticipant)
+	adminTeam.GET("/count", r.GetCount)
+	adminTeam.GET("/teams", r.GetAllTeam)
+	adminTeam.GET("/teams/:team_id", r.GetTeamByID)
+	adminTeam.GET("/teams/:team_id/progress", r.GetTeamByIDProgress)
+	adminTeam.GET("/teams/:team_id/documents", r.GetTeamDocuments)
+	adminTeam.GET("/teams/:team_id/lifecycle", r.GetTeamLifecycle)
+	adminTeam.GET("/duplicates", r.GetDuplicateReport)
+
+	adminTeamWrite := admin.Group("", r.middleware.RequirePermission(entity.PermissionTeamsWrite))
+	adminTeamWrite.PATCH("/documents/:document_id", r.ReviewDocument)
+	adminTeamWrite.PATCH("/teams/:team_id/lifecycle", r.UpdateTeamLifecycle)
+	adminTeamWrite.POST("/teams/:team_id/transfer-leadership", r.TransferTeamLeadership)
+	adminTeamWrite.DELETE("/teams/:team_id", r.DissolveTeamByID)
+
+	adminPayment := admin.Group("", r.middleware.RequirePermission(entity.PermissionPaymentsRead))
+	adminPayment.GET("/payment-status", r.GetUserPaymentStatus)
+	adminPayment.GET("/teams/:team_id/payment", r.GetTeamPayment)
+	adminPayment.GET("/teams/:team_id/payment/receipt", r.GetTeamReceipt)
+
+	adminPaymentVerify := admin.Group("", r.middleware.RequirePermission(entity.PermissionPaymentsVerify))
+	adminPaymentVerify.PATCH("/teams/:team_id", r.UpdateTeamStatus)
+	adminPaymentVerify.POST("/reconciliations", r.ImportBankStatement)
+	adminPaymentVerify.GET("/reconciliations", r.GetReconciliations)
+	adminPaymentVerify.GET("/reconciliations/:batch_id", r.GetReconciliation)
+	adminPaymentVerify.POST("/reconciliations/:batch_id/confirm", r.ConfirmReconciliation)
+
+	adminSubmissionDecide := admin.Group("", r.middleware.RequirePermission(entity.PermissionSubmissionsDecide))
+	adminSubmissionDecide.PATCH("/teams/:team_id/progress/:stage_id", r.UpdateStatusSubmission)
+	adminSubmissionDecide.POST("/submissions/:team_progress_id/judges", r.AssignJudge)
+	adminSubmissionDecide.DELETE("/submissions/:team_progress_id/judges/:judge_id", r.UnassignJudge)
+	adminSubmissionDecide.POST("/stages/:stage_id/pass-top", r.PassTopTeams)
+
+	adminSubmission := admin.Group("", r.middleware.RequirePermission(entity.PermissionSubmissionsRead))
+	adminSubmission.GET("/teams/:team_id/submissions", r.GetTeamSubmissionHistory)
+	adminSubmission.GET("/stages/:stage_id/rubric", r.GetRubric)
+	adminSubmission.GET("/stages/:stage_id/ranking", r.GetStageRanking)
+
+	adminUser := admin.Group("/users", r.middleware.RequirePermission(entity.PermissionUsersManage))
+	adminUser.POST("/:user_id/revoke-sessions", r.RevokeUserSessions)
+
+	adminRole := admin.Group("", r.middleware.RequirePermission(entity.PermissionRolesManage))
+	adminRole.GET("/roles", r.GetRoles)
+	adminRole.POST("/users/:user_id/roles", r.GrantRole)
+	adminRole.DELETE("/users/:user_id/roles/:role_id", r.RevokeRole)
+
+	adminCompetition := admin.Group("/competitions")
+	adminCompetition.GET("/", r.middleware.RequirePermission(entity.PermissionCompetitionsRead), r.GetAllCompetitionsAdmin)
+	adminCompetition.GET("/:competition_id/waitlist", r.middleware.RequirePermission(entity.PermissionCompetitionsRead), r.GetCompetitionWaitlist)
+	adminCompetition.GET("/:competition_id/form", r.middleware.RequirePermission(entity.PermissionCompetitionsRead), r.GetCompetitionForm)
+
+	adminCompetitionManage := adminCompetition.Group("", r.middleware.RequirePermission(entity.PermissionCompetitionsManage))
+	adminCompetitionManage.POST("/", r.CreateCompetition)
+	adminCompetitionManage.PATCH("/:competition_id", r.UpdateCompetition)
+	adminCompetitionManage.PATCH("/:competition_id/archive", r.ArchiveCompetition)
+	adminCompetitionManage.PUT("/:competition_id/rules", r.UpdateCompetitionRule)
+	adminCompetitionManage.PUT("/:competition_id/form", r.UpdateCompetitionForm)
+	adminCompetitionManage.POST("/:competition_id/stages", r.AddStage)
+	adminCompetitionManage.PATCH("/:competition_id/stages/:stage_id", r.UpdateStage)
+	adminCompetitionManage.PUT("/:competition_id/stages/order", r.ReorderStages)
+
+	adminVoucher := admin.Group("/vouchers")
+	adminVoucher.GET("/", r.middleware.RequirePermission(entity.PermissionCompetitionsRead), r.GetVouchers)
+	adminVoucher.GET("/:voucher_id", r.middleware.RequirePermission(entity.PermissionCompetitionsRead), r.GetVoucher)
+	adminVoucher.POST("/", r.middleware.RequirePermission(entity.PermissionCompetitionsManage), r.CreateVoucher)
+	adminVoucher.PATCH("/:voucher_id", r.middleware.RequirePermission(entity.PermissionCompetitionsManage), r.UpdateVoucher)
+
+	adminRubric := admin.Group("", r.middleware.RequirePermission(entity.PermissionCompetitionsManage))
+	adminRubric.PUT("/stages/:stage_id/rubric", r.SetRubric)
+
+	announcement := admin.Group("/announcement", r.middleware.RequirePermission(entity.PermissionAnnouncementsManage))
 	announcement.GET("/", r.GetAnnouncement)
 	announcement.POST("/", r.CreateAnnouncement)
+	announcement.PUT("/:announcement_id", r.UpdateAnnouncement)
+	announcement.DELETE("/:announcement_id", r.DeleteAnnouncement)
+	announcement.GET("/:announcement_id/revisions", r.GetAnnouncementRevisions)
+
+	email := admin.Group("/emails", r.middleware.RequirePermission(entity.PermissionAnnouncementsManage))
+	email.GET("/templates", r.GetEmailTemplates)
+	email.GET("/templates/:name/preview", r.PreviewEmailTemplate)
+	email.GET("/outbox", r.GetOutboxEmails)
+	email.POST("/outbox/requeue", r.RequeueDeadEmails)
+	email.POST("/outbox/:email_id/requeue", r.RequeueEmail)
 
-	excel := admin.Group("/excel")
+	excel := admin.Group("/excel", r.middleware.RequirePermission(entity.PermissionExportsRead))
 	excel.GET("/data-payment", r.GetExportPayment)
 	excel.GET("/data-team", r.GetExportTeam)
 	excel.GET("/data-competition", r.GetExportCompetitionID)
 }
 
-func (r *Rest) Run() {
+// Run menjalankan server sampai ctx dibatalkan, request yang sedang berjalan diberi waktu selesai sebelum server ditutup.
+func (r *Rest) Run(ctx context.Context) error {
 	addr := os.Getenv("ADDRESS")
 	port := os.Getenv("PORT")
 
-	r.router.Run(fmt.Sprintf("%s:%s", addr, port))
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%s", addr, port),
+		Handler: r.router,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
 }
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) GetRoles(c *gin.Context) {
+	roles, err := r.service.RoleService.GetRoles()
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to get roles", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get roles", roles)
+}
+
+func (r *Rest) GrantRole(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "user ID is invalid", err)
+		return
+	}
+
+	var param model.GrantRoleRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.RoleService.GrantRole(actor, userID, param)
+	if err != nil {
+		roleError(c, "failed to grant role", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to grant role", res)
+}
+
+func (r *Rest) RevokeRole(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "user ID is invalid", err)
+		return
+	}
+
+	roleID, err := strconv.Atoi(c.Param("role_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert role id", err)
+		return
+	}
+
+	res, err := r.service.RoleService.RevokeRole(actor, userID, roleID)
+	if err != nil {
+		roleError(c, "failed to revoke role", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to revoke role", res)
+}
+
+func roleError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrRoleNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrParticipantRoleChange) || errors.Is(err, model.ErrSelfRoleRevoke) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
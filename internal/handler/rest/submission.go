@@ -8,6 +8,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 func (r *Rest) GetSubmission(c *gin.Context) {
@@ -29,7 +31,7 @@ func (r *Rest) GetSubmission(c *gin.Context) {
 
 func (r *Rest) GetCurrentStage(c *gin.Context) {
 	user := c.MustGet("user").(*entity.User)
-	
+
 	data, err := r.service.SubmissionService.GetCurrentStage(user.UserID)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "failed to get current stage", err)
@@ -42,18 +44,27 @@ func (r *Rest) GetCurrentStage(c *gin.Context) {
 func (r *Rest) CreateSubmission(c *gin.Context) {
 	param := model.ReqSubmission{}
 	user := c.MustGet("user").(*entity.User)
-	
+
 	err := c.ShouldBind(&param)
 	if err != nil {
 		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
 		return
 	}
 
-	err = r.service.SubmissionService.CreateSubmission(user.UserID, &param)
+	file, err := c.FormFile("file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) && !errors.Is(err, http.ErrNotMultipart) {
+		response.Error(c, http.StatusBadRequest, "failed to read submission file", err)
+		return
+	}
+
+	err = r.service.SubmissionService.CreateSubmission(user.UserID, &param, file)
 	if err != nil {
 		if errors.Is(err, model.ErrUnverifiedAccount) {
 			response.Error(c, http.StatusForbidden, "Status team ditolak atau belum diverifikasi", err)
 			return
+		} else if errors.Is(err, model.ErrTeamInactive) {
+			response.Error(c, http.StatusForbidden, "tim sudah mundur atau didiskualifikasi", err)
+			return
 		} else if errors.Is(err, model.ErrNotPassedPrevious) {
 			response.Error(c, http.StatusUnprocessableEntity, "submission failed", err)
 			return
@@ -63,6 +74,18 @@ func (r *Rest) CreateSubmission(c *gin.Context) {
 		} else if errors.Is(err, model.ErrSubmissionProcessing) {
 			response.Error(c, http.StatusConflict, "submission sedang diproses", err)
 			return
+		} else if errors.Is(err, model.ErrSubmissionContent) || errors.Is(err, model.ErrFileTypeNotAllowed) {
+			response.Error(c, http.StatusBadRequest, "submission tidak valid", err)
+			return
+		} else if errors.Is(err, model.ErrFileTooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "ukuran file terlalu besar", err)
+			return
+		} else if errors.Is(err, model.ErrSameSubmissionLink) {
+			response.Error(c, http.StatusConflict, "submission sama dengan versi aktif", err)
+			return
+		} else if errors.Is(err, model.ErrSubmissionDecided) {
+			response.Error(c, http.StatusConflict, "submission sudah dinilai", err)
+			return
 		} else if errors.Is(err, model.ErrPassedDeadline) {
 			response.Error(c, http.StatusGone, "submission melewati deadline", err)
 			return
@@ -74,7 +97,45 @@ func (r *Rest) CreateSubmission(c *gin.Context) {
 	response.Success(c, http.StatusCreated, "success to create new submission", nil)
 }
 
+func (r *Rest) GetSubmissionHistory(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	data, err := r.service.SubmissionService.GetSubmissionHistory(user.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, http.StatusNotFound, "team not found", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to get submission history", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get submission history", data)
+}
+
+func (r *Rest) GetTeamSubmissionHistory(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	data, err := r.service.SubmissionService.GetTeamSubmissionHistory(teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, http.StatusNotFound, "team not found", err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to get submission history", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get submission history", data)
+}
+
 func (r *Rest) UpdateStatusSubmission(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
 	var req model.RequestUpdateStatusSubmission
 	teamID := c.Param("team_id")
 	stageID := c.Param("stage_id")
@@ -99,11 +160,11 @@ func (r *Rest) UpdateStatusSubmission(c *gin.Context) {
 		return
 	}
 
-	err = r.service.SubmissionService.UpdateStatusSubmission(teamID, stageID, &req)
+	err = r.service.SubmissionService.UpdateStatusSubmission(user.UserID, teamID, stageID, &req)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "failed to update team status", err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "success update team status", nil)
-}
\ No newline at end of file
+}
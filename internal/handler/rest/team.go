@@ -24,9 +24,18 @@ func (r *Rest) UpsertTeam(c *gin.Context) {
 
 	res, err := r.service.TeamService.UpsertTeam(user.UserID, &param)
 	if err != nil {
-		if err.Error() == "maximum of 2 team members allowed" {
+		if errors.Is(err, model.ErrTeamMemberLimit) {
 			response.Error(c, http.StatusBadRequest, "cannot add another team member", err)
 			return
+		} else if errors.Is(err, model.ErrDuplicateStudentNumber) {
+			response.Error(c, http.StatusConflict, "student number is already used", err)
+			return
+		} else if errors.Is(err, model.ErrAlreadyInTeam) {
+			response.Error(c, http.StatusConflict, "cannot create another team", err)
+			return
+		} else if errors.Is(err, model.ErrTeamLocked) || errors.Is(err, model.ErrTeamInactive) {
+			response.Error(c, http.StatusForbidden, "team can no longer be changed", err)
+			return
 		} else if err.Error() == "team name already exists" {
 			response.Error(c, http.StatusBadRequest, "cannot use this team name", err)
 			return
@@ -62,30 +71,6 @@ func (r *Rest) GetAllTeam(c *gin.Context) {
 	response.Success(c, http.StatusOK, "success get all team informations", res)
 }
 
-func (r *Rest) UpdateTeamStatus(c *gin.Context) {
-	var req model.ReqUpdateStatusTeam
-	teamID := c.Param("team_id")
-
-	if teamID == "" {
-		response.Error(c, http.StatusBadRequest, "team ID is required", nil)
-		return
-	}
-
-	err := c.ShouldBindJSON(&req)
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
-		return
-	}
-
-	err = r.service.TeamService.UpdateTeamStatus(teamID, req)
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "failed to update team status", err)
-		return
-	}
-
-	response.Success(c, http.StatusOK, "success update team status", nil)
-}
-
 func (r *Rest) GetTeamByID(c *gin.Context) {
 	teamIDParam := c.Param("team_id")
 
@@ -136,4 +121,4 @@ func (r *Rest) GetProgressByUserID(c *gin.Context) {
 	}
 
 	response.Success(c, http.StatusOK, "success get progress team", data)
-}
\ No newline at end of file
+}
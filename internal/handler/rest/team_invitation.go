@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (r *Rest) InviteMember(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	var param model.InviteMemberRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.InvitationService.InviteMember(user.UserID, param)
+	if err != nil {
+		invitationError(c, "failed to invite member", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success to invite member", res)
+}
+
+func (r *Rest) GetTeamInvitations(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.InvitationService.GetTeamInvitations(user.UserID)
+	if err != nil {
+		invitationError(c, "failed to get team invitations", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get team invitations", res)
+}
+
+func (r *Rest) CancelInvitation(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invitation ID is invalid", err)
+		return
+	}
+
+	err = r.service.InvitationService.CancelInvitation(user.UserID, invitationID)
+	if err != nil {
+		invitationError(c, "failed to cancel invitation", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to cancel invitation", nil)
+}
+
+func (r *Rest) GetMyInvitations(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.InvitationService.GetMyInvitations(user.UserID)
+	if err != nil {
+		invitationError(c, "failed to get invitations", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get invitations", res)
+}
+
+func (r *Rest) AcceptInvitation(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invitation ID is invalid", err)
+		return
+	}
+
+	err = r.service.InvitationService.AcceptInvitation(user.UserID, invitationID)
+	if err != nil {
+		invitationError(c, "failed to accept invitation", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to accept invitation", nil)
+}
+
+func (r *Rest) DeclineInvitation(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invitation ID is invalid", err)
+		return
+	}
+
+	err = r.service.InvitationService.DeclineInvitation(user.UserID, invitationID)
+	if err != nil {
+		invitationError(c, "failed to decline invitation", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to decline invitation", nil)
+}
+
+func invitationError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrInvitationNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrNotTeamLeader) || errors.Is(err, model.ErrInvitationEmailMismatch) ||
+		errors.Is(err, model.ErrTeamLocked) || errors.Is(err, model.ErrTeamInactive) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	} else if errors.Is(err, model.ErrInvitationDuplicate) || errors.Is(err, model.ErrAlreadyInTeam) ||
+		errors.Is(err, model.ErrInvitationNotPending) || errors.Is(err, model.ErrInvitationExpired) ||
+		errors.Is(err, model.ErrDuplicateStudentNumber) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrInviteSelf) || errors.Is(err, model.ErrTeamMemberLimit) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) TransferLeadership(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	var param model.TransferLeadershipRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.LeadershipService.TransferLeadership(user.UserID, param)
+	if err != nil {
+		leadershipError(c, "failed to transfer leadership", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to transfer leadership", nil)
+}
+
+func (r *Rest) DissolveTeam(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	err := r.service.LeadershipService.DissolveTeam(user.UserID)
+	if err != nil {
+		leadershipError(c, "failed to dissolve team", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to dissolve team", nil)
+}
+
+func (r *Rest) TransferTeamLeadership(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	var param model.TransferLeadershipRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	err = r.service.LeadershipService.TransferTeamLeadership(teamID, param)
+	if err != nil {
+		leadershipError(c, "failed to transfer leadership", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to transfer leadership", nil)
+}
+
+func (r *Rest) DissolveTeamByID(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	err = r.service.LeadershipService.DissolveTeamByID(teamID)
+	if err != nil {
+		leadershipError(c, "failed to dissolve team", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to dissolve team", nil)
+}
+
+func leadershipError(c *gin.Context, message string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrNotTeamLeader) || errors.Is(err, model.ErrTeamLocked) || errors.Is(err, model.ErrTeamInactive) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	} else if errors.Is(err, model.ErrAlreadyInTeam) || errors.Is(err, model.ErrTeamNotDissolvable) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrNewLeaderNotMember) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
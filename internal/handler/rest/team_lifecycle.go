@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Rest) WithdrawTeam(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	var param model.WithdrawTeamRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.LifecycleService.WithdrawTeam(user.UserID, param)
+	if err != nil {
+		lifecycleError(c, "failed to withdraw team", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to withdraw team", res)
+}
+
+func (r *Rest) GetTeamLifecycle(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	res, err := r.service.LifecycleService.GetTeamLifecycle(teamID)
+	if err != nil {
+		lifecycleError(c, "failed to get team lifecycle", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get team lifecycle", res)
+}
+
+func (r *Rest) UpdateTeamLifecycle(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	teamID, err := uuid.Parse(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "team ID is invalid", err)
+		return
+	}
+
+	var param model.UpdateLifecycleRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.LifecycleService.UpdateLifecycle(user, teamID, param)
+	if err != nil {
+		lifecycleError(c, "failed to update team lifecycle", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to update team lifecycle", res)
+}
+
+func lifecycleError(c *gin.Context, message string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrNotTeamLeader) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	} else if errors.Is(err, model.ErrInvalidLifecycleTransition) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrLifecycleReasonRequired) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
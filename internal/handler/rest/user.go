@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"itfest-2025/entity"
 	"itfest-2025/model"
 	"itfest-2025/pkg/response"
@@ -57,29 +58,6 @@ func (r *Rest) Login(c *gin.Context) {
 	response.Success(c, http.StatusOK, "success to login user", result)
 }
 
-func (r *Rest) UploadPayment(c *gin.Context) {
-	user := c.MustGet("user").(*entity.User)
-
-	paymentFile, err := c.FormFile("payment")
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "payment proof is required", err)
-		return
-	}
-
-	res, err := r.service.UserService.UploadPayment(user.UserID, paymentFile)
-	if err != nil {
-		if err.Error() == "file size exceeds maximum limit of 1MB" {
-			response.Error(c, http.StatusBadRequest, "please reduce the file size", err)
-		} else {
-			response.Error(c, http.StatusInternalServerError, "failed to upload payment", err)
-			return
-		}
-	}
-
-	response.Success(c, http.StatusOK, "success to upload payment", res)
-
-}
-
 func (r *Rest) UploadKTM(c *gin.Context) {
 	user := c.MustGet("user").(*entity.User)
 
@@ -89,14 +67,10 @@ func (r *Rest) UploadKTM(c *gin.Context) {
 		return
 	}
 
-	err = r.service.UserService.UploadKTM(user.UserID, ktmFile)
+	err = r.service.DocumentService.UploadMyKTM(user.UserID, ktmFile)
 	if err != nil {
-		if err.Error() == "file size exceeds maximum limit of 1MB" {
-			response.Error(c, http.StatusBadRequest, "please reduce the file size", err)
-		} else {
-			response.Error(c, http.StatusInternalServerError, "failed to upload payment", err)
-			return
-		}
+		documentError(c, "failed to upload ktm", err)
+		return
 	}
 
 	response.Success(c, http.StatusOK, "success to upload ktm", nil)
@@ -179,13 +153,13 @@ func (r *Rest) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	token, err := r.service.UserService.ChangePassword(param.Email)
+	err = r.service.UserService.ChangePassword(param.Email)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "failed to send email verification", err)
 		return
 	}
 
-	response.Success(c, http.StatusOK, "success to send email verification password", token)
+	response.Success(c, http.StatusOK, "success to send email verification password", nil)
 }
 
 func (r *Rest) VerifyOtpChangePassword(c *gin.Context) {
@@ -196,7 +170,7 @@ func (r *Rest) VerifyOtpChangePassword(c *gin.Context) {
 		return
 	}
 
-	err = r.service.UserService.VerifyOtpChangePassword(param)
+	res, err := r.service.UserService.VerifyOtpChangePassword(param)
 
 	if err != nil {
 		if err.Error() == "invalid token" {
@@ -211,7 +185,7 @@ func (r *Rest) VerifyOtpChangePassword(c *gin.Context) {
 		}
 	}
 
-	response.Success(c, http.StatusOK, "success to verify token", nil)
+	response.Success(c, http.StatusOK, "success to verify token", res)
 }
 
 func (r *Rest) ChangePasswordAfterVerify(c *gin.Context) {
@@ -230,6 +204,9 @@ func (r *Rest) ChangePasswordAfterVerify(c *gin.Context) {
 		} else if err.Error() == "new password cannot be same as old password" {
 			response.Error(c, http.StatusBadRequest, "please use another password", err)
 			return
+		} else if errors.Is(err, model.ErrInvalidResetToken) {
+			response.Error(c, http.StatusUnauthorized, "reset token is invalid", err)
+			return
 		} else {
 			response.Error(c, http.StatusInternalServerError, "failed to change user password", err)
 			return
@@ -258,7 +235,21 @@ func (r *Rest) CompetitionRegistration(c *gin.Context) {
 
 	err = r.service.UserService.CompetitionRegistration(user.UserID, idInt, param)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "failed to register competition", err)
+		if errors.Is(err, model.ErrDuplicateStudentNumber) {
+			response.Error(c, http.StatusConflict, "failed to register competition", err)
+			return
+		} else if errors.Is(err, model.ErrTeamLocked) || errors.Is(err, model.ErrTeamInactive) ||
+			errors.Is(err, model.ErrRegistrationNotOpen) || errors.Is(err, model.ErrRegistrationClosed) {
+			response.Error(c, http.StatusForbidden, "failed to register competition", err)
+			return
+		} else if errors.Is(err, model.ErrCompetitionFull) {
+			response.Error(c, http.StatusConflict, "failed to register competition", err)
+			return
+		} else if errors.Is(err, model.ErrInvalidFormAnswer) || errors.Is(err, model.ErrUnknownFormField) {
+			response.Error(c, http.StatusBadRequest, "failed to register competition", err)
+			return
+		}
+		voucherError(c, "failed to register competition", err)
 		return
 	}
 
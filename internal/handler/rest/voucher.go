@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) CreateVoucher(c *gin.Context) {
+	actor := c.MustGet("user").(*entity.User)
+
+	var param model.CreateVoucherRequest
+	err := c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.VoucherService.CreateVoucher(actor, param)
+	if err != nil {
+		voucherError(c, "failed to create voucher", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "success to create voucher", res)
+}
+
+func (r *Rest) UpdateVoucher(c *gin.Context) {
+	voucherID, err := strconv.Atoi(c.Param("voucher_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert voucher id", err)
+		return
+	}
+
+	var param model.UpdateVoucherRequest
+	err = c.ShouldBindJSON(&param)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to bind input", err)
+		return
+	}
+
+	res, err := r.service.VoucherService.UpdateVoucher(voucherID, param)
+	if err != nil {
+		voucherError(c, "failed to update voucher", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to update voucher", res)
+}
+
+func (r *Rest) GetVouchers(c *gin.Context) {
+	res, err := r.service.VoucherService.GetVouchers()
+	if err != nil {
+		voucherError(c, "failed to get vouchers", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get vouchers", res)
+}
+
+func (r *Rest) GetVoucher(c *gin.Context) {
+	voucherID, err := strconv.Atoi(c.Param("voucher_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert voucher id", err)
+		return
+	}
+
+	res, err := r.service.VoucherService.GetVoucher(voucherID)
+	if err != nil {
+		voucherError(c, "failed to get voucher", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get voucher", res)
+}
+
+func (r *Rest) GetRegistrationPrice(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	res, err := r.service.VoucherService.GetRegistrationPrice(user.UserID, competitionID, c.Query("voucher_code"))
+	if err != nil {
+		voucherError(c, "failed to get registration price", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get registration price", res)
+}
+
+func voucherError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrVoucherNotFound) || errors.Is(err, model.ErrCompetitionNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrVoucherCodeTaken) || errors.Is(err, model.ErrCompetitionArchived) ||
+		errors.Is(err, model.ErrRegistrationPaid) {
+		response.Error(c, http.StatusConflict, message, err)
+		return
+	} else if errors.Is(err, model.ErrVoucherInvalidDiscount) || errors.Is(err, model.ErrVoucherInactive) ||
+		errors.Is(err, model.ErrVoucherExpired) || errors.Is(err, model.ErrVoucherExhausted) ||
+		errors.Is(err, model.ErrVoucherNotApplicable) || errors.Is(err, model.ErrVoucherUniversity) {
+		response.Error(c, http.StatusBadRequest, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
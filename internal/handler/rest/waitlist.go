@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/model"
+	"itfest-2025/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (r *Rest) GetMyWaitlist(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	res, err := r.service.WaitlistService.GetMyWaitlist(user.UserID)
+	if err != nil {
+		waitlistError(c, "failed to get waitlist", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get waitlist", res)
+}
+
+func (r *Rest) LeaveWaitlist(c *gin.Context) {
+	user := c.MustGet("user").(*entity.User)
+
+	err := r.service.WaitlistService.LeaveWaitlist(user.UserID)
+	if err != nil {
+		waitlistError(c, "failed to leave waitlist", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to leave waitlist", nil)
+}
+
+func (r *Rest) GetCompetitionWaitlist(c *gin.Context) {
+	competitionID, err := strconv.Atoi(c.Param("competition_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to convert competition id", err)
+		return
+	}
+
+	res, err := r.service.WaitlistService.GetCompetitionWaitlist(competitionID)
+	if err != nil {
+		waitlistError(c, "failed to get competition waitlist", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "success to get competition waitlist", res)
+}
+
+func waitlistError(c *gin.Context, message string, err error) {
+	if errors.Is(err, model.ErrWaitlistNotFound) || errors.Is(err, model.ErrCompetitionNotFound) {
+		response.Error(c, http.StatusNotFound, message, err)
+		return
+	} else if errors.Is(err, model.ErrNotTeamLeader) {
+		response.Error(c, http.StatusForbidden, message, err)
+		return
+	}
+
+	response.Error(c, http.StatusInternalServerError, message, err)
+}
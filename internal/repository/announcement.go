@@ -2,13 +2,25 @@ package repository
 
 import (
 	"itfest-2025/entity"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IAnnouncementRepository interface {
 	CreateAnnouncement(tx *gorm.DB, req entity.Announcement) error
 	GetAnnouncement() ([]*entity.Announcement, error)
+	GetPublishedAnnouncementsForTeam(teamID uuid.UUID) ([]*entity.Announcement, error)
+	GetAnnouncementByID(tx *gorm.DB, announcementID uuid.UUID) (*entity.Announcement, error)
+	UpdateAnnouncement(tx *gorm.DB, announcement *entity.Announcement) error
+	DeleteAnnouncement(tx *gorm.DB, announcementID uuid.UUID) error
+	GetDueAnnouncementIDs(tx *gorm.DB, now time.Time, limit int) ([]uuid.UUID, error)
+	ClaimDueAnnouncement(tx *gorm.DB, announcementID uuid.UUID, now time.Time) (*entity.Announcement, error)
+	CreateRevision(tx *gorm.DB, revision *entity.AnnouncementRevision) error
+	GetRevisions(tx *gorm.DB, announcementID uuid.UUID) ([]entity.AnnouncementRevision, error)
+	GetRecipients(tx *gorm.DB, announcement entity.Announcement) ([]entity.User, error)
 }
 
 type AnnouncementRepository struct {
@@ -32,10 +44,170 @@ func (r *AnnouncementRepository) CreateAnnouncement(tx *gorm.DB, req entity.Anno
 
 func (r *AnnouncementRepository) GetAnnouncement() ([]*entity.Announcement, error) {
 	var announcement []*entity.Announcement
-	err := r.db.Debug().Find(&announcement).Order("created_at desc").Error
+	err := r.db.Debug().Order("created_at desc").Find(&announcement).Error
 	if err != nil {
 		return nil, err
 	}
 
 	return announcement, nil
 }
+
+// GetPublishedAnnouncementsForTeam mengambil pengumuman terbit tanpa target dan pengumuman yang targetnya cocok
+// dengan tim dalam satu query, kondisinya sama dengan targetTeams.
+func (r *AnnouncementRepository) GetPublishedAnnouncementsForTeam(teamID uuid.UUID) ([]*entity.Announcement, error) {
+	progress := r.db.Model(&entity.TeamProgress{}).Select("1").
+		Where("team_progresses.team_id = teams.team_id AND team_progresses.stage_id = announcements.stage_id").
+		Where("announcements.submission_status = '' OR announcements.submission_status IS NULL OR team_progresses.status = announcements.submission_status")
+
+	target := r.db.Model(&entity.Team{}).Select("1").
+		Joins("LEFT JOIN payments ON payments.team_id = teams.team_id").
+		Where("teams.team_id = ?", teamID).
+		Where("teams.competition_id <> ?", entity.UnassignedCompetitionID).
+		Where("teams.lifecycle_status NOT IN ?", []string{entity.TeamWithdrawn, entity.TeamDisqualified}).
+		Where("announcements.competition_id IS NULL OR teams.competition_id = announcements.competition_id").
+		Where("announcements.stage_id IS NULL OR EXISTS (?)", progress).
+		Where("announcements.payment_status = '' OR announcements.payment_status IS NULL OR COALESCE(payments.status, ?) = announcements.payment_status", "belum terverifikasi")
+
+	untargeted := "announcements.competition_id IS NULL AND announcements.stage_id IS NULL AND " +
+		"COALESCE(announcements.submission_status, '') = '' AND COALESCE(announcements.payment_status, '') = ''"
+
+	var announcement []*entity.Announcement
+	err := r.db.Debug().Where("status = ?", entity.AnnouncementPublished).
+		Where(r.db.Where(untargeted).Or("EXISTS (?)", target)).
+		Order("COALESCE(published_at, created_at) desc").
+		Find(&announcement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+func (r *AnnouncementRepository) GetAnnouncementByID(tx *gorm.DB, announcementID uuid.UUID) (*entity.Announcement, error) {
+	var announcement entity.Announcement
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("announcement_id = ?", announcementID).First(&announcement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (r *AnnouncementRepository) UpdateAnnouncement(tx *gorm.DB, announcement *entity.Announcement) error {
+	err := tx.Debug().Save(announcement).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AnnouncementRepository) DeleteAnnouncement(tx *gorm.DB, announcementID uuid.UUID) error {
+	err := tx.Debug().Where("announcement_id = ?", announcementID).Delete(&entity.Announcement{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetDueAnnouncementIDs mengambil id pengumuman terjadwal yang waktunya sudah lewat tanpa mengunci barisnya.
+func (r *AnnouncementRepository) GetDueAnnouncementIDs(tx *gorm.DB, now time.Time, limit int) ([]uuid.UUID, error) {
+	var announcementIDs []uuid.UUID
+	err := tx.Model(&entity.Announcement{}).
+		Where("status = ? AND publish_at <= ?", entity.AnnouncementScheduled, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Pluck("announcement_id", &announcementIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return announcementIDs, nil
+}
+
+// ClaimDueAnnouncement mengunci satu pengumuman terjadwal yang waktunya sudah lewat, baris yang sedang dikunci
+// scheduler lain dilewati agar tidak terbit dua kali. SKIP LOCKED membutuhkan MariaDB 10.6 ke atas.
+func (r *AnnouncementRepository) ClaimDueAnnouncement(tx *gorm.DB, announcementID uuid.UUID, now time.Time) (*entity.Announcement, error) {
+	var announcement entity.Announcement
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("announcement_id = ? AND status = ? AND publish_at <= ?", announcementID, entity.AnnouncementScheduled, now).
+		First(&announcement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (r *AnnouncementRepository) CreateRevision(tx *gorm.DB, revision *entity.AnnouncementRevision) error {
+	err := tx.Debug().Create(revision).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AnnouncementRepository) GetRevisions(tx *gorm.DB, announcementID uuid.UUID) ([]entity.AnnouncementRevision, error) {
+	var revisions []entity.AnnouncementRevision
+	err := tx.Where("announcement_id = ?", announcementID).Order("announcement_revision_id ASC").Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetRecipients mengambil peserta aktif penerima pengumuman, baik ketua maupun anggota yang punya akun.
+// Pengumuman tanpa target dikirim ke seluruh peserta aktif.
+func (r *AnnouncementRepository) GetRecipients(tx *gorm.DB, announcement entity.Announcement) ([]entity.User, error) {
+	query := tx.Where("role_id = ? AND status_account = ?", entity.RoleParticipant, "active")
+
+	if isTargeted(announcement) {
+		teamIDs := r.targetTeams(tx, announcement)
+		query = query.Where("user_id IN (?) OR user_id IN (?)",
+			tx.Model(&entity.Team{}).Select("user_id").Where("team_id IN (?)", teamIDs),
+			tx.Model(&entity.TeamMember{}).Select("user_id").Where("team_id IN (?) AND user_id IS NOT NULL", teamIDs),
+		)
+	}
+
+	var users []entity.User
+	err := query.Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// targetTeams menyusun subquery tim aktif yang cocok dengan target pengumuman.
+// Tim tanpa data pembayaran dianggap berstatus "belum terverifikasi".
+func (r *AnnouncementRepository) targetTeams(tx *gorm.DB, announcement entity.Announcement) *gorm.DB {
+	query := tx.Model(&entity.Team{}).Select("teams.team_id").
+		Where("teams.competition_id <> ?", entity.UnassignedCompetitionID).
+		Where("teams.lifecycle_status NOT IN ?", []string{entity.TeamWithdrawn, entity.TeamDisqualified})
+
+	if announcement.CompetitionID != nil {
+		query = query.Where("teams.competition_id = ?", *announcement.CompetitionID)
+	}
+
+	if announcement.StageID != nil {
+		progress := tx.Model(&entity.TeamProgress{}).Select("team_id").Where("stage_id = ?", *announcement.StageID)
+		if announcement.SubmissionStatus != "" {
+			progress = progress.Where("status = ?", announcement.SubmissionStatus)
+		}
+		query = query.Where("teams.team_id IN (?)", progress)
+	}
+
+	if announcement.PaymentStatus != "" {
+		query = query.Joins("LEFT JOIN payments ON payments.team_id = teams.team_id").
+			Where("COALESCE(payments.status, ?) = ?", "belum terverifikasi", announcement.PaymentStatus)
+	}
+
+	return query
+}
+
+func isTargeted(announcement entity.Announcement) bool {
+	return announcement.CompetitionID != nil || announcement.StageID != nil || announcement.SubmissionStatus != "" || announcement.PaymentStatus != ""
+}
@@ -4,11 +4,21 @@ import (
 	"itfest-2025/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ICompetitionRepository interface {
 	GetCompetitionByID(tx *gorm.DB, competitionID int) (*entity.Competition, error)
+	LockCompetition(tx *gorm.DB, competitionID int) (*entity.Competition, error)
 	GetAllCompetitions(tx *gorm.DB) ([]*entity.Competition, error)
+	GetAllCompetitionsWithStages(tx *gorm.DB) ([]*entity.Competition, error)
+	CreateCompetition(tx *gorm.DB, competition *entity.Competition) error
+	UpdateCompetition(tx *gorm.DB, competition *entity.Competition) error
+	GetStagesByCompetitionID(tx *gorm.DB, competitionID int) ([]entity.Stages, error)
+	CreateStage(tx *gorm.DB, stage *entity.Stages) error
+	UpdateStage(tx *gorm.DB, stage *entity.Stages) error
+	GetCompetitionRule(tx *gorm.DB, competitionID int) (*entity.CompetitionRule, error)
+	SaveCompetitionRule(tx *gorm.DB, rule *entity.CompetitionRule) error
 }
 
 type CompetitionRepository struct {
@@ -24,7 +34,7 @@ func NewCompetitionRepository(db *gorm.DB) ICompetitionRepository {
 func (c *CompetitionRepository) GetCompetitionByID(tx *gorm.DB, competitionID int) (*entity.Competition, error) {
 	var competition *entity.Competition
 
-	err := tx.Where("competition_id = ?", competitionID).Preload("Teams").Preload("Teams.TeamMembers").First(&competition).Error
+	err := tx.Where("competition_id = ?", competitionID).Preload("Teams").Preload("Teams.TeamMembers").Preload("Rule").First(&competition).Error
 	if err != nil {
 		return nil, err
 	}
@@ -32,13 +42,113 @@ func (c *CompetitionRepository) GetCompetitionByID(tx *gorm.DB, competitionID in
 	return competition, nil
 }
 
+// LockCompetition mengunci baris kompetisi agar pengecekan kuota tim tidak balapan antar registrasi.
+func (c *CompetitionRepository) LockCompetition(tx *gorm.DB, competitionID int) (*entity.Competition, error) {
+	var competition entity.Competition
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("competition_id = ?", competitionID).First(&competition).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &competition, nil
+}
+
 func (c *CompetitionRepository) GetAllCompetitions(tx *gorm.DB) ([]*entity.Competition, error) {
 	var competitions []*entity.Competition
 
-	err := tx.Where("competition_id >= ?", 2).Find(&competitions).Error
+	err := tx.Where("competition_id <> ? AND is_archived = ?", entity.UnassignedCompetitionID, false).Find(&competitions).Error
 	if err != nil {
 		return nil, err
 	}
 
 	return competitions, nil
 }
+
+func (c *CompetitionRepository) GetAllCompetitionsWithStages(tx *gorm.DB) ([]*entity.Competition, error) {
+	var competitions []*entity.Competition
+
+	err := tx.Where("competition_id <> ?", entity.UnassignedCompetitionID).
+		Preload("Rule").
+		Preload("Stages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("stage_order ASC")
+		}).
+		Find(&competitions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return competitions, nil
+}
+
+func (c *CompetitionRepository) CreateCompetition(tx *gorm.DB, competition *entity.Competition) error {
+	err := tx.Debug().Omit("Stages", "Rule").Create(competition).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *CompetitionRepository) UpdateCompetition(tx *gorm.DB, competition *entity.Competition) error {
+	err := tx.Debug().Model(&entity.Competition{}).
+		Where("competition_id = ?", competition.CompetitionID).
+		Select("competition_name", "description", "deadline", "registration_fee", "early_bird_fee", "early_bird_start", "early_bird_end", "registration_open", "registration_close", "max_teams", "is_archived").
+		Updates(competition).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *CompetitionRepository) GetStagesByCompetitionID(tx *gorm.DB, competitionID int) ([]entity.Stages, error) {
+	var stages []entity.Stages
+
+	err := tx.Where("competition_id = ?", competitionID).Order("stage_order ASC").Find(&stages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stages, nil
+}
+
+func (c *CompetitionRepository) CreateStage(tx *gorm.DB, stage *entity.Stages) error {
+	err := tx.Debug().Omit("TeamProgresses").Create(stage).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *CompetitionRepository) UpdateStage(tx *gorm.DB, stage *entity.Stages) error {
+	err := tx.Debug().Model(&entity.Stages{}).
+		Where("stage_id = ?", stage.StageID).
+		Select("stage_name", "stage_order", "deadline", "allowed_mime_types", "max_file_size").
+		Updates(stage).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *CompetitionRepository) GetCompetitionRule(tx *gorm.DB, competitionID int) (*entity.CompetitionRule, error) {
+	var rule entity.CompetitionRule
+
+	err := tx.Where("competition_id = ?", competitionID).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (c *CompetitionRepository) SaveCompetitionRule(tx *gorm.DB, rule *entity.CompetitionRule) error {
+	err := tx.Debug().Save(rule).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IEmailOutboxRepository interface {
+	CreateEmails(tx *gorm.DB, emails []entity.EmailOutbox) error
+	ClaimDueEmails(tx *gorm.DB, now time.Time, staleBefore time.Time, limit int) ([]entity.EmailOutbox, error)
+	UpdateEmail(tx *gorm.DB, email *entity.EmailOutbox) error
+	GetEmails(tx *gorm.DB, status string) ([]entity.EmailOutbox, error)
+	GetEmailByID(tx *gorm.DB, emailID int) (*entity.EmailOutbox, error)
+	RequeueDeadEmails(tx *gorm.DB, now time.Time) (int64, error)
+	PurgeSentEmails(tx *gorm.DB, sentBefore time.Time) (int64, error)
+}
+
+type EmailOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailOutboxRepository(db *gorm.DB) IEmailOutboxRepository {
+	return &EmailOutboxRepository{
+		db: db,
+	}
+}
+
+func (e *EmailOutboxRepository) CreateEmails(tx *gorm.DB, emails []entity.EmailOutbox) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	err := tx.Debug().CreateInBatches(&emails, 100).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueEmails mengambil email yang sudah waktunya dikirim dan menandainya sedang dikirim.
+// Email yang tertahan di status sending terlalu lama (worker mati di tengah jalan) ikut diambil ulang.
+// SKIP LOCKED membutuhkan MariaDB 10.6 ke atas, versi lebih lama akan menolak query ini.
+func (e *EmailOutboxRepository) ClaimDueEmails(tx *gorm.DB, now time.Time, staleBefore time.Time, limit int) ([]entity.EmailOutbox, error) {
+	var emails []entity.EmailOutbox
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND updated_at < ?)", entity.EmailPending, now, entity.EmailSending, staleBefore).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&emails).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		return emails, nil
+	}
+
+	var emailIDs []int
+	for i := range emails {
+		emailIDs = append(emailIDs, emails[i].EmailID)
+		emails[i].Status = entity.EmailSending
+	}
+
+	err = tx.Debug().Model(&entity.EmailOutbox{}).Where("email_id IN ?", emailIDs).Updates(map[string]interface{}{
+		"status":     entity.EmailSending,
+		"updated_at": now,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+func (e *EmailOutboxRepository) UpdateEmail(tx *gorm.DB, email *entity.EmailOutbox) error {
+	err := tx.Debug().Save(email).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *EmailOutboxRepository) GetEmails(tx *gorm.DB, status string) ([]entity.EmailOutbox, error) {
+	var emails []entity.EmailOutbox
+	err := tx.Omit("html_body", "text_body", "attachments").
+		Where("status = ?", status).
+		Order("updated_at DESC").
+		Find(&emails).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+func (e *EmailOutboxRepository) GetEmailByID(tx *gorm.DB, emailID int) (*entity.EmailOutbox, error) {
+	var email entity.EmailOutbox
+	err := tx.Where("email_id = ?", emailID).First(&email).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+func (e *EmailOutboxRepository) RequeueDeadEmails(tx *gorm.DB, now time.Time) (int64, error) {
+	result := tx.Debug().Model(&entity.EmailOutbox{}).Where("status = ?", entity.EmailDead).Updates(map[string]interface{}{
+		"status":          entity.EmailPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// PurgeSentEmails mengosongkan isi email terkirim yang lebih lama dari sentBefore karena bisa memuat kode OTP dan kwitansi,
+// metadata pengiriman tetap disimpan untuk riwayat.
+func (e *EmailOutboxRepository) PurgeSentEmails(tx *gorm.DB, sentBefore time.Time) (int64, error) {
+	result := tx.Debug().Model(&entity.EmailOutbox{}).
+		Where("status = ? AND sent_at < ?", entity.EmailSent, sentBefore).
+		Where("html_body <> '' OR text_body <> '' OR attachments IS NOT NULL").
+		Updates(map[string]interface{}{
+			"html_body":   "",
+			"text_body":   "",
+			"attachments": nil,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
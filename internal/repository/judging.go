@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IJudgingRepository interface {
+	GetRubricByStageID(tx *gorm.DB, stageID int) ([]entity.RubricCriterion, error)
+	CreateRubricCriterion(tx *gorm.DB, criterion *entity.RubricCriterion) error
+	DeleteRubricByStageID(tx *gorm.DB, stageID int) error
+	CountScoresByStageID(tx *gorm.DB, stageID int) (int64, error)
+	CreateAssignment(tx *gorm.DB, assignment *entity.JudgeAssignment) error
+	UpdateAssignment(tx *gorm.DB, assignment *entity.JudgeAssignment) error
+	DeleteAssignment(tx *gorm.DB, assignmentID uuid.UUID) error
+	GetAssignmentByID(tx *gorm.DB, assignmentID uuid.UUID) (*entity.JudgeAssignment, error)
+	GetAssignment(tx *gorm.DB, teamProgressID int, judgeID uuid.UUID) (*entity.JudgeAssignment, error)
+	GetAssignmentsByJudgeID(tx *gorm.DB, judgeID uuid.UUID) ([]entity.JudgeAssignment, error)
+	GetAssignmentsByTeamProgressIDs(tx *gorm.DB, teamProgressIDs []int) ([]entity.JudgeAssignment, error)
+	ReplaceScores(tx *gorm.DB, assignmentID uuid.UUID, scores []entity.JudgeScore) error
+}
+
+type JudgingRepository struct {
+	db *gorm.DB
+}
+
+func NewJudgingRepository(db *gorm.DB) IJudgingRepository {
+	return &JudgingRepository{
+		db: db,
+	}
+}
+
+func (j *JudgingRepository) GetRubricByStageID(tx *gorm.DB, stageID int) ([]entity.RubricCriterion, error) {
+	var criteria []entity.RubricCriterion
+	err := tx.Where("stage_id = ?", stageID).Order("criterion_id ASC").Find(&criteria).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return criteria, nil
+}
+
+func (j *JudgingRepository) CreateRubricCriterion(tx *gorm.DB, criterion *entity.RubricCriterion) error {
+	err := tx.Debug().Create(criterion).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (j *JudgingRepository) DeleteRubricByStageID(tx *gorm.DB, stageID int) error {
+	return tx.Debug().Where("stage_id = ?", stageID).Delete(&entity.RubricCriterion{}).Error
+}
+
+func (j *JudgingRepository) CountScoresByStageID(tx *gorm.DB, stageID int) (int64, error) {
+	var count int64
+	err := tx.Model(&entity.JudgeScore{}).
+		Joins("JOIN rubric_criteria ON rubric_criteria.criterion_id = judge_scores.criterion_id").
+		Where("rubric_criteria.stage_id = ?", stageID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (j *JudgingRepository) CreateAssignment(tx *gorm.DB, assignment *entity.JudgeAssignment) error {
+	err := tx.Debug().Create(assignment).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (j *JudgingRepository) UpdateAssignment(tx *gorm.DB, assignment *entity.JudgeAssignment) error {
+	err := tx.Debug().Omit("Scores").Save(assignment).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (j *JudgingRepository) DeleteAssignment(tx *gorm.DB, assignmentID uuid.UUID) error {
+	err := tx.Debug().Where("assignment_id = ?", assignmentID).Delete(&entity.JudgeScore{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Debug().Where("assignment_id = ?", assignmentID).Delete(&entity.JudgeAssignment{}).Error
+}
+
+func (j *JudgingRepository) GetAssignmentByID(tx *gorm.DB, assignmentID uuid.UUID) (*entity.JudgeAssignment, error) {
+	var assignment entity.JudgeAssignment
+	err := tx.Preload("Scores").Where("assignment_id = ?", assignmentID).First(&assignment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+func (j *JudgingRepository) GetAssignment(tx *gorm.DB, teamProgressID int, judgeID uuid.UUID) (*entity.JudgeAssignment, error) {
+	var assignment entity.JudgeAssignment
+	err := tx.Preload("Scores").Where("team_progress_id = ? AND judge_id = ?", teamProgressID, judgeID).First(&assignment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+func (j *JudgingRepository) GetAssignmentsByJudgeID(tx *gorm.DB, judgeID uuid.UUID) ([]entity.JudgeAssignment, error) {
+	var assignments []entity.JudgeAssignment
+	err := tx.Preload("Scores").Where("judge_id = ?", judgeID).Order("created_at DESC").Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (j *JudgingRepository) GetAssignmentsByTeamProgressIDs(tx *gorm.DB, teamProgressIDs []int) ([]entity.JudgeAssignment, error) {
+	var assignments []entity.JudgeAssignment
+	if len(teamProgressIDs) == 0 {
+		return assignments, nil
+	}
+
+	err := tx.Preload("Scores").Where("team_progress_id IN ?", teamProgressIDs).Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (j *JudgingRepository) ReplaceScores(tx *gorm.DB, assignmentID uuid.UUID, scores []entity.JudgeScore) error {
+	err := tx.Debug().Where("assignment_id = ?", assignmentID).Delete(&entity.JudgeScore{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(scores) == 0 {
+		return nil
+	}
+
+	return tx.Debug().Create(&scores).Error
+}
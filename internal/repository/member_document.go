@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IMemberDocumentRepository interface {
+	CreateDocument(tx *gorm.DB, document *entity.MemberDocument) error
+	UpdateDocument(tx *gorm.DB, document *entity.MemberDocument) error
+	GetDocumentByID(tx *gorm.DB, documentID uuid.UUID) (*entity.MemberDocument, error)
+	GetDocumentByOwner(tx *gorm.DB, ownerID uuid.UUID, documentType string) (*entity.MemberDocument, error)
+	GetDocumentsByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.MemberDocument, error)
+	DeleteDocumentsByOwnerIDs(tx *gorm.DB, ownerIDs []uuid.UUID) ([]entity.MemberDocument, error)
+}
+
+type MemberDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewMemberDocumentRepository(db *gorm.DB) IMemberDocumentRepository {
+	return &MemberDocumentRepository{
+		db: db,
+	}
+}
+
+func (m *MemberDocumentRepository) CreateDocument(tx *gorm.DB, document *entity.MemberDocument) error {
+	err := tx.Debug().Create(document).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *MemberDocumentRepository) UpdateDocument(tx *gorm.DB, document *entity.MemberDocument) error {
+	err := tx.Debug().Save(document).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *MemberDocumentRepository) GetDocumentByID(tx *gorm.DB, documentID uuid.UUID) (*entity.MemberDocument, error) {
+	var document entity.MemberDocument
+	err := tx.Where("document_id = ?", documentID).First(&document).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+func (m *MemberDocumentRepository) GetDocumentByOwner(tx *gorm.DB, ownerID uuid.UUID, documentType string) (*entity.MemberDocument, error) {
+	var document entity.MemberDocument
+	err := tx.Where("owner_id = ? AND document_type = ?", ownerID, documentType).First(&document).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+func (m *MemberDocumentRepository) GetDocumentsByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.MemberDocument, error) {
+	var documents []entity.MemberDocument
+	err := tx.Where("team_id = ?", teamID).Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// DeleteDocumentsByOwnerIDs mengembalikan dokumen yang dihapus agar file di storage ikut dibersihkan.
+func (m *MemberDocumentRepository) DeleteDocumentsByOwnerIDs(tx *gorm.DB, ownerIDs []uuid.UUID) ([]entity.MemberDocument, error) {
+	if len(ownerIDs) == 0 {
+		return nil, nil
+	}
+
+	var documents []entity.MemberDocument
+	err := tx.Where("owner_id IN ?", ownerIDs).Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("owner_id IN ?", ownerIDs).Delete(&entity.MemberDocument{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
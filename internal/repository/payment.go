@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPaymentRepository interface {
+	GetPaymentByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.Payment, error)
+	GetPaymentsByTeamIDs(tx *gorm.DB, teamIDs []uuid.UUID) ([]entity.Payment, error)
+	CreatePayment(tx *gorm.DB, payment *entity.Payment) error
+	UpdatePayment(tx *gorm.DB, payment *entity.Payment) error
+	CreatePaymentHistory(tx *gorm.DB, history *entity.PaymentHistory) error
+	CreateCharge(tx *gorm.DB, charge *entity.PaymentCharge) error
+	UpdateCharge(tx *gorm.DB, charge *entity.PaymentCharge) error
+	GetChargeByOrderID(tx *gorm.DB, orderID string) (*entity.PaymentCharge, error)
+	GetPendingCharge(tx *gorm.DB, teamID uuid.UUID) (*entity.PaymentCharge, error)
+	DeletePaymentsByTeamID(tx *gorm.DB, teamID uuid.UUID) error
+}
+
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) IPaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+func (p *PaymentRepository) GetPaymentByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.Payment, error) {
+	var payment entity.Payment
+	err := tx.
+		Preload("Histories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at DESC, payment_history_id DESC")
+		}).
+		Where("team_id = ?", teamID).
+		First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (p *PaymentRepository) GetPaymentsByTeamIDs(tx *gorm.DB, teamIDs []uuid.UUID) ([]entity.Payment, error) {
+	var payments []entity.Payment
+	err := tx.Where("team_id IN ?", teamIDs).Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (p *PaymentRepository) CreatePayment(tx *gorm.DB, payment *entity.Payment) error {
+	err := tx.Debug().Omit("Histories").Create(payment).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PaymentRepository) UpdatePayment(tx *gorm.DB, payment *entity.Payment) error {
+	err := tx.Debug().Omit("Histories").Save(payment).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PaymentRepository) CreatePaymentHistory(tx *gorm.DB, history *entity.PaymentHistory) error {
+	err := tx.Debug().Create(history).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PaymentRepository) CreateCharge(tx *gorm.DB, charge *entity.PaymentCharge) error {
+	err := tx.Debug().Create(charge).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PaymentRepository) UpdateCharge(tx *gorm.DB, charge *entity.PaymentCharge) error {
+	err := tx.Debug().Save(charge).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PaymentRepository) GetChargeByOrderID(tx *gorm.DB, orderID string) (*entity.PaymentCharge, error) {
+	var charge entity.PaymentCharge
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderID).First(&charge).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &charge, nil
+}
+
+func (p *PaymentRepository) GetPendingCharge(tx *gorm.DB, teamID uuid.UUID) (*entity.PaymentCharge, error) {
+	var charge entity.PaymentCharge
+	err := tx.
+		Where("team_id = ? AND status = ? AND expires_at > ?", teamID, "pending", time.Now()).
+		Order("created_at DESC").
+		First(&charge).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &charge, nil
+}
+
+// DeletePaymentsByTeamID menghapus pembayaran tim beserta riwayat dan tagihan gateway-nya.
+func (p *PaymentRepository) DeletePaymentsByTeamID(tx *gorm.DB, teamID uuid.UUID) error {
+	err := tx.Debug().Where("payment_id IN (?)", tx.Model(&entity.Payment{}).Select("payment_id").Where("team_id = ?", teamID)).
+		Delete(&entity.PaymentHistory{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Debug().Where("team_id = ?", teamID).Delete(&entity.PaymentCharge{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Debug().Where("team_id = ?", teamID).Delete(&entity.Payment{}).Error
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReceiptRepository interface {
+	NextSequence(tx *gorm.DB, name string) (int64, error)
+	CreateReceipt(tx *gorm.DB, receipt *entity.Receipt) error
+	GetActiveReceiptByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.Receipt, error)
+	VoidReceipts(tx *gorm.DB, teamID uuid.UUID) error
+}
+
+type ReceiptRepository struct {
+	db *gorm.DB
+}
+
+func NewReceiptRepository(db *gorm.DB) IReceiptRepository {
+	return &ReceiptRepository{
+		db: db,
+	}
+}
+
+// NextSequence menaikkan penghitung di dalam transaksi, nomor ikut batal jika transaksi di-rollback.
+func (r *ReceiptRepository) NextSequence(tx *gorm.DB, name string) (int64, error) {
+	err := tx.Debug().Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.ReceiptSequence{Name: name}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var sequence entity.ReceiptSequence
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	sequence.LastValue++
+	err = tx.Debug().Model(&sequence).Update("last_value", sequence.LastValue).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence.LastValue, nil
+}
+
+func (r *ReceiptRepository) CreateReceipt(tx *gorm.DB, receipt *entity.Receipt) error {
+	err := tx.Debug().Create(receipt).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReceiptRepository) GetActiveReceiptByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.Receipt, error) {
+	var receipt entity.Receipt
+	err := tx.Where("team_id = ? AND is_void = ?", teamID, false).Order("sequence DESC").First(&receipt).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+func (r *ReceiptRepository) VoidReceipts(tx *gorm.DB, teamID uuid.UUID) error {
+	return tx.Debug().Model(&entity.Receipt{}).
+		Where("team_id = ? AND is_void = ?", teamID, false).
+		Updates(map[string]interface{}{"is_void": true, "voided_at": time.Now()}).Error
+}
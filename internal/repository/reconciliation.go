@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReconciliationRepository interface {
+	CreateBatch(tx *gorm.DB, batch *entity.ReconciliationBatch) error
+	UpdateBatch(tx *gorm.DB, batch *entity.ReconciliationBatch) error
+	GetBatches(tx *gorm.DB) ([]entity.ReconciliationBatch, error)
+	GetBatchByID(tx *gorm.DB, batchID uuid.UUID) (*entity.ReconciliationBatch, error)
+	LockBatch(tx *gorm.DB, batchID uuid.UUID) (*entity.ReconciliationBatch, error)
+	UpdateLine(tx *gorm.DB, line *entity.ReconciliationLine) error
+}
+
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) IReconciliationRepository {
+	return &ReconciliationRepository{
+		db: db,
+	}
+}
+
+func (r *ReconciliationRepository) CreateBatch(tx *gorm.DB, batch *entity.ReconciliationBatch) error {
+	err := tx.Debug().Create(batch).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepository) UpdateBatch(tx *gorm.DB, batch *entity.ReconciliationBatch) error {
+	err := tx.Debug().Omit("Lines").Save(batch).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepository) GetBatches(tx *gorm.DB) ([]entity.ReconciliationBatch, error) {
+	var batches []entity.ReconciliationBatch
+	err := tx.Order("created_at DESC").Find(&batches).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+func (r *ReconciliationRepository) GetBatchByID(tx *gorm.DB, batchID uuid.UUID) (*entity.ReconciliationBatch, error) {
+	var batch entity.ReconciliationBatch
+	err := tx.
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("row_number ASC")
+		}).
+		Where("batch_id = ?", batchID).
+		First(&batch).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &batch, nil
+}
+
+func (r *ReconciliationRepository) LockBatch(tx *gorm.DB, batchID uuid.UUID) (*entity.ReconciliationBatch, error) {
+	var batch entity.ReconciliationBatch
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("batch_id = ?", batchID).First(&batch).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Where("batch_id = ?", batchID).Order("row_number ASC").Find(&batch.Lines).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &batch, nil
+}
+
+func (r *ReconciliationRepository) UpdateLine(tx *gorm.DB, line *entity.ReconciliationLine) error {
+	err := tx.Debug().Save(line).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
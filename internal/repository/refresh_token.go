@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRefreshTokenRepository interface {
+	CreateRefreshToken(tx *gorm.DB, token *entity.RefreshToken) error
+	GetRefreshTokenByHash(tx *gorm.DB, tokenHash string) (*entity.RefreshToken, error)
+	RevokeRefreshToken(tx *gorm.DB, tokenID uuid.UUID) error
+	RevokeSession(tx *gorm.DB, sessionID uuid.UUID) error
+	RevokeAllUserTokens(tx *gorm.DB, userID uuid.UUID) error
+	IsSessionActive(sessionID uuid.UUID) (bool, error)
+}
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) IRefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *RefreshTokenRepository) CreateRefreshToken(tx *gorm.DB, token *entity.RefreshToken) error {
+	err := tx.Debug().Create(token).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetRefreshTokenByHash mengunci baris token sehingga refresh bersamaan dengan token yang sama menunggu rotasi pertama
+// selesai lalu terdeteksi sebagai pemakaian ulang.
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(tx *gorm.DB, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	err := tx.Debug().Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshToken(tx *gorm.DB, tokenID uuid.UUID) error {
+	return tx.Debug().Model(&entity.RefreshToken{}).
+		Where("token_id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) RevokeSession(tx *gorm.DB, sessionID uuid.UUID) error {
+	return tx.Debug().Model(&entity.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) RevokeAllUserTokens(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Debug().Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) IsSessionActive(sessionID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.RefreshToken{}).
+		Where("session_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
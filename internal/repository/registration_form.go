@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRegistrationFormRepository interface {
+	GetFieldsByCompetitionID(tx *gorm.DB, competitionID int) ([]entity.RegistrationField, error)
+	CreateField(tx *gorm.DB, field *entity.RegistrationField) error
+	UpdateField(tx *gorm.DB, field *entity.RegistrationField) error
+	DeleteFields(tx *gorm.DB, fieldIDs []int) ([]entity.RegistrationAnswer, error)
+	GetAnswersByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.RegistrationAnswer, error)
+	GetAnswersByTeamIDs(tx *gorm.DB, teamIDs []uuid.UUID) ([]entity.RegistrationAnswer, error)
+	SaveAnswer(tx *gorm.DB, answer *entity.RegistrationAnswer) error
+	DeleteAnswersByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.RegistrationAnswer, error)
+}
+
+type RegistrationFormRepository struct {
+	db *gorm.DB
+}
+
+func NewRegistrationFormRepository(db *gorm.DB) IRegistrationFormRepository {
+	return &RegistrationFormRepository{
+		db: db,
+	}
+}
+
+func (r *RegistrationFormRepository) GetFieldsByCompetitionID(tx *gorm.DB, competitionID int) ([]entity.RegistrationField, error) {
+	var fields []entity.RegistrationField
+	err := tx.Where("competition_id = ?", competitionID).
+		Order("field_order ASC, field_id ASC").
+		Find(&fields).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (r *RegistrationFormRepository) CreateField(tx *gorm.DB, field *entity.RegistrationField) error {
+	err := tx.Debug().Create(field).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RegistrationFormRepository) UpdateField(tx *gorm.DB, field *entity.RegistrationField) error {
+	err := tx.Debug().Save(field).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteFields menghapus isian form beserta jawabannya, jawaban yang dihapus dikembalikan agar file-nya bisa dibersihkan.
+func (r *RegistrationFormRepository) DeleteFields(tx *gorm.DB, fieldIDs []int) ([]entity.RegistrationAnswer, error) {
+	if len(fieldIDs) == 0 {
+		return nil, nil
+	}
+
+	var answers []entity.RegistrationAnswer
+	err := tx.Preload("Field").Where("field_id IN ?", fieldIDs).Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("field_id IN ?", fieldIDs).Delete(&entity.RegistrationAnswer{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("field_id IN ?", fieldIDs).Delete(&entity.RegistrationField{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+func (r *RegistrationFormRepository) GetAnswersByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.RegistrationAnswer, error) {
+	var answers []entity.RegistrationAnswer
+	err := tx.Preload("Field").Where("team_id = ?", teamID).Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+func (r *RegistrationFormRepository) GetAnswersByTeamIDs(tx *gorm.DB, teamIDs []uuid.UUID) ([]entity.RegistrationAnswer, error) {
+	var answers []entity.RegistrationAnswer
+	if len(teamIDs) == 0 {
+		return answers, nil
+	}
+
+	err := tx.Preload("Field").Where("team_id IN ?", teamIDs).Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// SaveAnswer menyimpan jawaban tim, jawaban lama untuk isian yang sama ditimpa.
+func (r *RegistrationFormRepository) SaveAnswer(tx *gorm.DB, answer *entity.RegistrationAnswer) error {
+	return tx.Debug().Omit("Field").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "team_id"}, {Name: "field_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "file_name", "updated_at"}),
+	}).Create(answer).Error
+}
+
+func (r *RegistrationFormRepository) DeleteAnswersByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.RegistrationAnswer, error) {
+	answers, err := r.GetAnswersByTeamID(tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("team_id = ?", teamID).Delete(&entity.RegistrationAnswer{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
@@ -3,21 +3,45 @@ package repository
 import "gorm.io/gorm"
 
 type Repository struct {
-	UserRepository        IUserRepository
-	TeamRepository        ITeamRepository
-	OtpRepository         IOtpRepository
-	CompetitionRepository ICompetitionRepository
-	SubmissionRepository  ISubmissionRepository
-	AnnouncementRepository  IAnnouncementRepository
+	UserRepository           IUserRepository
+	TeamRepository           ITeamRepository
+	OtpRepository            IOtpRepository
+	CompetitionRepository    ICompetitionRepository
+	SubmissionRepository     ISubmissionRepository
+	AnnouncementRepository   IAnnouncementRepository
+	RefreshTokenRepository   IRefreshTokenRepository
+	RoleRepository           IRoleRepository
+	JudgingRepository        IJudgingRepository
+	PaymentRepository        IPaymentRepository
+	VoucherRepository        IVoucherRepository
+	ReconciliationRepository IReconciliationRepository
+	ReceiptRepository        IReceiptRepository
+	TeamInvitationRepository ITeamInvitationRepository
+	MemberDocumentRepository IMemberDocumentRepository
+	WaitlistRepository       IWaitlistRepository
+	FormRepository           IRegistrationFormRepository
+	OutboxRepository         IEmailOutboxRepository
 }
 
 func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{
-		UserRepository:        NewUserRepository(db),
-		TeamRepository:        NewTeamRepository(db),
-		OtpRepository:         NewOtpRepository(db),
-		CompetitionRepository: NewCompetitionRepository(db),
-		SubmissionRepository:  NewSubmissionRepository(db),
-		AnnouncementRepository:  NewAnnouncementRepository(db),
+		UserRepository:           NewUserRepository(db),
+		TeamRepository:           NewTeamRepository(db),
+		OtpRepository:            NewOtpRepository(db),
+		CompetitionRepository:    NewCompetitionRepository(db),
+		SubmissionRepository:     NewSubmissionRepository(db),
+		AnnouncementRepository:   NewAnnouncementRepository(db),
+		RefreshTokenRepository:   NewRefreshTokenRepository(db),
+		RoleRepository:           NewRoleRepository(db),
+		JudgingRepository:        NewJudgingRepository(db),
+		PaymentRepository:        NewPaymentRepository(db),
+		VoucherRepository:        NewVoucherRepository(db),
+		ReconciliationRepository: NewReconciliationRepository(db),
+		ReceiptRepository:        NewReceiptRepository(db),
+		TeamInvitationRepository: NewTeamInvitationRepository(db),
+		MemberDocumentRepository: NewMemberDocumentRepository(db),
+		WaitlistRepository:       NewWaitlistRepository(db),
+		FormRepository:           NewRegistrationFormRepository(db),
+		OutboxRepository:         NewEmailOutboxRepository(db),
 	}
 }
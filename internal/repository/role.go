@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRoleRepository interface {
+	GetRoles(tx *gorm.DB) ([]*entity.Role, error)
+	GetRoleByID(tx *gorm.DB, roleID int) (*entity.Role, error)
+	GetUserRoleIDs(tx *gorm.DB, userID uuid.UUID) ([]int, error)
+	GetPermissionsByRoleIDs(tx *gorm.DB, roleIDs []int) ([]string, error)
+	GrantRole(tx *gorm.DB, userID uuid.UUID, roleID int) error
+	RevokeRole(tx *gorm.DB, userID uuid.UUID, roleID int) error
+	UpdateUserPrimaryRole(tx *gorm.DB, userID uuid.UUID, roleID int) error
+}
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) IRoleRepository {
+	return &RoleRepository{
+		db: db,
+	}
+}
+
+func (r *RoleRepository) GetRoles(tx *gorm.DB) ([]*entity.Role, error) {
+	var roles []*entity.Role
+	err := tx.Preload("Permissions").Order("role_id ASC").Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) GetRoleByID(tx *gorm.DB, roleID int) (*entity.Role, error) {
+	var role entity.Role
+	err := tx.Where("role_id = ?", roleID).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *RoleRepository) GetUserRoleIDs(tx *gorm.DB, userID uuid.UUID) ([]int, error) {
+	var roleIDs []int
+	err := tx.Table("user_roles").Where("user_id = ?", userID).Pluck("role_id", &roleIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return roleIDs, nil
+}
+
+func (r *RoleRepository) GetPermissionsByRoleIDs(tx *gorm.DB, roleIDs []int) ([]string, error) {
+	var permissions []string
+	err := tx.Model(&entity.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.permission_id").
+		Where("role_permissions.role_id IN ?", roleIDs).
+		Pluck("permissions.name", &permissions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *RoleRepository) GrantRole(tx *gorm.DB, userID uuid.UUID, roleID int) error {
+	return tx.Debug().Table("user_roles").Clauses(clause.OnConflict{DoNothing: true}).Create(map[string]interface{}{
+		"user_id": userID,
+		"role_id": roleID,
+	}).Error
+}
+
+func (r *RoleRepository) RevokeRole(tx *gorm.DB, userID uuid.UUID, roleID int) error {
+	return tx.Debug().Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, roleID).Error
+}
+
+func (r *RoleRepository) UpdateUserPrimaryRole(tx *gorm.DB, userID uuid.UUID, roleID int) error {
+	return tx.Debug().Model(&entity.User{}).Where("user_id = ?", userID).Update("role_id", roleID).Error
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"itfest-2025/entity"
 	"itfest-2025/model"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ISubmissionRepository interface {
@@ -16,7 +18,16 @@ type ISubmissionRepository interface {
 	CreateSubmission(tx *gorm.DB, submission *entity.TeamProgress) error
 	GetStage(tx *gorm.DB, currentID int) (entity.Stages, error)
 	GetSubmissionAllStage(tx *gorm.DB, teamID uuid.UUID, competitionID int) ([]model.Stages, error)
-	UpdateStatusSubmission(tx *gorm.DB, teamID string, stageID string, req model.RequestUpdateStatusSubmission) error
+	UpdateStatusSubmission(tx *gorm.DB, teamID string, stageID string, decidedBy uuid.UUID, req model.RequestUpdateStatusSubmission) error
+	GetSubmissionByID(tx *gorm.DB, teamProgressID int) (*entity.TeamProgress, error)
+	LockSubmission(tx *gorm.DB, teamProgressID int) (*entity.TeamProgress, error)
+	GetSubmissionsByStageID(tx *gorm.DB, stageID int) ([]entity.TeamProgress, error)
+	UpdateSubmissionDecision(tx *gorm.DB, teamProgressID int, status string, decidedBy uuid.UUID, note string) error
+	UpdateSubmissionContent(tx *gorm.DB, version *entity.SubmissionVersion) error
+	CreateSubmissionVersion(tx *gorm.DB, version *entity.SubmissionVersion) error
+	GetSubmissionVersion(tx *gorm.DB, teamProgressID int, version int) (*entity.SubmissionVersion, error)
+	GetSubmissionHistory(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamProgress, error)
+	DeleteSubmissionsByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamProgress, error)
 }
 
 type SubmissionRepository struct {
@@ -105,7 +116,7 @@ func (t *SubmissionRepository) GetSubmissionAllStage(tx *gorm.DB, teamID uuid.UU
 
 	err := tx.
 		Table("stages").
-		Select("stages.stage_id AS stage_id, stages.stage_name AS stage, stages.deadline AS deadline, team_progresses.gdrive_link AS gdrive_link, team_progresses.status as status").
+		Select("stages.stage_id AS stage_id, stages.stage_order AS stage_order, stages.stage_name AS stage, stages.deadline AS deadline, team_progresses.gdrive_link AS gdrive_link, team_progresses.status as status").
 		Joins("LEFT JOIN team_progresses ON team_progresses.stage_id = stages.stage_id AND team_progresses.team_id = ?", teamID).
 		Where("stages.competition_id = ?", competitionID).
 		Order("stages.stage_order ASC").
@@ -118,8 +129,146 @@ func (t *SubmissionRepository) GetSubmissionAllStage(tx *gorm.DB, teamID uuid.UU
 	return stages, nil
 }
 
-func (t *SubmissionRepository) UpdateStatusSubmission(tx *gorm.DB, teamID string, stageID string, req model.RequestUpdateStatusSubmission) error {
+func (t *SubmissionRepository) UpdateStatusSubmission(tx *gorm.DB, teamID string, stageID string, decidedBy uuid.UUID, req model.RequestUpdateStatusSubmission) error {
 	return tx.Debug().Model(&entity.TeamProgress{}).
 		Where("team_id = ? AND stage_id = ?", teamID, stageID).
-		Update("status", req.SubmissionStatus).Error
+		Updates(map[string]interface{}{
+			"status":        req.SubmissionStatus,
+			"decided_by":    decidedBy,
+			"decision_note": req.Reason,
+			"decided_at":    time.Now(),
+		}).Error
+}
+
+func (t *SubmissionRepository) GetSubmissionByID(tx *gorm.DB, teamProgressID int) (*entity.TeamProgress, error) {
+	var progress entity.TeamProgress
+	err := tx.Where("team_progress_id = ?", teamProgressID).First(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &progress, nil
+}
+
+// LockSubmission mengunci baris progress agar nomor versi submission tidak dihitung ganda oleh resubmit bersamaan.
+func (t *SubmissionRepository) LockSubmission(tx *gorm.DB, teamProgressID int) (*entity.TeamProgress, error) {
+	var progress entity.TeamProgress
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("team_progress_id = ?", teamProgressID).First(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &progress, nil
+}
+
+func (t *SubmissionRepository) GetSubmissionsByStageID(tx *gorm.DB, stageID int) ([]entity.TeamProgress, error) {
+	var progresses []entity.TeamProgress
+	err := tx.Where("stage_id = ?", stageID).Find(&progresses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return progresses, nil
+}
+
+func (t *SubmissionRepository) UpdateSubmissionDecision(tx *gorm.DB, teamProgressID int, status string, decidedBy uuid.UUID, note string) error {
+	return tx.Debug().Model(&entity.TeamProgress{}).
+		Where("team_progress_id = ?", teamProgressID).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"decided_by":    decidedBy,
+			"decision_note": note,
+			"decided_at":    time.Now(),
+		}).Error
+}
+
+func (t *SubmissionRepository) UpdateSubmissionContent(tx *gorm.DB, version *entity.SubmissionVersion) error {
+	return tx.Debug().Model(&entity.TeamProgress{}).
+		Where("team_progress_id = ?", version.TeamProgressID).
+		Updates(map[string]interface{}{
+			"gdrive_link":     version.GdriveLink,
+			"file_key":        version.FileKey,
+			"file_name":       version.FileName,
+			"file_type":       version.FileType,
+			"file_size":       version.FileSize,
+			"current_version": version.Version,
+		}).Error
+}
+
+func (t *SubmissionRepository) CreateSubmissionVersion(tx *gorm.DB, version *entity.SubmissionVersion) error {
+	err := tx.Debug().Create(version).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *SubmissionRepository) GetSubmissionVersion(tx *gorm.DB, teamProgressID int, version int) (*entity.SubmissionVersion, error) {
+	var submissionVersion entity.SubmissionVersion
+	err := tx.Where("team_progress_id = ? AND version = ?", teamProgressID, version).First(&submissionVersion).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &submissionVersion, nil
+}
+
+func (t *SubmissionRepository) GetSubmissionHistory(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamProgress, error) {
+	var progresses []entity.TeamProgress
+	err := tx.
+		Preload("Versions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("version DESC")
+		}).
+		Joins("JOIN stages ON stages.stage_id = team_progresses.stage_id").
+		Where("team_progresses.team_id = ?", teamID).
+		Order("stages.stage_order ASC").
+		Find(&progresses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return progresses, nil
+}
+
+// DeleteSubmissionsByTeamID menghapus seluruh progres tim beserta versi berkas dan penilaian juri,
+// progres yang dihapus dikembalikan agar berkasnya dapat dibersihkan dari storage.
+func (t *SubmissionRepository) DeleteSubmissionsByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamProgress, error) {
+	var progresses []entity.TeamProgress
+	err := tx.Preload("Versions").Where("team_id = ?", teamID).Find(&progresses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(progresses) == 0 {
+		return nil, nil
+	}
+
+	progressIDs := make([]int, 0, len(progresses))
+	for _, v := range progresses {
+		progressIDs = append(progressIDs, v.TeamProgressID)
+	}
+
+	err = tx.Debug().Where("assignment_id IN (?)", tx.Model(&entity.JudgeAssignment{}).Select("assignment_id").Where("team_progress_id IN ?", progressIDs)).
+		Delete(&entity.JudgeScore{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("team_progress_id IN ?", progressIDs).Delete(&entity.JudgeAssignment{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("team_progress_id IN ?", progressIDs).Delete(&entity.SubmissionVersion{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Debug().Where("team_progress_id IN ?", progressIDs).Delete(&entity.TeamProgress{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return progresses, nil
 }
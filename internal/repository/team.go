@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ITeamRepository interface {
@@ -13,13 +14,26 @@ type ITeamRepository interface {
 	GetTeamByName(tx *gorm.DB, teamName string) error
 	GetTeam(tx *gorm.DB) ([]*entity.Team, error)
 	GetTeamByID(tx *gorm.DB, teamID uuid.UUID) (*entity.Team, error)
+	LockTeam(tx *gorm.DB, teamID uuid.UUID) (*entity.Team, error)
 	CreateTeamMember(tx *gorm.DB, teamMember *entity.TeamMember) error
 	GetTeamByUserID(tx *gorm.DB, userID uuid.UUID) (*entity.Team, error)
 	UpdateTeam(tx *gorm.DB, team *entity.Team) error
 	DeleteTeamMembers(tx *gorm.DB, teamID uuid.UUID) error
 	GetTeamMemberByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]*entity.TeamMember, error)
-	GetCount(tx *gorm.DB, competitionID string) (int64, error)
+	GetCount(tx *gorm.DB, competitionID int) (int64, error)
 	UpdateTeamStatus(tx *gorm.DB, req model.ReqUpdateStatusTeam) error
+	GetUnpaidTeams(tx *gorm.DB) ([]entity.Team, error)
+	GetTeamsByIDs(tx *gorm.DB, teamIDs []uuid.UUID) ([]entity.Team, error)
+	GetTeamForUser(tx *gorm.DB, userID uuid.UUID) (*entity.Team, error)
+	GetTeamMemberByUserID(tx *gorm.DB, userID uuid.UUID) (*entity.TeamMember, error)
+	DeleteTeam(tx *gorm.DB, teamID uuid.UUID) error
+	DeleteTeamMember(tx *gorm.DB, teamMemberID uuid.UUID) error
+	DeleteAllTeamMembers(tx *gorm.DB, teamID uuid.UUID) error
+	UpdateTeamLeader(tx *gorm.DB, teamID uuid.UUID, userID uuid.UUID) error
+	GetParticipants(tx *gorm.DB, competitionID int) ([]model.Participant, error)
+	UpdateLifecycle(tx *gorm.DB, team *entity.Team) error
+	CreateLifecycleHistory(tx *gorm.DB, history *entity.TeamLifecycleHistory) error
+	GetLifecycleHistory(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamLifecycleHistory, error)
 }
 
 type TeamRepository struct {
@@ -50,10 +64,11 @@ func (t *TeamRepository) GetTeamByName(tx *gorm.DB, teamName string) error {
 	return nil
 }
 
-func (t *TeamRepository) GetCount(tx *gorm.DB, competitionID string) (int64, error) {
+func (t *TeamRepository) GetCount(tx *gorm.DB, competitionID int) (int64, error) {
 	var count int64
-	query := tx.Debug().Model(&entity.Team{}).Where("competition_id >= ?", 2)
-	if competitionID != "" {
+	query := tx.Debug().Model(&entity.Team{}).
+		Where("competition_id <> ? AND lifecycle_status NOT IN ?", entity.UnassignedCompetitionID, []string{entity.TeamWithdrawn, entity.TeamDisqualified})
+	if competitionID != 0 {
 		query = query.Where("competition_id = ?", competitionID)
 	}
 	err := query.Count(&count).Error
@@ -90,6 +105,17 @@ func (t *TeamRepository) CreateTeamMember(tx *gorm.DB, teamMember *entity.TeamMe
 	return nil
 }
 
+// LockTeam mengunci baris tim agar pengecekan slot anggota tidak balapan antar undangan.
+func (t *TeamRepository) LockTeam(tx *gorm.DB, teamID uuid.UUID) (*entity.Team, error) {
+	var team entity.Team
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("team_id = ?", teamID).First(&team).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
 func (t *TeamRepository) GetTeamByUserID(tx *gorm.DB, userID uuid.UUID) (*entity.Team, error) {
 	var team entity.Team
 	err := tx.Where("user_id = ?", userID).First(&team).Error
@@ -109,8 +135,9 @@ func (t *TeamRepository) UpdateTeam(tx *gorm.DB, team *entity.Team) error {
 	return nil
 }
 
+// DeleteTeamMembers hanya menghapus anggota yang diisi manual, anggota yang tertaut akun tetap dipertahankan.
 func (t *TeamRepository) DeleteTeamMembers(tx *gorm.DB, teamID uuid.UUID) error {
-	err := tx.Where("team_id = ?", teamID).Delete(&entity.TeamMember{}).Error
+	err := tx.Where("team_id = ? AND user_id IS NULL", teamID).Delete(&entity.TeamMember{}).Error
 	if err != nil {
 		return err
 	}
@@ -133,3 +160,154 @@ func (t *TeamRepository) UpdateTeamStatus(tx *gorm.DB, req model.ReqUpdateStatus
 		Where("team_id = ?", req.TeamID).
 		Update("team_status", req.PaymentStatus).Error
 }
+
+func (t *TeamRepository) GetUnpaidTeams(tx *gorm.DB) ([]entity.Team, error) {
+	var teams []entity.Team
+	err := tx.
+		Where("team_status <> ? AND competition_id <> ?", "terverifikasi", entity.UnassignedCompetitionID).
+		Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (t *TeamRepository) GetTeamsByIDs(tx *gorm.DB, teamIDs []uuid.UUID) ([]entity.Team, error) {
+	var teams []entity.Team
+	err := tx.Where("team_id IN ?", teamIDs).Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// GetTeamForUser mencari tim yang dipimpin user atau tim tempat user tergabung sebagai anggota.
+func (t *TeamRepository) GetTeamForUser(tx *gorm.DB, userID uuid.UUID) (*entity.Team, error) {
+	var team entity.Team
+	err := tx.
+		Where("user_id = ? OR team_id IN (SELECT team_id FROM team_members WHERE user_id = ?)", userID, userID).
+		First(&team).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+func (t *TeamRepository) GetTeamMemberByUserID(tx *gorm.DB, userID uuid.UUID) (*entity.TeamMember, error) {
+	var member entity.TeamMember
+	err := tx.Where("user_id = ?", userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (t *TeamRepository) DeleteTeam(tx *gorm.DB, teamID uuid.UUID) error {
+	err := tx.Debug().Where("team_id = ?", teamID).Delete(&entity.TeamLifecycleHistory{}).Error
+	if err != nil {
+		return err
+	}
+
+	// baris waitlist yang sudah dibatalkan atau dipromosikan tetap menyimpan foreign key ke tim
+	err = tx.Debug().Where("team_id = ?", teamID).Delete(&entity.CompetitionWaitlist{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Debug().Where("team_id = ?", teamID).Delete(&entity.Team{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamRepository) DeleteTeamMember(tx *gorm.DB, teamMemberID uuid.UUID) error {
+	err := tx.Debug().Where("team_member_id = ?", teamMemberID).Delete(&entity.TeamMember{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAllTeamMembers menghapus seluruh anggota tim, termasuk anggota yang tertaut akun.
+func (t *TeamRepository) DeleteAllTeamMembers(tx *gorm.DB, teamID uuid.UUID) error {
+	err := tx.Debug().Where("team_id = ?", teamID).Delete(&entity.TeamMember{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamRepository) UpdateTeamLeader(tx *gorm.DB, teamID uuid.UUID, userID uuid.UUID) error {
+	return tx.Debug().Model(&entity.Team{}).
+		Where("team_id = ?", teamID).
+		Update("user_id", userID).Error
+}
+
+// GetParticipants mengambil ketua dan anggota seluruh tim aktif pada lomba, competitionID 0 berarti semua lomba.
+// Anggota yang diisi manual tidak punya universitas sendiri sehingga memakai universitas ketuanya.
+func (t *TeamRepository) GetParticipants(tx *gorm.DB, competitionID int) ([]model.Participant, error) {
+	var leaders []model.Participant
+	query := tx.Table("teams").
+		Select("teams.team_id, teams.team_name, teams.competition_id, users.user_id, ? AS role, users.full_name AS name, users.student_number, users.university", "leader").
+		Joins("JOIN users ON users.user_id = teams.user_id").
+		Where("teams.competition_id <> ? AND teams.lifecycle_status NOT IN ?", entity.UnassignedCompetitionID, []string{entity.TeamWithdrawn, entity.TeamDisqualified})
+	if competitionID != 0 {
+		query = query.Where("teams.competition_id = ?", competitionID)
+	}
+
+	err := query.Scan(&leaders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var members []model.Participant
+	query = tx.Table("team_members").
+		Select("teams.team_id, teams.team_name, teams.competition_id, team_members.user_id, team_members.team_member_id, ? AS role, team_members.member_name AS name, team_members.student_number, COALESCE(member_users.university, leaders.university) AS university", "member").
+		Joins("JOIN teams ON teams.team_id = team_members.team_id").
+		Joins("JOIN users AS leaders ON leaders.user_id = teams.user_id").
+		Joins("LEFT JOIN users AS member_users ON member_users.user_id = team_members.user_id").
+		Where("teams.competition_id <> ? AND teams.lifecycle_status NOT IN ?", entity.UnassignedCompetitionID, []string{entity.TeamWithdrawn, entity.TeamDisqualified})
+	if competitionID != 0 {
+		query = query.Where("teams.competition_id = ?", competitionID)
+	}
+
+	err = query.Scan(&members).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(leaders, members...), nil
+}
+
+func (t *TeamRepository) UpdateLifecycle(tx *gorm.DB, team *entity.Team) error {
+	return tx.Debug().Model(team).
+		Select("lifecycle_status", "lifecycle_reason", "lifecycle_changed_at").
+		Updates(team).Error
+}
+
+func (t *TeamRepository) CreateLifecycleHistory(tx *gorm.DB, history *entity.TeamLifecycleHistory) error {
+	err := tx.Debug().Create(history).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamRepository) GetLifecycleHistory(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamLifecycleHistory, error) {
+	var histories []entity.TeamLifecycleHistory
+	err := tx.Where("team_id = ?", teamID).Order("created_at ASC").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
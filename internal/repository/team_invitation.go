@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ITeamInvitationRepository interface {
+	CreateInvitation(tx *gorm.DB, invitation *entity.TeamInvitation) error
+	UpdateInvitation(tx *gorm.DB, invitation *entity.TeamInvitation) error
+	GetInvitationByID(tx *gorm.DB, invitationID uuid.UUID) (*entity.TeamInvitation, error)
+	GetInvitationsByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamInvitation, error)
+	GetPendingInvitationsByEmail(tx *gorm.DB, email string) ([]entity.TeamInvitation, error)
+	CountPendingInvitations(tx *gorm.DB, teamID uuid.UUID) (int64, error)
+	HasPendingInvitation(tx *gorm.DB, teamID uuid.UUID, email string) (bool, error)
+	ExpireInvitations(tx *gorm.DB) error
+	DeleteInvitationsByTeamID(tx *gorm.DB, teamID uuid.UUID) error
+}
+
+type TeamInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamInvitationRepository(db *gorm.DB) ITeamInvitationRepository {
+	return &TeamInvitationRepository{
+		db: db,
+	}
+}
+
+func (t *TeamInvitationRepository) CreateInvitation(tx *gorm.DB, invitation *entity.TeamInvitation) error {
+	err := tx.Debug().Omit("Team").Create(invitation).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamInvitationRepository) UpdateInvitation(tx *gorm.DB, invitation *entity.TeamInvitation) error {
+	err := tx.Debug().Omit("Team").Save(invitation).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *TeamInvitationRepository) GetInvitationByID(tx *gorm.DB, invitationID uuid.UUID) (*entity.TeamInvitation, error) {
+	var invitation entity.TeamInvitation
+	err := tx.Preload("Team").Where("invitation_id = ?", invitationID).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (t *TeamInvitationRepository) GetInvitationsByTeamID(tx *gorm.DB, teamID uuid.UUID) ([]entity.TeamInvitation, error) {
+	var invitations []entity.TeamInvitation
+	err := tx.Where("team_id = ?", teamID).Order("created_at DESC").Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (t *TeamInvitationRepository) GetPendingInvitationsByEmail(tx *gorm.DB, email string) ([]entity.TeamInvitation, error) {
+	var invitations []entity.TeamInvitation
+	err := tx.Preload("Team").
+		Where("email = ? AND status = ? AND expires_at > ?", email, "pending", time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (t *TeamInvitationRepository) CountPendingInvitations(tx *gorm.DB, teamID uuid.UUID) (int64, error) {
+	var count int64
+	err := tx.Model(&entity.TeamInvitation{}).
+		Where("team_id = ? AND status = ? AND expires_at > ?", teamID, "pending", time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (t *TeamInvitationRepository) HasPendingInvitation(tx *gorm.DB, teamID uuid.UUID, email string) (bool, error) {
+	var count int64
+	err := tx.Model(&entity.TeamInvitation{}).
+		Where("team_id = ? AND email = ? AND status = ? AND expires_at > ?", teamID, email, "pending", time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ExpireInvitations menandai undangan yang sudah lewat batas waktu agar tidak bisa diterima lagi.
+func (t *TeamInvitationRepository) ExpireInvitations(tx *gorm.DB) error {
+	return tx.Debug().Model(&entity.TeamInvitation{}).
+		Where("status = ? AND expires_at <= ?", "pending", time.Now()).
+		Update("status", "expired").Error
+}
+
+func (t *TeamInvitationRepository) DeleteInvitationsByTeamID(tx *gorm.DB, teamID uuid.UUID) error {
+	err := tx.Debug().Where("team_id = ?", teamID).Delete(&entity.TeamInvitation{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"itfest-2025/entity"
 	"itfest-2025/model"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -13,6 +14,7 @@ type IUserRepository interface {
 	GetUser(param model.UserParam) (*entity.User, error)
 	GetAllUser() ([]*entity.User, error)
 	GetCountPayment() (int64, error)
+	IncrementTokenVersion(tx *gorm.DB, userID uuid.UUID) error
 }
 
 type UserRepository struct {
@@ -65,9 +67,17 @@ func (u *UserRepository) GetAllUser() ([]*entity.User, error) {
 
 func (u *UserRepository) GetCountPayment() (int64, error) {
 	var count int64
-	err := u.db.Debug().Model(&entity.User{}).Where("registration_link IS NOT NULL AND payment_transc <> ''").Count(&count).Error
+	err := u.db.Debug().Model(&entity.User{}).Where("registration_link IS NOT NULL AND payment_transc <> ''").
+		Where("user_id NOT IN (SELECT user_id FROM teams WHERE lifecycle_status IN ?)", []string{entity.TeamWithdrawn, entity.TeamDisqualified}).
+		Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
 	return count, nil
 }
+
+func (u *UserRepository) IncrementTokenVersion(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Debug().Model(&entity.User{}).
+		Where("user_id = ?", userID).
+		Update("token_version", gorm.Expr("token_version + ?", 1)).Error
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IVoucherRepository interface {
+	GetVouchers(tx *gorm.DB) ([]entity.Voucher, error)
+	GetVoucherByID(tx *gorm.DB, voucherID int) (*entity.Voucher, error)
+	GetVoucherByCode(tx *gorm.DB, code string) (*entity.Voucher, error)
+	LockVoucher(tx *gorm.DB, voucherID int) (*entity.Voucher, error)
+	CreateVoucher(tx *gorm.DB, voucher *entity.Voucher) error
+	UpdateVoucher(tx *gorm.DB, voucher *entity.Voucher) error
+	AddVoucherUsage(tx *gorm.DB, voucherID int, delta int) error
+	GetRedemptionByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.VoucherRedemption, error)
+	CreateRedemption(tx *gorm.DB, redemption *entity.VoucherRedemption) error
+	DeleteRedemption(tx *gorm.DB, redemption *entity.VoucherRedemption) error
+}
+
+type VoucherRepository struct {
+	db *gorm.DB
+}
+
+func NewVoucherRepository(db *gorm.DB) IVoucherRepository {
+	return &VoucherRepository{
+		db: db,
+	}
+}
+
+func (v *VoucherRepository) GetVouchers(tx *gorm.DB) ([]entity.Voucher, error) {
+	var vouchers []entity.Voucher
+	err := tx.Preload("Redemptions").Order("created_at DESC").Find(&vouchers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return vouchers, nil
+}
+
+func (v *VoucherRepository) GetVoucherByID(tx *gorm.DB, voucherID int) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	err := tx.
+		Preload("Redemptions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at DESC")
+		}).
+		Where("voucher_id = ?", voucherID).
+		First(&voucher).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &voucher, nil
+}
+
+func (v *VoucherRepository) GetVoucherByCode(tx *gorm.DB, code string) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	err := tx.Where("code = ?", code).First(&voucher).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &voucher, nil
+}
+
+func (v *VoucherRepository) LockVoucher(tx *gorm.DB, voucherID int) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("voucher_id = ?", voucherID).First(&voucher).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &voucher, nil
+}
+
+func (v *VoucherRepository) CreateVoucher(tx *gorm.DB, voucher *entity.Voucher) error {
+	err := tx.Debug().Omit("Redemptions").Create(voucher).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *VoucherRepository) UpdateVoucher(tx *gorm.DB, voucher *entity.Voucher) error {
+	err := tx.Debug().Model(&entity.Voucher{}).
+		Where("voucher_id = ?", voucher.VoucherID).
+		Select("discount_type", "discount_value", "max_uses", "expires_at", "allowed_universities", "is_active").
+		Updates(voucher).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *VoucherRepository) AddVoucherUsage(tx *gorm.DB, voucherID int, delta int) error {
+	err := tx.Debug().Model(&entity.Voucher{}).
+		Where("voucher_id = ?", voucherID).
+		Update("used_count", gorm.Expr("GREATEST(used_count + ?, 0)", delta)).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *VoucherRepository) GetRedemptionByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.VoucherRedemption, error) {
+	var redemption entity.VoucherRedemption
+	err := tx.Where("team_id = ?", teamID).First(&redemption).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &redemption, nil
+}
+
+func (v *VoucherRepository) CreateRedemption(tx *gorm.DB, redemption *entity.VoucherRedemption) error {
+	err := tx.Debug().Create(redemption).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *VoucherRepository) DeleteRedemption(tx *gorm.DB, redemption *entity.VoucherRedemption) error {
+	err := tx.Debug().Delete(redemption).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"itfest-2025/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IWaitlistRepository interface {
+	CreateEntry(tx *gorm.DB, entry *entity.CompetitionWaitlist) error
+	UpdateEntry(tx *gorm.DB, entry *entity.CompetitionWaitlist) error
+	GetWaitingEntryByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.CompetitionWaitlist, error)
+	GetFirstWaitingEntry(tx *gorm.DB, competitionID int) (*entity.CompetitionWaitlist, error)
+	GetWaitingEntries(tx *gorm.DB, competitionID int) ([]entity.CompetitionWaitlist, error)
+	CountEntriesAhead(tx *gorm.DB, entry *entity.CompetitionWaitlist) (int64, error)
+}
+
+type WaitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) IWaitlistRepository {
+	return &WaitlistRepository{
+		db: db,
+	}
+}
+
+func (w *WaitlistRepository) CreateEntry(tx *gorm.DB, entry *entity.CompetitionWaitlist) error {
+	err := tx.Debug().Omit("Team", "Competition").Create(entry).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *WaitlistRepository) UpdateEntry(tx *gorm.DB, entry *entity.CompetitionWaitlist) error {
+	err := tx.Debug().Omit("Team", "Competition").Save(entry).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *WaitlistRepository) GetWaitingEntryByTeamID(tx *gorm.DB, teamID uuid.UUID) (*entity.CompetitionWaitlist, error) {
+	var entry entity.CompetitionWaitlist
+	err := tx.Preload("Competition").
+		Where("team_id = ? AND status = ?", teamID, entity.WaitlistWaiting).
+		First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (w *WaitlistRepository) GetFirstWaitingEntry(tx *gorm.DB, competitionID int) (*entity.CompetitionWaitlist, error) {
+	var entry entity.CompetitionWaitlist
+	err := tx.Where("competition_id = ? AND status = ?", competitionID, entity.WaitlistWaiting).
+		Order("waitlist_id ASC").
+		First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (w *WaitlistRepository) GetWaitingEntries(tx *gorm.DB, competitionID int) ([]entity.CompetitionWaitlist, error) {
+	var entries []entity.CompetitionWaitlist
+	err := tx.Preload("Team").Preload("Competition").
+		Where("competition_id = ? AND status = ?", competitionID, entity.WaitlistWaiting).
+		Order("waitlist_id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CountEntriesAhead menghitung antrean yang masih menunggu sebelum entry pada lomba yang sama.
+func (w *WaitlistRepository) CountEntriesAhead(tx *gorm.DB, entry *entity.CompetitionWaitlist) (int64, error) {
+	var count int64
+	err := tx.Model(&entity.CompetitionWaitlist{}).
+		Where("competition_id = ? AND status = ? AND waitlist_id < ?", entry.CompetitionID, entity.WaitlistWaiting, entry.WaitlistID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
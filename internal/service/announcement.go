@@ -1,13 +1,16 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"itfest-2025/entity"
 	"itfest-2025/internal/repository"
 	"itfest-2025/model"
 	"itfest-2025/pkg/database/mariadb"
 	"itfest-2025/pkg/mail"
-	"strings"
-
+	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +18,14 @@ import (
 )
 
 type IAnnouncementService interface {
-	SendAnnouncement(req model.RequestAnnouncement) error
+	SendAnnouncement(actorID uuid.UUID, req model.RequestAnnouncement) (*model.SendAnnouncementResponse, error)
+	UpdateAnnouncement(actorID uuid.UUID, announcementID uuid.UUID, req model.UpdateAnnouncementRequest) (*model.ResponseAnnouncement, error)
+	DeleteAnnouncement(actorID uuid.UUID, announcementID uuid.UUID) error
 	GetAnnouncement() ([]*model.ResponseAnnouncement, error)
+	GetMyAnnouncements(userID uuid.UUID) ([]*model.ResponseAnnouncement, error)
+	GetRevisions(announcementID uuid.UUID) ([]model.AnnouncementRevisionResponse, error)
+	StartScheduler(ctx context.Context, wg *sync.WaitGroup)
+	PublishDueAnnouncements() (int, error)
 }
 
 type AnnouncementService struct {
@@ -24,203 +33,525 @@ type AnnouncementService struct {
 	UserRepository         repository.IUserRepository
 	TeamRepository         repository.ITeamRepository
 	AnnouncementRepository repository.IAnnouncementRepository
+	CompetitionRepository  repository.ICompetitionRepository
+	SubmissionRepository   repository.ISubmissionRepository
+	OutboxRepository       repository.IEmailOutboxRepository
 }
 
-func NewAnnouncementService(userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, announcementRepository repository.IAnnouncementRepository) IAnnouncementService {
+func NewAnnouncementService(userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, announcementRepository repository.IAnnouncementRepository, competitionRepository repository.ICompetitionRepository, submissionRepository repository.ISubmissionRepository, outboxRepository repository.IEmailOutboxRepository) IAnnouncementService {
 	return &AnnouncementService{
 		db:                     mariadb.Connection,
 		UserRepository:         userRepository,
 		TeamRepository:         teamRepository,
 		AnnouncementRepository: announcementRepository,
+		CompetitionRepository:  competitionRepository,
+		SubmissionRepository:   submissionRepository,
+		OutboxRepository:       outboxRepository,
 	}
 }
 
 func (a *AnnouncementService) GetAnnouncement() ([]*model.ResponseAnnouncement, error) {
-	var response []*model.ResponseAnnouncement
 	data, err := a.AnnouncementRepository.GetAnnouncement()
 	if err != nil {
 		return nil, err
 	}
+
+	var response []*model.ResponseAnnouncement
 	for _, v := range data {
-		response = append(response, &model.ResponseAnnouncement{
-			AnnouncementID: v.AnnouncementID.String(),
-			Message:        v.Description,
-			Date:           v.CreatedAt,
-		})
+		response = append(response, toAnnouncementResponse(v))
 	}
 
 	return response, nil
 }
 
-func (a *AnnouncementService) SendAnnouncement(req model.RequestAnnouncement) error {
-	users, err := a.UserRepository.GetAllUser()
+// GetMyAnnouncements hanya mengembalikan pengumuman umum dan pengumuman yang targetnya cocok dengan tim user.
+func (a *AnnouncementService) GetMyAnnouncements(userID uuid.UUID) ([]*model.ResponseAnnouncement, error) {
+	team, err := a.TeamRepository.GetTeamForUser(a.db, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// user tanpa tim memakai uuid.Nil sehingga hanya pengumuman tanpa target yang cocok
+	teamID := uuid.Nil
+	if team != nil {
+		teamID = team.TeamID
+	}
 
+	data, err := a.AnnouncementRepository.GetPublishedAnnouncementsForTeam(teamID)
 	if err != nil {
-		return err
-	} else if len(users) == 0 {
-		return model.ErrUserRecordNotFound
+		return nil, err
+	}
+
+	response := []*model.ResponseAnnouncement{}
+	for _, v := range data {
+		response = append(response, toAnnouncementResponse(v))
 	}
 
+	return response, nil
+}
+
+func (a *AnnouncementService) SendAnnouncement(actorID uuid.UUID, req model.RequestAnnouncement) (*model.SendAnnouncementResponse, error) {
 	tx := a.db.Begin()
 	defer tx.Rollback()
 
-	err = a.AnnouncementRepository.CreateAnnouncement(tx, entity.Announcement{
-		AnnouncementID: uuid.New(),
-		Title:          "Announcement",
-		Description:    req.Message,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-	})
+	now := time.Now()
+	title := req.Title
+	if title == "" {
+		title = "Announcement"
+	}
+
+	announcement := entity.Announcement{
+		AnnouncementID:   uuid.New(),
+		Title:            title,
+		Description:      req.Message,
+		CompetitionID:    req.CompetitionID,
+		StageID:          req.StageID,
+		SubmissionStatus: req.SubmissionStatus,
+		PaymentStatus:    req.PaymentStatus,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	err := a.validateTarget(tx, &announcement)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// tanpa status, pengumuman dengan publish_at dijadwalkan dan sisanya langsung terbit
+	status := req.Status
+	if status == "" {
+		status = entity.AnnouncementPublished
+		if req.PublishAt != nil {
+			status = entity.AnnouncementScheduled
+		}
+	}
+
+	err = applySchedule(&announcement, status, req.PublishAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if announcement.Status == entity.AnnouncementPublished {
+		err = a.publish(tx, &announcement, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.AnnouncementRepository.CreateAnnouncement(tx, announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.AnnouncementRepository.CreateRevision(tx, newAnnouncementRevision(announcement, entity.RevisionCreated, false, &actorID))
+	if err != nil {
+		return nil, err
 	}
 
 	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SendAnnouncementResponse{
+		AnnouncementID: announcement.AnnouncementID,
+		Status:         announcement.Status,
+		Recipients:     announcement.RecipientCount,
+	}, nil
+}
+
+// UpdateAnnouncement mengganti isi pengumuman dan mencatat revisinya.
+// Pengumuman yang sudah terbit hanya dikirim ulang lewat email jika resend_email diisi.
+func (a *AnnouncementService) UpdateAnnouncement(actorID uuid.UUID, announcementID uuid.UUID, req model.UpdateAnnouncementRequest) (*model.ResponseAnnouncement, error) {
+	tx := a.db.Begin()
+	defer tx.Rollback()
+
+	announcement, err := a.getAnnouncement(tx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	wasPublished := announcement.Status == entity.AnnouncementPublished
+
+	status := req.Status
+	if status == "" {
+		status = announcement.Status
+	}
+	if wasPublished && status != entity.AnnouncementPublished {
+		return nil, model.ErrAnnouncementPublished
+	}
+
+	previous := *announcement
+
+	if req.Title != "" {
+		announcement.Title = req.Title
+	}
+	announcement.Description = req.Message
+	announcement.CompetitionID = req.CompetitionID
+	announcement.StageID = req.StageID
+	announcement.SubmissionStatus = req.SubmissionStatus
+	announcement.PaymentStatus = req.PaymentStatus
+
+	err = a.validateTarget(tx, announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	// recipient_count hanya dihitung ulang saat email dikirim ulang, jadi target tidak boleh berubah tanpa resend
+	if wasPublished && !req.ResendEmail && !sameTarget(previous, *announcement) {
+		return nil, model.ErrAnnouncementTargetLocked
+	}
+
+	if !wasPublished {
+		err = applySchedule(announcement, status, req.PublishAt, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	action := entity.RevisionUpdated
+	resent := false
+	if !wasPublished && announcement.Status == entity.AnnouncementPublished {
+		action = entity.RevisionPublished
+		err = a.publish(tx, announcement, now)
+	} else if wasPublished && req.ResendEmail {
+		resent = true
+		announcement.RecipientCount, err = a.queueAnnouncementEmails(tx, *announcement, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.AnnouncementRepository.UpdateAnnouncement(tx, announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.AnnouncementRepository.CreateRevision(tx, newAnnouncementRevision(*announcement, action, resent, &actorID))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toAnnouncementResponse(announcement), nil
+}
+
+// DeleteAnnouncement menarik pengumuman, isi terakhirnya tetap tersimpan sebagai revisi deleted.
+// Email yang sudah masuk outbox tidak dibatalkan.
+func (a *AnnouncementService) DeleteAnnouncement(actorID uuid.UUID, announcementID uuid.UUID) error {
+	tx := a.db.Begin()
+	defer tx.Rollback()
+
+	announcement, err := a.getAnnouncement(tx, announcementID)
 	if err != nil {
 		return err
 	}
 
-	mailBody := `
-		<!DOCTYPE html>
-		<html lang="id">
-
-		<head>
-			<meta charset="UTF-8">
-			<meta name="viewport" content="width=device-width, initial-scale=1.0">
-			<title>Pengumuman Terbaru IT FEST 2025</title>
-			<style type="text/css">
-				/* Reset CSS dasar untuk klien email */
-				body, table, td, a {
-					-webkit-text-size-adjust: 100%;
-					-ms-text-size-adjust: 100%;
-				}
-				table, td {
-					mso-table-lspace: 0pt;
-					mso-table-rspace: 0pt;
-				}
-				img {
-					-ms-interpolation-mode: bicubic;
-					border: 0;
-					height: auto;
-					line-height: 100%;
-					outline: none;
-					text-decoration: none;
-				}
-				body {
-					height: 100% !important;
-					margin: 0 !important;
-					padding: 0 !important;
-					width: 100% !important;
-				}
-
-				/* Styling untuk teks yang konsisten */
-				.paragraph {
-					font-family: Arial, sans-serif; /* Fallback for Changa */
-					font-size: 16px;
-					line-height: 1.5;
-					color: #d1d1d1;
-					text-align: left;
-				}
-				.header-text {
-					font-family: Arial, sans-serif; /* Fallback for Changa */
-					font-size: 24px;
-					font-weight: bold;
-					color: #ffffff;
-				}
-				.footer-text {
-					font-family: Arial, sans-serif; /* Fallback for Changa */
-					font-size: 12px;
-					line-height: 1.5;
-					color: #a0a0a0;
-				}
-
-				/* Media Queries untuk Responsivitas (Opsional, tidak semua klien email mendukung) */
-				@media screen and (max-width: 600px) {
-					.full-width-image {
-						width: 100% !important;
-						max-width: 100% !important;
-					}
-					.header-text {
-						font-size: 20px !important; /* Ukuran font lebih kecil di mobile */
-					}
-					.paragraph {
-						font-size: 14px !important; /* Ukuran font lebih kecil di mobile */
-					}
-					.content-padding {
-						padding: 10px !important;
-					}
-				}
-			</style>
-		</head>
-
-		<body style="margin: 0; padding: 0; background-color: #030D35;">
-			<div style="background: linear-gradient(to bottom, #030D35 0%, #19217C 100%);">
-				<table border="0" cellpadding="0" cellspacing="0" width="100%" style="max-width: 600px; margin: 0 auto; background-color: transparent;">
-					<tr>
-						<td align="center" valign="top" style="padding: 40px 20px 20px 20px;" class="content-padding">
-							<table border="0" cellpadding="0" cellspacing="0" width="100%">
-
-								<tr>
-									<td align="center" style="padding-bottom: 20px;">
-										<img src="https://i.imgur.com/3fcE9Ll.png" width="300" alt="IT FEST 2025 Logo" style="display: block; width: 300px; max-width: 100%; min-width: 100px; font-family: Arial, sans-serif; color: #ffffff;" class="full-width-image">
-									</td>
-								</tr>
-
-								<tr>
-									<td align="center" style="padding: 20px 0;">
-										<img src="https://i.imgur.com/2pvNmnv.png" width="130" alt="Ikon Pengumuman" style="display: block; width: 130px;">
-									</td>
-								</tr>
-
-								<tr>
-									<td align="center" style="padding: 10px 0; font-family: Arial, sans-serif; font-size: 24px; font-weight: bold; color: #ffffff;" class="header-text">
-										Pengumuman Terbaru ITFEST 2025
-									</td>
-								</tr>
-
-								<tr>
-									<td align="center" style="padding: 10px 20px; font-family: Arial, sans-serif; font-size: 16px; line-height: 1.5; color: #d1d1d1; text-align: left;" class="paragraph">
-										Halo Para Peserta, berikut pengumuman penting dari Panitia IT FEST 2025:
-										<br><br>
-											$MESSAGE$
-										<br><br>
-										Untuk informasi lebih lengkap, silakan kunjungi laman Dashboard Anda.
-									</td>
-								</tr>
-
-								<tr>
-									<td align="center" style="padding: 20px 20px; font-family: Arial, sans-serif; font-size: 16px; line-height: 1.5; color: #d1d1d1;" class="paragraph">
-										Terima kasih,<br>
-										Tim Panitia IT FEST
-									</td>
-								</tr>
-
-								<tr>
-									<td align="center" style="padding: 0 20px 40px 20px; font-family: Arial, sans-serif; font-size: 12px; line-height: 1.5; color: #a0a0a0;" class="footer-text">
-										Keluarga Besar Mahasiswa Departemen Sistem Informasi
-										<br>
-										Universitas Brawijaya
-									</td>
-								</tr>
-
-							</table>
-						</td>
-					</tr>
-				</table>
-			</div>
-		</body>
-		</html>
-	`
-	mailBody = strings.Replace(mailBody, "$MESSAGE$", req.Message, 1)
-
-	for _, v := range users {
-		if v.RoleID == 2 && v.StatusAccount == "active" {
-			err = mail.SendEmail(v.Email, "Pengumuman IT FEST 2025", mailBody)
+	err = a.AnnouncementRepository.CreateRevision(tx, newAnnouncementRevision(*announcement, entity.RevisionDeleted, false, &actorID))
+	if err != nil {
+		return err
+	}
+
+	err = a.AnnouncementRepository.DeleteAnnouncement(tx, announcement.AnnouncementID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetRevisions tetap bisa dipanggil untuk pengumuman yang sudah dihapus.
+func (a *AnnouncementService) GetRevisions(announcementID uuid.UUID) ([]model.AnnouncementRevisionResponse, error) {
+	revisions, err := a.AnnouncementRepository.GetRevisions(a.db, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return nil, model.ErrAnnouncementNotFound
+	}
+
+	var response []model.AnnouncementRevisionResponse
+	for _, v := range revisions {
+		response = append(response, model.AnnouncementRevisionResponse{
+			RevisionID:       v.AnnouncementRevisionID,
+			Action:           v.Action,
+			Title:            v.Title,
+			Message:          v.Description,
+			CompetitionID:    v.CompetitionID,
+			StageID:          v.StageID,
+			SubmissionStatus: v.SubmissionStatus,
+			PaymentStatus:    v.PaymentStatus,
+			Status:           v.Status,
+			PublishAt:        v.PublishAt,
+			EmailResent:      v.EmailResent,
+			ChangedBy:        v.ChangedBy,
+			CreatedAt:        v.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// StartScheduler menerbitkan pengumuman terjadwal yang sudah jatuh tempo secara berkala sampai ctx dibatalkan.
+func (a *AnnouncementService) StartScheduler(ctx context.Context, wg *sync.WaitGroup) {
+	interval := time.Duration(envInt("ANNOUNCEMENT_POLL_INTERVAL", 30)) * time.Second
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			_, err := a.PublishDueAnnouncements()
+			if err != nil {
+				log.Printf("failed to publish scheduled announcements: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// PublishDueAnnouncements menerbitkan satu batch pengumuman terjadwal dan mengembalikan jumlah yang diterbitkan.
+// Setiap pengumuman terbit di transaksinya sendiri agar satu kegagalan tidak menahan pengumuman lain.
+func (a *AnnouncementService) PublishDueAnnouncements() (int, error) {
+	now := time.Now()
+
+	announcementIDs, err := a.AnnouncementRepository.GetDueAnnouncementIDs(a.db, now, envInt("ANNOUNCEMENT_BATCH_SIZE", 20))
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var errs []error
+	for _, v := range announcementIDs {
+		ok, err := a.publishDueAnnouncement(v, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("announcement %s: %w", v, err))
+			continue
+		}
+		if ok {
+			published++
 		}
 	}
 
+	return published, errors.Join(errs...)
+}
+
+// publishDueAnnouncement mengembalikan false tanpa error jika pengumuman sudah diambil scheduler lain atau batal dijadwalkan.
+func (a *AnnouncementService) publishDueAnnouncement(announcementID uuid.UUID, now time.Time) (bool, error) {
+	tx := a.db.Begin()
+	defer tx.Rollback()
+
+	announcement, err := a.AnnouncementRepository.ClaimDueAnnouncement(tx, announcementID, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = a.publish(tx, announcement, now)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.AnnouncementRepository.UpdateAnnouncement(tx, announcement)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.AnnouncementRepository.CreateRevision(tx, newAnnouncementRevision(*announcement, entity.RevisionPublished, false, nil))
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *AnnouncementService) getAnnouncement(tx *gorm.DB, announcementID uuid.UUID) (*entity.Announcement, error) {
+	announcement, err := a.AnnouncementRepository.GetAnnouncementByID(tx, announcementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrAnnouncementNotFound
+		}
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+// publish menandai pengumuman terbit dan mengantrekan emailnya ke penerima yang cocok saat itu.
+func (a *AnnouncementService) publish(tx *gorm.DB, announcement *entity.Announcement, now time.Time) error {
+	recipients, err := a.queueAnnouncementEmails(tx, *announcement, false)
 	if err != nil {
 		return err
 	}
+
+	announcement.Status = entity.AnnouncementPublished
+	announcement.PublishedAt = &now
+	announcement.RecipientCount = recipients
+
+	return nil
+}
+
+// validateTarget memastikan lomba dan tahap yang dituju ada, lomba diisi otomatis dari tahap jika kosong.
+func (a *AnnouncementService) validateTarget(tx *gorm.DB, announcement *entity.Announcement) error {
+	if announcement.SubmissionStatus != "" && announcement.StageID == nil {
+		return model.ErrSubmissionStatusNeedsStage
+	}
+
+	if announcement.CompetitionID != nil {
+		_, err := a.CompetitionRepository.GetCompetitionByID(tx, *announcement.CompetitionID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrCompetitionNotFound
+			}
+			return err
+		}
+	}
+
+	if announcement.StageID != nil {
+		stage, err := a.SubmissionRepository.GetStage(tx, *announcement.StageID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrStageNotFound
+			}
+			return err
+		}
+
+		if announcement.CompetitionID == nil {
+			announcement.CompetitionID = &stage.CompetitionID
+		} else if *announcement.CompetitionID != stage.CompetitionID {
+			return model.ErrStageNotInCompetition
+		}
+	}
+
 	return nil
 }
+
+// queueAnnouncementEmails memasukkan email pengumuman untuk setiap penerima ke outbox dan mengembalikan jumlah penerimanya.
+func (a *AnnouncementService) queueAnnouncementEmails(tx *gorm.DB, announcement entity.Announcement, updated bool) (int, error) {
+	recipients, err := a.AnnouncementRepository.GetRecipients(tx, announcement)
+	if err != nil {
+		return 0, err
+	}
+
+	message, err := mail.NewMessage("", mail.TemplateAnnouncement, mail.AnnouncementData{
+		Title:   announcement.Title,
+		Message: announcement.Description,
+		Updated: updated,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var emails []entity.EmailOutbox
+	for _, v := range recipients {
+		message.To = v.Email
+		email, err := newOutboxEmail(mail.TemplateAnnouncement, message)
+		if err != nil {
+			return 0, err
+		}
+		emails = append(emails, *email)
+	}
+
+	err = a.OutboxRepository.CreateEmails(tx, emails)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(recipients), nil
+}
+
+// applySchedule memasang status dan waktu terbit pengumuman yang belum terbit.
+func applySchedule(announcement *entity.Announcement, status string, publishAt *time.Time, now time.Time) error {
+	if status == entity.AnnouncementScheduled {
+		if publishAt == nil {
+			return model.ErrPublishAtRequired
+		}
+		if !publishAt.After(now) {
+			return model.ErrPublishAtInPast
+		}
+	}
+
+	// publish_at hanya berlaku untuk pengumuman terjadwal
+	if status != entity.AnnouncementScheduled {
+		publishAt = nil
+	}
+
+	announcement.Status = status
+	announcement.PublishAt = publishAt
+
+	return nil
+}
+
+func sameTarget(a, b entity.Announcement) bool {
+	return sameIntPtr(a.CompetitionID, b.CompetitionID) && sameIntPtr(a.StageID, b.StageID) &&
+		a.SubmissionStatus == b.SubmissionStatus && a.PaymentStatus == b.PaymentStatus
+}
+
+func sameIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func newAnnouncementRevision(announcement entity.Announcement, action string, emailResent bool, changedBy *uuid.UUID) *entity.AnnouncementRevision {
+	return &entity.AnnouncementRevision{
+		AnnouncementID:   announcement.AnnouncementID,
+		Action:           action,
+		Title:            announcement.Title,
+		Description:      announcement.Description,
+		CompetitionID:    announcement.CompetitionID,
+		StageID:          announcement.StageID,
+		SubmissionStatus: announcement.SubmissionStatus,
+		PaymentStatus:    announcement.PaymentStatus,
+		Status:           announcement.Status,
+		PublishAt:        announcement.PublishAt,
+		EmailResent:      emailResent,
+		ChangedBy:        changedBy,
+	}
+}
+
+func toAnnouncementResponse(announcement *entity.Announcement) *model.ResponseAnnouncement {
+	// pengumuman lama belum punya published_at sehingga tanggalnya memakai created_at
+	date := announcement.CreatedAt
+	if announcement.PublishedAt != nil {
+		date = *announcement.PublishedAt
+	}
+
+	return &model.ResponseAnnouncement{
+		AnnouncementID:   announcement.AnnouncementID.String(),
+		Title:            announcement.Title,
+		Message:          announcement.Description,
+		Date:             date,
+		CompetitionID:    announcement.CompetitionID,
+		StageID:          announcement.StageID,
+		SubmissionStatus: announcement.SubmissionStatus,
+		PaymentStatus:    announcement.PaymentStatus,
+		Status:           announcement.Status,
+		PublishAt:        announcement.PublishAt,
+		PublishedAt:      announcement.PublishedAt,
+		RecipientCount:   announcement.RecipientCount,
+	}
+}
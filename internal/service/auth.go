@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/jwt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IAuthService interface {
+	IssueToken(tx *gorm.DB, user *entity.User) (model.LoginResponse, error)
+	RefreshToken(param model.RefreshTokenRequest) (model.LoginResponse, error)
+	Logout(param model.RefreshTokenRequest) error
+	RevokeAllSessions(tx *gorm.DB, userID uuid.UUID) error
+	RevokeUserSessions(userID uuid.UUID) error
+	Authenticate(claims *jwt.Claims) (*entity.User, error)
+}
+
+type AuthService struct {
+	db                     *gorm.DB
+	UserRepository         repository.IUserRepository
+	RefreshTokenRepository repository.IRefreshTokenRepository
+	JwtAuth                jwt.Interface
+}
+
+func NewAuthService(userRepository repository.IUserRepository, refreshTokenRepository repository.IRefreshTokenRepository, jwtAuth jwt.Interface) IAuthService {
+	return &AuthService{
+		db:                     mariadb.Connection,
+		UserRepository:         userRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		JwtAuth:                jwtAuth,
+	}
+}
+
+// IssueToken membuat sesi baru berisi access token dan refresh token untuk user.
+func (a *AuthService) IssueToken(tx *gorm.DB, user *entity.User) (model.LoginResponse, error) {
+	return a.issueSessionToken(tx, user, uuid.New())
+}
+
+func (a *AuthService) RefreshToken(param model.RefreshTokenRequest) (model.LoginResponse, error) {
+	var result model.LoginResponse
+
+	tx := a.db.Begin()
+	defer tx.Rollback()
+
+	token, err := a.RefreshTokenRepository.GetRefreshTokenByHash(tx, a.JwtAuth.HashRefreshToken(param.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, model.ErrInvalidRefreshToken
+		}
+		return result, err
+	}
+
+	// refresh token yang sudah dirotasi dipakai ulang, cabut seluruh sesi
+	if token.RevokedAt != nil {
+		err = a.RefreshTokenRepository.RevokeSession(tx, token.SessionID)
+		if err != nil {
+			return result, err
+		}
+
+		err = tx.Commit().Error
+		if err != nil {
+			return result, err
+		}
+
+		return result, model.ErrSessionRevoked
+	}
+
+	if token.ExpiresAt.Before(time.Now()) {
+		return result, model.ErrInvalidRefreshToken
+	}
+
+	user, err := a.UserRepository.GetUser(model.UserParam{
+		UserID: token.UserID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = a.RefreshTokenRepository.RevokeRefreshToken(tx, token.TokenID)
+	if err != nil {
+		return result, err
+	}
+
+	result, err = a.issueSessionToken(tx, user, token.SessionID)
+	if err != nil {
+		return result, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (a *AuthService) Logout(param model.RefreshTokenRequest) error {
+	tx := a.db.Begin()
+	defer tx.Rollback()
+
+	token, err := a.RefreshTokenRepository.GetRefreshTokenByHash(tx, a.JwtAuth.HashRefreshToken(param.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrInvalidRefreshToken
+		}
+		return err
+	}
+
+	err = a.RefreshTokenRepository.RevokeSession(tx, token.SessionID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RevokeAllSessions menaikkan token version user sehingga seluruh access token lama tidak berlaku.
+func (a *AuthService) RevokeAllSessions(tx *gorm.DB, userID uuid.UUID) error {
+	err := a.UserRepository.IncrementTokenVersion(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	return a.RefreshTokenRepository.RevokeAllUserTokens(tx, userID)
+}
+
+func (a *AuthService) RevokeUserSessions(userID uuid.UUID) error {
+	tx := a.db.Begin()
+	defer tx.Rollback()
+
+	_, err := a.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = a.RevokeAllSessions(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (a *AuthService) Authenticate(claims *jwt.Claims) (*entity.User, error) {
+	user, err := a.UserRepository.GetUser(model.UserParam{
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// token reset password dan token tanpa sesi tidak bisa dipakai sebagai access token
+	if user.TokenVersion != claims.TokenVersion || claims.Purpose != "" || claims.SessionID == uuid.Nil {
+		return nil, model.ErrSessionRevoked
+	}
+
+	active, err := a.RefreshTokenRepository.IsSessionActive(claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, model.ErrSessionRevoked
+	}
+
+	return user, nil
+}
+
+func (a *AuthService) issueSessionToken(tx *gorm.DB, user *entity.User, sessionID uuid.UUID) (model.LoginResponse, error) {
+	var result model.LoginResponse
+
+	accessToken, err := a.JwtAuth.CreateJWTToken(user, sessionID)
+	if err != nil {
+		return result, errors.New("failed to create token")
+	}
+
+	refreshToken, refreshHash, err := a.JwtAuth.CreateRefreshToken()
+	if err != nil {
+		return result, errors.New("failed to create token")
+	}
+
+	err = a.RefreshTokenRepository.CreateRefreshToken(tx, &entity.RefreshToken{
+		TokenID:   uuid.New(),
+		UserID:    user.UserID,
+		SessionID: sessionID,
+		TokenHash: refreshHash,
+		ExpiresAt: time.Now().Add(a.JwtAuth.RefreshExpiredTime()),
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Token = accessToken
+	result.RefreshToken = refreshToken
+	result.ExpiresAt = time.Now().Add(a.JwtAuth.AccessExpiredTime())
+
+	return result, nil
+}
@@ -1,26 +1,41 @@
 package service
 
 import (
+	"errors"
+	"itfest-2025/entity"
 	"itfest-2025/internal/repository"
 	"itfest-2025/model"
 	"itfest-2025/pkg/database/mariadb"
+	"sort"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type ICompetitionService interface {
 	GetAllCompetitions() ([]*model.GetAllCompetitionsResponse, error)
+	GetAllCompetitionsAdmin() ([]*model.CompetitionDetailResponse, error)
+	CreateCompetition(param model.CreateCompetitionRequest) (*model.CompetitionDetailResponse, error)
+	UpdateCompetition(competitionID int, param model.UpdateCompetitionRequest) (*model.CompetitionDetailResponse, error)
+	ArchiveCompetition(competitionID int, param model.ArchiveCompetitionRequest) error
+	AddStage(competitionID int, param model.StageRequest) (*model.CompetitionDetailResponse, error)
+	UpdateStage(competitionID int, stageID int, param model.UpdateStageRequest) (*model.CompetitionDetailResponse, error)
+	ReorderStages(competitionID int, param model.ReorderStagesRequest) (*model.CompetitionDetailResponse, error)
+	UpdateCompetitionRule(competitionID int, param model.CompetitionRuleRequest) (*model.CompetitionRuleResponse, error)
 }
 
 type CompetitionService struct {
 	db                    *gorm.DB
 	CompetitionRepository repository.ICompetitionRepository
+	WaitlistService       IWaitlistService
 }
 
-func NewCompetitionService(CompetitionRepository repository.ICompetitionRepository) *CompetitionService {
+func NewCompetitionService(CompetitionRepository repository.ICompetitionRepository, waitlistService IWaitlistService) *CompetitionService {
 	return &CompetitionService{
 		db:                    mariadb.Connection,
 		CompetitionRepository: CompetitionRepository,
+		WaitlistService:       waitlistService,
 	}
 }
 
@@ -36,11 +51,581 @@ func (c *CompetitionService) GetAllCompetitions() ([]*model.GetAllCompetitionsRe
 	var response []*model.GetAllCompetitionsResponse
 	for _, v := range competitions {
 		response = append(response, &model.GetAllCompetitionsResponse{
-			CompetitionID:   v.CompetitionID,
-			CompetitionName: v.CompetitionName,
-			Description:     v.Description,
+			CompetitionID:     v.CompetitionID,
+			CompetitionName:   v.CompetitionName,
+			Description:       v.Description,
+			RegistrationFee:   v.RegistrationFee,
+			CurrentFee:        currentFee(*v, time.Now()),
+			RegistrationOpen:  v.RegistrationOpen,
+			RegistrationClose: v.RegistrationClose,
+			MaxTeams:          v.MaxTeams,
 		})
 	}
 
 	return response, nil
 }
+
+func (c *CompetitionService) GetAllCompetitionsAdmin() ([]*model.CompetitionDetailResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	competitions, err := c.CompetitionRepository.GetAllCompetitionsWithStages(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []*model.CompetitionDetailResponse
+	for _, v := range competitions {
+		response = append(response, toCompetitionDetail(v, v.Stages))
+	}
+
+	return response, nil
+}
+
+func (c *CompetitionService) CreateCompetition(param model.CreateCompetitionRequest) (*model.CompetitionDetailResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	var stages []entity.Stages
+	for _, v := range param.Stages {
+		stages = append(stages, entity.Stages{
+			StageName:        v.StageName,
+			StageOrder:       v.StageOrder,
+			Deadline:         v.Deadline,
+			AllowedMimeTypes: joinMimeTypes(v.AllowedMimeTypes),
+			MaxFileSize:      stageFileSize(v.MaxFileSize),
+		})
+	}
+
+	err := validateStages(stages)
+	if err != nil {
+		return nil, err
+	}
+
+	competition := &entity.Competition{
+		CompetitionName:   param.CompetitionName,
+		Description:       param.Description,
+		RegistrationFee:   param.RegistrationFee,
+		EarlyBirdFee:      param.EarlyBirdFee,
+		EarlyBirdStart:    param.EarlyBirdStart,
+		EarlyBirdEnd:      param.EarlyBirdEnd,
+		Deadline:          param.Deadline,
+		RegistrationOpen:  param.RegistrationOpen,
+		RegistrationClose: param.RegistrationClose,
+		MaxTeams:          param.MaxTeams,
+	}
+
+	err = validateEarlyBird(competition)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateRegistrationWindow(competition)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.CompetitionRepository.CreateCompetition(tx, competition)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stages {
+		stages[i].CompetitionID = competition.CompetitionID
+		err = c.CompetitionRepository.CreateStage(tx, &stages[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rule := defaultCompetitionRule(competition.CompetitionID)
+	if param.Rule != nil {
+		rule = toCompetitionRule(competition.CompetitionID, *param.Rule)
+	}
+
+	err = c.CompetitionRepository.SaveCompetitionRule(tx, &rule)
+	if err != nil {
+		return nil, err
+	}
+	competition.Rule = &rule
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toCompetitionDetail(competition, stages), nil
+}
+
+func (c *CompetitionService) UpdateCompetition(competitionID int, param model.UpdateCompetitionRequest) (*model.CompetitionDetailResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	competition, err := c.getCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if param.CompetitionName != "" {
+		competition.CompetitionName = param.CompetitionName
+	}
+	if param.Description != "" {
+		competition.Description = param.Description
+	}
+	if param.RegistrationFee != nil {
+		competition.RegistrationFee = *param.RegistrationFee
+	}
+	if param.EarlyBirdFee != nil {
+		competition.EarlyBirdFee = *param.EarlyBirdFee
+	}
+	if param.EarlyBirdStart != nil {
+		competition.EarlyBirdStart = param.EarlyBirdStart
+	}
+	if param.EarlyBirdEnd != nil {
+		competition.EarlyBirdEnd = param.EarlyBirdEnd
+	}
+	if param.Deadline != nil {
+		competition.Deadline = *param.Deadline
+	}
+	if param.RegistrationOpen != nil {
+		competition.RegistrationOpen = param.RegistrationOpen
+	}
+	if param.RegistrationClose != nil {
+		competition.RegistrationClose = param.RegistrationClose
+	}
+	if param.MaxTeams != nil {
+		competition.MaxTeams = *param.MaxTeams
+	}
+
+	err = validateEarlyBird(competition)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateRegistrationWindow(competition)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.CompetitionRepository.UpdateCompetition(tx, competition)
+	if err != nil {
+		return nil, err
+	}
+
+	// kuota yang ditambah langsung diisi oleh tim di daftar tunggu
+	err = c.WaitlistService.PromoteWaitlist(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := c.CompetitionRepository.GetStagesByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toCompetitionDetail(competition, stages), nil
+}
+
+func (c *CompetitionService) ArchiveCompetition(competitionID int, param model.ArchiveCompetitionRequest) error {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	competition, err := c.getCompetition(tx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	competition.IsArchived = param.IsArchived
+
+	err = c.CompetitionRepository.UpdateCompetition(tx, competition)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (c *CompetitionService) AddStage(competitionID int, param model.StageRequest) (*model.CompetitionDetailResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	competition, err := c.getEditableCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := c.CompetitionRepository.GetStagesByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := entity.Stages{
+		StageName:        param.StageName,
+		CompetitionID:    competitionID,
+		StageOrder:       param.StageOrder,
+		Deadline:         param.Deadline,
+		AllowedMimeTypes: joinMimeTypes(param.AllowedMimeTypes),
+		MaxFileSize:      stageFileSize(param.MaxFileSize),
+	}
+
+	err = validateStages(append(stages, stage))
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.CompetitionRepository.CreateStage(tx, &stage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toCompetitionDetail(competition, append(stages, stage)), nil
+}
+
+func (c *CompetitionService) UpdateStage(competitionID int, stageID int, param model.UpdateStageRequest) (*model.CompetitionDetailResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	competition, err := c.getEditableCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := c.CompetitionRepository.GetStagesByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, v := range stages {
+		if v.StageID == stageID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, model.ErrStageNotFound
+	}
+
+	if param.StageName != "" {
+		stages[index].StageName = param.StageName
+	}
+	if param.Deadline != nil {
+		stages[index].Deadline = *param.Deadline
+	}
+	if param.AllowedMimeTypes != nil {
+		stages[index].AllowedMimeTypes = joinMimeTypes(param.AllowedMimeTypes)
+	}
+	if param.MaxFileSize != 0 {
+		stages[index].MaxFileSize = param.MaxFileSize
+	}
+
+	err = validateStages(stages)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.CompetitionRepository.UpdateStage(tx, &stages[index])
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toCompetitionDetail(competition, stages), nil
+}
+
+func (c *CompetitionService) ReorderStages(competitionID int, param model.ReorderStagesRequest) (*model.CompetitionDetailResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	competition, err := c.getEditableCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := c.CompetitionRepository.GetStagesByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(param.StageIDs) != len(stages) {
+		return nil, model.ErrStageReorderMismatch
+	}
+
+	stageMap := make(map[int]entity.Stages)
+	for _, v := range stages {
+		stageMap[v.StageID] = v
+	}
+
+	var reordered []entity.Stages
+	for i, id := range param.StageIDs {
+		stage, ok := stageMap[id]
+		if !ok {
+			return nil, model.ErrStageReorderMismatch
+		}
+		delete(stageMap, id)
+
+		stage.StageOrder = i + 1
+		if deadline, ok := param.Deadlines[id]; ok {
+			stage.Deadline = deadline
+		}
+		reordered = append(reordered, stage)
+	}
+
+	err = validateStages(reordered)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reordered {
+		err = c.CompetitionRepository.UpdateStage(tx, &reordered[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toCompetitionDetail(competition, reordered), nil
+}
+
+func (c *CompetitionService) UpdateCompetitionRule(competitionID int, param model.CompetitionRuleRequest) (*model.CompetitionRuleResponse, error) {
+	tx := c.db.Begin()
+	defer tx.Rollback()
+
+	_, err := c.getCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := toCompetitionRule(competitionID, param)
+	err = c.CompetitionRepository.SaveCompetitionRule(tx, &rule)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := toCompetitionRuleResponse(rule)
+	return &response, nil
+}
+
+func (c *CompetitionService) getCompetition(tx *gorm.DB, competitionID int) (*entity.Competition, error) {
+	competition, err := c.CompetitionRepository.GetCompetitionByID(tx, competitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+
+	return competition, nil
+}
+
+func (c *CompetitionService) getEditableCompetition(tx *gorm.DB, competitionID int) (*entity.Competition, error) {
+	competition, err := c.getCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if competition.IsArchived {
+		return nil, model.ErrCompetitionArchived
+	}
+
+	return competition, nil
+}
+
+// validateStages memastikan stage_order unik dan deadline naik sesuai urutan stage.
+func validateStages(stages []entity.Stages) error {
+	sorted := make([]entity.Stages, len(stages))
+	copy(sorted, stages)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StageOrder < sorted[j].StageOrder
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].StageOrder == sorted[i-1].StageOrder {
+			return model.ErrDuplicateStageOrder
+		}
+		if !sorted[i].Deadline.After(sorted[i-1].Deadline) {
+			return model.ErrStageDeadlineOrder
+		}
+	}
+
+	return nil
+}
+
+// validateEarlyBird memastikan jendela early bird valid jika diatur.
+func validateEarlyBird(competition *entity.Competition) error {
+	if competition.EarlyBirdStart == nil && competition.EarlyBirdEnd == nil {
+		return nil
+	}
+	if competition.EarlyBirdStart == nil || competition.EarlyBirdEnd == nil || !competition.EarlyBirdStart.Before(*competition.EarlyBirdEnd) {
+		return model.ErrEarlyBirdWindow
+	}
+	if competition.EarlyBirdFee > competition.RegistrationFee {
+		return model.ErrEarlyBirdWindow
+	}
+
+	return nil
+}
+
+// currentFee menghitung biaya registrasi yang berlaku pada waktu now.
+func currentFee(competition entity.Competition, now time.Time) int64 {
+	if competition.EarlyBirdStart != nil && competition.EarlyBirdEnd != nil &&
+		!now.Before(*competition.EarlyBirdStart) && now.Before(*competition.EarlyBirdEnd) {
+		return competition.EarlyBirdFee
+	}
+
+	return competition.RegistrationFee
+}
+
+func toCompetitionDetail(competition *entity.Competition, stages []entity.Stages) *model.CompetitionDetailResponse {
+	sorted := make([]entity.Stages, len(stages))
+	copy(sorted, stages)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StageOrder < sorted[j].StageOrder
+	})
+
+	stageResponse := []model.StageResponse{}
+	for _, v := range sorted {
+		stageResponse = append(stageResponse, model.StageResponse{
+			StageID:          v.StageID,
+			StageName:        v.StageName,
+			StageOrder:       v.StageOrder,
+			Deadline:         v.Deadline,
+			AllowedMimeTypes: stageMimeTypes(v),
+			MaxFileSize:      stageFileSize(v.MaxFileSize),
+		})
+	}
+
+	rule := defaultCompetitionRule(competition.CompetitionID)
+	if competition.Rule != nil {
+		rule = *competition.Rule
+	}
+
+	return &model.CompetitionDetailResponse{
+		CompetitionID:     competition.CompetitionID,
+		CompetitionName:   competition.CompetitionName,
+		Description:       competition.Description,
+		RegistrationFee:   competition.RegistrationFee,
+		EarlyBirdFee:      competition.EarlyBirdFee,
+		EarlyBirdStart:    competition.EarlyBirdStart,
+		EarlyBirdEnd:      competition.EarlyBirdEnd,
+		Deadline:          competition.Deadline,
+		IsArchived:        competition.IsArchived,
+		RegistrationOpen:  competition.RegistrationOpen,
+		RegistrationClose: competition.RegistrationClose,
+		MaxTeams:          competition.MaxTeams,
+		Stages:            stageResponse,
+		Rule:              toCompetitionRuleResponse(rule),
+	}
+}
+
+// getCompetitionRule mengambil aturan kompetisi, atau aturan default jika belum diatur admin.
+func getCompetitionRule(tx *gorm.DB, competitionRepository repository.ICompetitionRepository, competitionID int) (entity.CompetitionRule, error) {
+	rule, err := competitionRepository.GetCompetitionRule(tx, competitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultCompetitionRule(competitionID), nil
+		}
+		return entity.CompetitionRule{}, err
+	}
+
+	return *rule, nil
+}
+
+func defaultCompetitionRule(competitionID int) entity.CompetitionRule {
+	return entity.CompetitionRule{
+		CompetitionID:     competitionID,
+		PaymentRequired:   true,
+		PaymentStageOrder: 1,
+		MaxTeamMembers:    2,
+		FreeFirstStage:    false,
+	}
+}
+
+// paymentBlocksStage menentukan apakah submission pada stage tersebut membutuhkan pembayaran terverifikasi.
+func paymentBlocksStage(rule entity.CompetitionRule, stageOrder int) bool {
+	if !rule.PaymentRequired {
+		return false
+	}
+	if rule.FreeFirstStage && stageOrder == 1 {
+		return false
+	}
+
+	return stageOrder >= rule.PaymentStageOrder
+}
+
+func toCompetitionRule(competitionID int, param model.CompetitionRuleRequest) entity.CompetitionRule {
+	paymentStageOrder := param.PaymentStageOrder
+	if paymentStageOrder == 0 {
+		paymentStageOrder = 1
+	}
+
+	return entity.CompetitionRule{
+		CompetitionID:     competitionID,
+		PaymentRequired:   param.PaymentRequired,
+		PaymentStageOrder: paymentStageOrder,
+		MaxTeamMembers:    *param.MaxTeamMembers,
+		FreeFirstStage:    param.FreeFirstStage,
+	}
+}
+
+func toCompetitionRuleResponse(rule entity.CompetitionRule) model.CompetitionRuleResponse {
+	return model.CompetitionRuleResponse{
+		PaymentRequired:   rule.PaymentRequired,
+		PaymentStageOrder: rule.PaymentStageOrder,
+		MaxTeamMembers:    rule.MaxTeamMembers,
+		FreeFirstStage:    rule.FreeFirstStage,
+	}
+}
+
+// stageMimeTypes mengembalikan tipe file yang boleh diunggah pada stage, atau tipe default jika belum diatur.
+func stageMimeTypes(stage entity.Stages) []string {
+	mimeTypes := stage.AllowedMimeTypes
+	if mimeTypes == "" {
+		mimeTypes = entity.DefaultSubmissionMimeTypes
+	}
+
+	return strings.Split(mimeTypes, ",")
+}
+
+func joinMimeTypes(mimeTypes []string) string {
+	var cleaned []string
+	for _, v := range mimeTypes {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v != "" && !contains(cleaned, v) {
+			cleaned = append(cleaned, v)
+		}
+	}
+
+	return strings.Join(cleaned, ",")
+}
+
+func stageFileSize(size int64) int64 {
+	if size <= 0 {
+		return entity.DefaultSubmissionFileSize
+	}
+
+	return size
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"itfest-2025/internal/repository"
+	"itfest-2025/model"
 	"itfest-2025/pkg/database/mariadb"
 
 	"gorm.io/gorm"
@@ -12,23 +13,33 @@ type ICountService interface {
 }
 
 type CountService struct {
-	db             *gorm.DB
-	TeamRepository repository.ITeamRepository
-	UserRepository repository.IUserRepository
+	db                    *gorm.DB
+	TeamRepository        repository.ITeamRepository
+	UserRepository        repository.IUserRepository
+	CompetitionRepository repository.ICompetitionRepository
 }
 
+// legacyUIUXCompetitionID dan legacyBusinessCompetitionID hanya dipakai untuk mengisi kolom total lama
+// yang masih dibaca klien, perhitungan per lomba ada di Competitions.
+const (
+	legacyUIUXCompetitionID     = 2
+	legacyBusinessCompetitionID = 3
+)
+
 type responCount struct {
 	TotalTeam     int64
 	TotalPayment  int64
 	TotalBusiness int64
 	TotalUIUX     int64
+	Competitions  []model.CompetitionCount
 }
 
-func NewCountService(TeamRepository repository.ITeamRepository, UserRepository repository.IUserRepository) *CountService {
+func NewCountService(TeamRepository repository.ITeamRepository, UserRepository repository.IUserRepository, CompetitionRepository repository.ICompetitionRepository) *CountService {
 	return &CountService{
-		db:             mariadb.Connection,
-		TeamRepository: TeamRepository,
-		UserRepository: UserRepository,
+		db:                    mariadb.Connection,
+		TeamRepository:        TeamRepository,
+		UserRepository:        UserRepository,
+		CompetitionRepository: CompetitionRepository,
 	}
 }
 
@@ -36,28 +47,44 @@ func (c *CountService) GetAllCount() (responCount, error) {
 	tx := c.db.Begin()
 	defer tx.Rollback()
 
-	totalTeam, err := c.TeamRepository.GetCount(tx, "")
+	totalTeam, err := c.TeamRepository.GetCount(tx, 0)
 	if err != nil {
 		return responCount{}, err
 	}
-	countBusiness, err := c.TeamRepository.GetCount(tx, "3")
+
+	competitions, err := c.CompetitionRepository.GetAllCompetitions(tx)
 	if err != nil {
 		return responCount{}, err
 	}
-	countUIUX, err := c.TeamRepository.GetCount(tx, "2")
-	if err != nil {
-		return responCount{}, err
+
+	res := responCount{
+		TotalTeam:    totalTeam,
+		Competitions: []model.CompetitionCount{},
 	}
-	
-	countPayment, err := c.UserRepository.GetCountPayment()
+	for _, v := range competitions {
+		count, err := c.TeamRepository.GetCount(tx, v.CompetitionID)
+		if err != nil {
+			return responCount{}, err
+		}
+
+		switch v.CompetitionID {
+		case legacyUIUXCompetitionID:
+			res.TotalUIUX = count
+		case legacyBusinessCompetitionID:
+			res.TotalBusiness = count
+		}
+
+		res.Competitions = append(res.Competitions, model.CompetitionCount{
+			CompetitionID:   v.CompetitionID,
+			CompetitionName: v.CompetitionName,
+			Total:           count,
+		})
+	}
+
+	res.TotalPayment, err = c.UserRepository.GetCountPayment()
 	if err != nil {
 		return responCount{}, err
 	}
 
-	return responCount{
-		TotalTeam:     totalTeam,
-		TotalPayment:  countPayment,
-		TotalBusiness: countBusiness,
-		TotalUIUX:     countUIUX,
-	}, nil
+	return res, nil
 }
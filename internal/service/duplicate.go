@@ -0,0 +1,224 @@
+package service
+
+import (
+	"fmt"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// nameSimilarityThreshold adalah kemiripan nama minimal agar dua peserta dari universitas yang sama dicurigai duplikat.
+const nameSimilarityThreshold = 0.85
+
+type IDuplicateService interface {
+	GetDuplicateReport(competitionID int) (*model.DuplicateReportResponse, error)
+}
+
+type DuplicateService struct {
+	db             *gorm.DB
+	TeamRepository repository.ITeamRepository
+}
+
+func NewDuplicateService(teamRepository repository.ITeamRepository) IDuplicateService {
+	return &DuplicateService{
+		db:             mariadb.Connection,
+		TeamRepository: teamRepository,
+	}
+}
+
+func (d *DuplicateService) GetDuplicateReport(competitionID int) (*model.DuplicateReportResponse, error) {
+	participants, err := d.TeamRepository.GetParticipants(d.db, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	byCompetition := make(map[int][]model.Participant)
+	var competitionIDs []int
+	for _, v := range participants {
+		if _, ok := byCompetition[v.CompetitionID]; !ok {
+			competitionIDs = append(competitionIDs, v.CompetitionID)
+		}
+		byCompetition[v.CompetitionID] = append(byCompetition[v.CompetitionID], v)
+	}
+	sort.Ints(competitionIDs)
+
+	res := &model.DuplicateReportResponse{
+		Groups: []model.DuplicateGroup{},
+	}
+	for _, id := range competitionIDs {
+		res.Groups = append(res.Groups, studentNumberDuplicates(id, byCompetition[id])...)
+		res.Groups = append(res.Groups, suspectedDuplicates(id, byCompetition[id])...)
+	}
+	res.Total = len(res.Groups)
+
+	return res, nil
+}
+
+// studentNumberDuplicates mengelompokkan peserta yang NIM-nya sama setelah dinormalisasi.
+func studentNumberDuplicates(competitionID int, participants []model.Participant) []model.DuplicateGroup {
+	byNumber := make(map[string][]model.Participant)
+	var numbers []string
+	for _, v := range participants {
+		number := normalizeStudentNumber(v.StudentNumber)
+		if number == "" {
+			continue
+		}
+		if _, ok := byNumber[number]; !ok {
+			numbers = append(numbers, number)
+		}
+		byNumber[number] = append(byNumber[number], v)
+	}
+
+	var groups []model.DuplicateGroup
+	for _, number := range numbers {
+		if len(byNumber[number]) < 2 {
+			continue
+		}
+
+		groups = append(groups, model.DuplicateGroup{
+			CompetitionID: competitionID,
+			Reason:        "student_number",
+			Similarity:    1,
+			Participants:  byNumber[number],
+		})
+	}
+
+	return groups
+}
+
+// suspectedDuplicates mencari pasangan peserta dari tim berbeda dengan universitas sama dan nama yang mirip
+// tetapi NIM berbeda, misalnya karena salah ketik NIM saat mendaftar di dua tim.
+func suspectedDuplicates(competitionID int, participants []model.Participant) []model.DuplicateGroup {
+	var groups []model.DuplicateGroup
+	for i := 0; i < len(participants); i++ {
+		for j := i + 1; j < len(participants); j++ {
+			a, b := participants[i], participants[j]
+			if a.TeamID == b.TeamID {
+				continue
+			}
+
+			numberA, numberB := normalizeStudentNumber(a.StudentNumber), normalizeStudentNumber(b.StudentNumber)
+			if numberA != "" && numberA == numberB {
+				continue
+			}
+
+			universityA, universityB := normalizeName(a.University), normalizeName(b.University)
+			if universityA == "" || universityA != universityB {
+				continue
+			}
+
+			similarity := nameSimilarity(normalizeName(a.Name), normalizeName(b.Name))
+			if similarity < nameSimilarityThreshold {
+				continue
+			}
+
+			groups = append(groups, model.DuplicateGroup{
+				CompetitionID: competitionID,
+				Reason:        "name_university",
+				Similarity:    similarity,
+				Participants:  []model.Participant{a, b},
+			})
+		}
+	}
+
+	return groups
+}
+
+// checkDuplicateParticipants memastikan NIM peserta tim tidak dobel di dalam tim maupun dengan tim lain pada lomba yang sama.
+func checkDuplicateParticipants(tx *gorm.DB, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository, teamID uuid.UUID, competitionID int, studentNumbers []string) error {
+	seen := make(map[string]bool)
+	for _, v := range studentNumbers {
+		number := normalizeStudentNumber(v)
+		if number == "" {
+			continue
+		}
+		if seen[number] {
+			return fmt.Errorf("%w: %s is listed more than once in the team", model.ErrDuplicateStudentNumber, v)
+		}
+		seen[number] = true
+	}
+
+	if len(seen) == 0 || competitionID == 0 {
+		return nil
+	}
+
+	// baris lomba dikunci sampai transaksi selesai agar dua pendaftaran bersamaan tidak sama-sama lolos pengecekan
+	_, err := competitionRepository.LockCompetition(tx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	participants, err := teamRepository.GetParticipants(tx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range participants {
+		if v.TeamID == teamID {
+			continue
+		}
+
+		if seen[normalizeStudentNumber(v.StudentNumber)] {
+			return fmt.Errorf("%w: %s is already registered by team %s", model.ErrDuplicateStudentNumber, v.StudentNumber, v.TeamName)
+		}
+	}
+
+	return nil
+}
+
+// normalizeStudentNumber menghapus spasi dan angka nol di depan agar "0012 345" dan "12345" dianggap sama.
+func normalizeStudentNumber(number string) string {
+	number = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, number)
+
+	return strings.TrimLeft(number, "0")
+}
+
+func normalizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// nameSimilarity menghitung kemiripan dua nama dari jarak Levenshtein, bernilai 0 sampai 1.
+func nameSimilarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(rb)])/float64(max(len(ra), len(rb)))
+}
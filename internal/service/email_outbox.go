@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/mail"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IEmailOutboxService interface {
+	StartWorker(ctx context.Context, wg *sync.WaitGroup)
+	ProcessDueEmails() (int, error)
+	GetEmails(status string) ([]model.OutboxEmailResponse, error)
+	RequeueEmail(emailID int) (*model.OutboxEmailResponse, error)
+	RequeueDeadEmails() (*model.RequeueEmailsResponse, error)
+}
+
+type EmailOutboxService struct {
+	db               *gorm.DB
+	OutboxRepository repository.IEmailOutboxRepository
+	Mailer           mail.Interface
+}
+
+const (
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = time.Hour
+	// outboxStaleAfter adalah batas email dianggap tertinggal di status sending karena worker berhenti.
+	outboxStaleAfter = 10 * time.Minute
+)
+
+func NewEmailOutboxService(outboxRepository repository.IEmailOutboxRepository, mailer mail.Interface) IEmailOutboxService {
+	return &EmailOutboxService{
+		db:               mariadb.Connection,
+		OutboxRepository: outboxRepository,
+		Mailer:           mailer,
+	}
+}
+
+// StartWorker menjalankan sejumlah worker pengirim email di background sampai ctx dibatalkan,
+// wg selesai setelah semua worker menuntaskan batch yang sedang dikirim.
+func (e *EmailOutboxService) StartWorker(ctx context.Context, wg *sync.WaitGroup) {
+	workers := envInt("OUTBOX_WORKERS", 2)
+	interval := time.Duration(envInt("OUTBOX_POLL_INTERVAL", 5)) * time.Second
+
+	wg.Add(workers + 1)
+	go func() {
+		defer wg.Done()
+		e.purgeLoop(ctx)
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				processed, err := e.ProcessDueEmails()
+				if err != nil {
+					log.Printf("failed to process email outbox: %v", err)
+				}
+
+				// selama masih ada email yang diproses, batch berikutnya langsung diambil tanpa menunggu
+				if err == nil && processed > 0 {
+					select {
+					case <-ctx.Done():
+						return
+					default:
+						continue
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+}
+
+// purgeLoop mengosongkan isi email terkirim yang sudah melewati OUTBOX_RETENTION_DAYS setiap jam.
+func (e *EmailOutboxService) purgeLoop(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		retention := time.Duration(envInt("OUTBOX_RETENTION_DAYS", 7)) * 24 * time.Hour
+		_, err := e.OutboxRepository.PurgeSentEmails(e.db, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("failed to purge sent emails: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDueEmails mengirim satu batch email yang sudah jatuh tempo dan mengembalikan jumlah email yang diproses.
+func (e *EmailOutboxService) ProcessDueEmails() (int, error) {
+	now := time.Now()
+
+	tx := e.db.Begin()
+	defer tx.Rollback()
+
+	emails, err := e.OutboxRepository.ClaimDueEmails(tx, now, now.Add(-outboxStaleAfter), envInt("OUTBOX_BATCH_SIZE", 20))
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range emails {
+		e.deliver(&emails[i])
+	}
+
+	return len(emails), nil
+}
+
+func (e *EmailOutboxService) GetEmails(status string) ([]model.OutboxEmailResponse, error) {
+	if status == "" {
+		status = entity.EmailDead
+	}
+
+	if !contains([]string{entity.EmailPending, entity.EmailSending, entity.EmailSent, entity.EmailDead}, status) {
+		return nil, model.ErrInvalidEmailStatus
+	}
+
+	emails, err := e.OutboxRepository.GetEmails(e.db, status)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []model.OutboxEmailResponse{}
+	for _, v := range emails {
+		response = append(response, toOutboxEmailResponse(v))
+	}
+
+	return response, nil
+}
+
+// RequeueEmail mengantrekan ulang email yang gagal, jumlah percobaan direset agar backoff dimulai dari awal.
+func (e *EmailOutboxService) RequeueEmail(emailID int) (*model.OutboxEmailResponse, error) {
+	tx := e.db.Begin()
+	defer tx.Rollback()
+
+	email, err := e.OutboxRepository.GetEmailByID(tx, emailID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrEmailNotFound
+		}
+		return nil, err
+	}
+
+	if email.Status == entity.EmailSent || email.Status == entity.EmailSending {
+		return nil, model.ErrEmailNotRequeueable
+	}
+
+	email.Status = entity.EmailPending
+	email.Attempts = 0
+	email.NextAttemptAt = time.Now()
+
+	err = e.OutboxRepository.UpdateEmail(tx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := toOutboxEmailResponse(*email)
+	return &response, nil
+}
+
+func (e *EmailOutboxService) RequeueDeadEmails() (*model.RequeueEmailsResponse, error) {
+	tx := e.db.Begin()
+	defer tx.Rollback()
+
+	requeued, err := e.OutboxRepository.RequeueDeadEmails(tx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RequeueEmailsResponse{
+		Requeued: requeued,
+	}, nil
+}
+
+func (e *EmailOutboxService) deliver(email *entity.EmailOutbox) {
+	message := mail.Message{
+		To:      email.Recipient,
+		Subject: email.Subject,
+		Body:    email.HTMLBody,
+		Text:    email.TextBody,
+	}
+
+	var err error
+	if len(email.Attachments) > 0 {
+		err = json.Unmarshal(email.Attachments, &message.Attachments)
+	}
+	if err == nil {
+		err = e.Mailer.Send(message)
+	}
+
+	email.Attempts++
+	if err == nil {
+		now := time.Now()
+		email.Status = entity.EmailSent
+		email.SentAt = &now
+		email.LastError = ""
+	} else if email.Attempts >= envInt("OUTBOX_MAX_ATTEMPTS", 6) {
+		email.Status = entity.EmailDead
+		email.LastError = err.Error()
+		log.Printf("failed to send email %d to %s, moved to dead letter: %v", email.EmailID, email.Recipient, err)
+	} else {
+		email.Status = entity.EmailPending
+		email.LastError = err.Error()
+		email.NextAttemptAt = time.Now().Add(outboxBackoff(email.Attempts))
+	}
+
+	err = e.OutboxRepository.UpdateEmail(e.db, email)
+	if err != nil {
+		log.Printf("failed to update email %d status: %v", email.EmailID, err)
+	}
+}
+
+// outboxBackoff menggandakan jeda setiap kali pengiriman gagal, dibatasi outboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+
+	return delay
+}
+
+// queueEmail merender template lalu menyimpannya ke outbox di transaksi yang sama dengan perubahan datanya,
+// sehingga email hanya terkirim jika transaksi berhasil dan kegagalan SMTP tidak membatalkan transaksi.
+func queueEmail(tx *gorm.DB, outboxRepository repository.IEmailOutboxRepository, to, name string, data any, attachments ...mail.Attachment) error {
+	message, err := mail.NewMessage(to, name, data, attachments...)
+	if err != nil {
+		return err
+	}
+
+	email, err := newOutboxEmail(name, message)
+	if err != nil {
+		return err
+	}
+
+	return outboxRepository.CreateEmails(tx, []entity.EmailOutbox{*email})
+}
+
+func newOutboxEmail(name string, message mail.Message) (*entity.EmailOutbox, error) {
+	var attachments []byte
+	if len(message.Attachments) > 0 {
+		var err error
+		attachments, err = json.Marshal(message.Attachments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &entity.EmailOutbox{
+		Recipient:     message.To,
+		Subject:       message.Subject,
+		Template:      name,
+		HTMLBody:      message.Body,
+		TextBody:      message.Text,
+		Attachments:   attachments,
+		Status:        entity.EmailPending,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+func toOutboxEmailResponse(email entity.EmailOutbox) model.OutboxEmailResponse {
+	return model.OutboxEmailResponse{
+		EmailID:       email.EmailID,
+		Recipient:     email.Recipient,
+		Subject:       email.Subject,
+		Template:      email.Template,
+		Status:        email.Status,
+		Attempts:      email.Attempts,
+		LastError:     email.LastError,
+		NextAttemptAt: email.NextAttemptAt,
+		SentAt:        email.SentAt,
+		CreatedAt:     email.CreatedAt,
+		UpdatedAt:     email.UpdatedAt,
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/pkg/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryOutboxRepository menyimpan outbox di memori agar service bisa dites tanpa database.
+type memoryOutboxRepository struct {
+	emails []entity.EmailOutbox
+}
+
+func (m *memoryOutboxRepository) CreateEmails(tx *gorm.DB, emails []entity.EmailOutbox) error {
+	for _, v := range emails {
+		v.EmailID = len(m.emails) + 1
+		m.emails = append(m.emails, v)
+	}
+
+	return nil
+}
+
+func (m *memoryOutboxRepository) ClaimDueEmails(tx *gorm.DB, now time.Time, staleBefore time.Time, limit int) ([]entity.EmailOutbox, error) {
+	var emails []entity.EmailOutbox
+	for _, v := range m.emails {
+		if v.Status == entity.EmailPending && !v.NextAttemptAt.After(now) && len(emails) < limit {
+			emails = append(emails, v)
+		}
+	}
+
+	return emails, nil
+}
+
+func (m *memoryOutboxRepository) UpdateEmail(tx *gorm.DB, email *entity.EmailOutbox) error {
+	for i := range m.emails {
+		if m.emails[i].EmailID == email.EmailID {
+			m.emails[i] = *email
+			return nil
+		}
+	}
+
+	return gorm.ErrRecordNotFound
+}
+
+func (m *memoryOutboxRepository) GetEmails(tx *gorm.DB, status string) ([]entity.EmailOutbox, error) {
+	return m.emails, nil
+}
+
+func (m *memoryOutboxRepository) GetEmailByID(tx *gorm.DB, emailID int) (*entity.EmailOutbox, error) {
+	for i := range m.emails {
+		if m.emails[i].EmailID == emailID {
+			return &m.emails[i], nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *memoryOutboxRepository) RequeueDeadEmails(tx *gorm.DB, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *memoryOutboxRepository) PurgeSentEmails(tx *gorm.DB, sentBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+type failingMailer struct{}
+
+func (f failingMailer) Send(message mail.Message) error {
+	return errors.New("smtp unavailable")
+}
+
+func TestOutboxDeliversQueuedOtpEmail(t *testing.T) {
+	repo := &memoryOutboxRepository{}
+	mailer := mail.NewMemory()
+	outbox := &EmailOutboxService{
+		OutboxRepository: repo,
+		Mailer:           mailer,
+	}
+
+	err := queueEmail(nil, repo, "peserta@itfest.id", mail.TemplateOtpVerification, mail.OtpData{
+		Code: "123456",
+	})
+	if err != nil {
+		t.Fatalf("queue email: %v", err)
+	}
+
+	outbox.deliver(&repo.emails[0])
+
+	sent := mailer.SentTo("peserta@itfest.id")
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(sent))
+	}
+	if !strings.Contains(sent[0].Body, "123456") {
+		t.Errorf("expected otp code in email body")
+	}
+	if repo.emails[0].Status != entity.EmailSent || repo.emails[0].SentAt == nil {
+		t.Errorf("expected email to be marked as sent, got %s", repo.emails[0].Status)
+	}
+}
+
+func TestOutboxRetriesFailedEmail(t *testing.T) {
+	repo := &memoryOutboxRepository{}
+	outbox := &EmailOutboxService{
+		OutboxRepository: repo,
+		Mailer:           failingMailer{},
+	}
+
+	err := queueEmail(nil, repo, "peserta@itfest.id", mail.TemplateOtpVerification, mail.OtpData{
+		Code: "123456",
+	})
+	if err != nil {
+		t.Fatalf("queue email: %v", err)
+	}
+
+	before := time.Now()
+	outbox.deliver(&repo.emails[0])
+
+	email := repo.emails[0]
+	if email.Status != entity.EmailPending || email.Attempts != 1 {
+		t.Fatalf("expected pending email with 1 attempt, got %s with %d attempts", email.Status, email.Attempts)
+	}
+	if email.NextAttemptAt.Before(before.Add(outboxBaseBackoff)) {
+		t.Errorf("expected next attempt to be delayed by backoff")
+	}
+}
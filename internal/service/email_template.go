@@ -0,0 +1,49 @@
+package service
+
+import (
+	"itfest-2025/model"
+	"itfest-2025/pkg/mail"
+)
+
+type IEmailTemplateService interface {
+	GetEmailTemplates() ([]model.EmailTemplateResponse, error)
+	PreviewEmailTemplate(name string) (*model.EmailPreviewResponse, error)
+}
+
+type EmailTemplateService struct{}
+
+func NewEmailTemplateService() IEmailTemplateService {
+	return &EmailTemplateService{}
+}
+
+func (e *EmailTemplateService) GetEmailTemplates() ([]model.EmailTemplateResponse, error) {
+	response := []model.EmailTemplateResponse{}
+	for _, name := range mail.Templates() {
+		rendered, err := mail.RenderSample(name)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, model.EmailTemplateResponse{
+			Name:    name,
+			Subject: rendered.Subject,
+		})
+	}
+
+	return response, nil
+}
+
+// PreviewEmailTemplate merender template email dengan contoh data agar admin bisa melihat hasil akhirnya.
+func (e *EmailTemplateService) PreviewEmailTemplate(name string) (*model.EmailPreviewResponse, error) {
+	rendered, err := mail.RenderSample(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.EmailPreviewResponse{
+		Name:    name,
+		Subject: rendered.Subject,
+		HTML:    rendered.HTML,
+		Text:    rendered.Text,
+	}, nil
+}
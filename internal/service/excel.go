@@ -1,11 +1,17 @@
 package service
 
 import (
+	"fmt"
+	"itfest-2025/entity"
 	"itfest-2025/internal/repository"
 	"itfest-2025/model"
 	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
 	"itfest-2025/pkg/template"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/xuri/excelize/v2"
 	"gorm.io/gorm"
 )
@@ -21,14 +27,21 @@ type ExcelService struct {
 	UserRepository        repository.IUserRepository
 	TeamRepository        repository.ITeamRepository
 	CompetitionRepository repository.ICompetitionRepository
+	FormRepository        repository.IRegistrationFormRepository
+	Storage               storage.Interface
 }
 
-func NewExcelService(teamRepo repository.ITeamRepository, compRepo repository.ICompetitionRepository, userRepo repository.IUserRepository) IExcelService {
+// exportURLExpiry dibuat panjang karena file excel biasanya dibuka jauh setelah diunduh.
+const exportURLExpiry = 7 * 24 * time.Hour
+
+func NewExcelService(teamRepo repository.ITeamRepository, compRepo repository.ICompetitionRepository, userRepo repository.IUserRepository, formRepo repository.IRegistrationFormRepository, storage storage.Interface) IExcelService {
 	return &ExcelService{
 		db:                    mariadb.Connection,
 		TeamRepository:        teamRepo,
 		CompetitionRepository: compRepo,
 		UserRepository:        userRepo,
+		FormRepository:        formRepo,
+		Storage:               storage,
 	}
 }
 
@@ -119,7 +132,16 @@ func (s *ExcelService) ExportExcelPayment() (string, error) {
 	userColorToggle := 0
 
 	for _, dt := range data {
-		sheet.Rows = append(sheet.Rows, []interface{}{no, dt.FullName, dt.Email, dt.StudentNumber, dt.Team.TeamName, dt.RegistrationLink, dt.PaymentTransc})
+		if !isTeamActive(&dt.Team) {
+			continue
+		}
+
+		paymentURL, err := storage.URL(s.Storage, dt.PaymentTransc, exportURLExpiry)
+		if err != nil {
+			return "", err
+		}
+
+		sheet.Rows = append(sheet.Rows, []interface{}{no, dt.FullName, dt.Email, dt.StudentNumber, dt.Team.TeamName, dt.RegistrationLink, paymentURL})
 
 		excelRowNum := rowIndex + 2
 
@@ -187,7 +209,7 @@ func (s *ExcelService) ExportExcelTeam() (string, error) {
 		return "", err
 	}
 
-	headers := []string{"No", "Nama User", "Nama Tim", "Nama Kompetisi", "Member"}
+	headers := []string{"No", "Nama User", "Nama Tim", "Nama Kompetisi", "Member", "Form Registrasi"}
 	rows := [][]interface{}{}
 
 	tx := s.db.Begin()
@@ -197,12 +219,31 @@ func (s *ExcelService) ExportExcelTeam() (string, error) {
 		}
 	}()
 
+	var teamIDs []uuid.UUID
+	for _, user := range data {
+		if user.Team.TeamID != uuid.Nil {
+			teamIDs = append(teamIDs, user.Team.TeamID)
+		}
+	}
+
+	// jawaban form semua tim diambil sekali lalu dikelompokkan per tim
+	answers, err := s.FormRepository.GetAnswersByTeamIDs(tx, teamIDs)
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	answerMap := make(map[uuid.UUID][]entity.RegistrationAnswer)
+	for _, answer := range answers {
+		answerMap[answer.TeamID] = append(answerMap[answer.TeamID], answer)
+	}
+
 	teamMap := make(map[string]bool)
 	no := 1
 
 	for _, user := range data {
 		team, err := s.TeamRepository.GetTeamByUserID(tx, user.UserID)
-		if err != nil || team == nil {
+		if err != nil || team == nil || !isTeamActive(team) {
 			continue
 		}
 
@@ -223,6 +264,20 @@ func (s *ExcelService) ExportExcelTeam() (string, error) {
 			return "", err
 		}
 
+		var form []string
+		for _, answer := range answerMap[team.TeamID] {
+			if answer.Field.CompetitionID != team.CompetitionID {
+				continue
+			}
+
+			value, err := formAnswerText(s.Storage, answer)
+			if err != nil {
+				tx.Rollback()
+				return "", err
+			}
+			form = append(form, fmt.Sprintf("%s: %s", answer.Field.Label, value))
+		}
+
 		for i, member := range members {
 			if i == 0 {
 				rows = append(rows, []interface{}{
@@ -231,10 +286,11 @@ func (s *ExcelService) ExportExcelTeam() (string, error) {
 					team.TeamName,
 					competition.CompetitionName,
 					member.MemberName,
+					strings.Join(form, "\n"),
 				})
 			} else {
 				rows = append(rows, []interface{}{
-					"", "", "", "", member.MemberName,
+					"", "", "", "", member.MemberName, "",
 				})
 			}
 		}
@@ -258,7 +314,7 @@ func (s *ExcelService) ExportExcelTeam() (string, error) {
 		Name:          "Team",
 		Headers:       headers,
 		Rows:          rows,
-		ColWidths:     map[int]float64{1: 5, 2: 30, 3: 30, 4: 30, 5: 25},
+		ColWidths:     map[int]float64{1: 5, 2: 30, 3: 30, 4: 30, 5: 25, 6: 50},
 		HeaderStyleID: headerStyle,
 		RowStyleMap:   rowStyleMap,
 		ColStyleMap:   colStyleMap,
@@ -322,12 +378,53 @@ func (s *ExcelService) ExportExcelCompetitionByID(competitionID int) (string, er
 		return "", err
 	}
 
+	fields, err := s.FormRepository.GetFieldsByCompetitionID(tx, competitionID)
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	var teamIDs []uuid.UUID
+	for _, team := range competition.Teams {
+		teamIDs = append(teamIDs, team.TeamID)
+	}
+
+	answers, err := s.FormRepository.GetAnswersByTeamIDs(tx, teamIDs)
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	// jawaban dikelompokkan per tim lalu per isian agar urutan kolom mengikuti urutan form
+	answerMap := make(map[uuid.UUID]map[int]string)
+	for _, answer := range answers {
+		value, err := formAnswerText(s.Storage, answer)
+		if err != nil {
+			tx.Rollback()
+			return "", err
+		}
+
+		if answerMap[answer.TeamID] == nil {
+			answerMap[answer.TeamID] = make(map[int]string)
+		}
+		answerMap[answer.TeamID][answer.FieldID] = value
+	}
+
 	headers := []string{"No", "Nama User", "Nama Tim", "Nama Kompetisi", "Member"}
+	colWidths := map[int]float64{1: 5, 2: 30, 3: 30, 4: 30, 5: 25}
+	for _, field := range fields {
+		headers = append(headers, field.Label)
+		colWidths[len(headers)] = 30
+	}
 	rows := [][]interface{}{}
 
 	no := 1
 
 	for _, team := range competition.Teams {
+		if !isTeamActive(&team) {
+			continue
+		}
+
 		user, err := s.UserRepository.GetUser(model.UserParam{
 			UserID: team.UserID,
 		})
@@ -338,17 +435,25 @@ func (s *ExcelService) ExportExcelCompetitionByID(competitionID int) (string, er
 
 		for i, member := range team.TeamMembers {
 			if i == 0 {
-				rows = append(rows, []interface{}{
+				row := []interface{}{
 					no,
 					user.FullName,
 					team.TeamName,
 					competition.CompetitionName,
 					member.MemberName,
-				})
+				}
+				for _, field := range fields {
+					row = append(row, answerMap[team.TeamID][field.FieldID])
+				}
+				rows = append(rows, row)
 			} else {
-				rows = append(rows, []interface{}{
+				row := []interface{}{
 					"", "", "", "", member.MemberName,
-				})
+				}
+				for range fields {
+					row = append(row, "")
+				}
+				rows = append(rows, row)
 			}
 		}
 		no++
@@ -371,7 +476,7 @@ func (s *ExcelService) ExportExcelCompetitionByID(competitionID int) (string, er
 		Name:          "Team",
 		Headers:       headers,
 		Rows:          rows,
-		ColWidths:     map[int]float64{1: 5, 2: 30, 3: 30, 4: 30, 5: 25},
+		ColWidths:     colWidths,
 		HeaderStyleID: headerStyle,
 		RowStyleMap:   rowStyleMap,
 		ColStyleMap:   colStyleMap,
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"io"
+	"itfest-2025/pkg/storage"
+)
+
+type IFileService interface {
+	OpenSignedFile(key string, expires string, signature string) (io.ReadCloser, error)
+}
+
+type FileService struct {
+	Storage storage.Interface
+}
+
+func NewFileService(storage storage.Interface) IFileService {
+	return &FileService{
+		Storage: storage,
+	}
+}
+
+// OpenSignedFile hanya dipakai driver yang melayani signed URL sendiri, driver lain memakai URL dari penyedia storage.
+func (f *FileService) OpenSignedFile(key string, expires string, signature string) (io.ReadCloser, error) {
+	verifier, ok := f.Storage.(storage.Verifier)
+	if !ok {
+		return nil, storage.ErrFileNotFound
+	}
+
+	err := verifier.Verify(key, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Storage.Open(key)
+}
+
+// signedFileURL membuat signed URL yang kedaluwarsa untuk file di storage, URL lama dikembalikan apa adanya.
+func signedFileURL(s storage.Interface, value string) (string, error) {
+	return storage.URL(s, value, storage.SignedURLExpiry())
+}
+
+// deleteStoredFile menghapus file lama di storage, URL lama yang bukan key storage dilewati.
+func deleteStoredFile(s storage.Interface, value string) {
+	if !storage.IsKey(value) {
+		return
+	}
+
+	_ = s.Delete(value)
+}
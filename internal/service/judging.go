@@ -0,0 +1,635 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IJudgingService interface {
+	GetRubric(stageID int) ([]model.RubricCriterionResponse, error)
+	SetRubric(stageID int, param model.RubricRequest) ([]model.RubricCriterionResponse, error)
+	AssignJudge(actor *entity.User, teamProgressID int, param model.AssignJudgeRequest) (*model.JudgeAssignmentResponse, error)
+	UnassignJudge(teamProgressID int, judgeID uuid.UUID) error
+	GetMyAssignments(judgeID uuid.UUID) ([]*model.JudgeAssignmentResponse, error)
+	SubmitScores(judgeID uuid.UUID, assignmentID uuid.UUID, param model.ScoreSubmissionRequest) (*model.JudgeAssignmentResponse, error)
+	GetStageRanking(stageID int) ([]*model.StageRankingResponse, error)
+	PassTopTeams(actor *entity.User, stageID int, param model.PassTopRequest) (*model.PassTopResponse, error)
+}
+
+type JudgingService struct {
+	db                   *gorm.DB
+	JudgingRepository    repository.IJudgingRepository
+	SubmissionRepository repository.ISubmissionRepository
+	TeamRepository       repository.ITeamRepository
+	UserRepository       repository.IUserRepository
+	RoleService          IRoleService
+	Storage              storage.Interface
+}
+
+func NewJudgingService(judgingRepository repository.IJudgingRepository, submissionRepository repository.ISubmissionRepository, teamRepository repository.ITeamRepository, userRepository repository.IUserRepository, roleService IRoleService, storage storage.Interface) IJudgingService {
+	return &JudgingService{
+		db:                   mariadb.Connection,
+		JudgingRepository:    judgingRepository,
+		SubmissionRepository: submissionRepository,
+		TeamRepository:       teamRepository,
+		UserRepository:       userRepository,
+		RoleService:          roleService,
+		Storage:              storage,
+	}
+}
+
+func (j *JudgingService) GetRubric(stageID int) ([]model.RubricCriterionResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	_, err := j.getStage(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := j.JudgingRepository.GetRubricByStageID(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRubricResponse(criteria), nil
+}
+
+func (j *JudgingService) SetRubric(stageID int, param model.RubricRequest) ([]model.RubricCriterionResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	_, err := j.getStage(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	scored, err := j.JudgingRepository.CountScoresByStageID(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+	if scored > 0 {
+		return nil, model.ErrRubricLocked
+	}
+
+	err = j.JudgingRepository.DeleteRubricByStageID(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var criteria []entity.RubricCriterion
+	for _, v := range param.Criteria {
+		criterion := entity.RubricCriterion{
+			StageID:     stageID,
+			Name:        v.Name,
+			Description: v.Description,
+			Weight:      v.Weight,
+			MaxScore:    v.MaxScore,
+		}
+
+		err = j.JudgingRepository.CreateRubricCriterion(tx, &criterion)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toRubricResponse(criteria), nil
+}
+
+func (j *JudgingService) AssignJudge(actor *entity.User, teamProgressID int, param model.AssignJudgeRequest) (*model.JudgeAssignmentResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	progress, err := j.getSubmission(tx, teamProgressID)
+	if err != nil {
+		return nil, err
+	}
+
+	judge, err := j.UserRepository.GetUser(model.UserParam{
+		UserID: param.JudgeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	isJudge, err := j.RoleService.HasPermission(judge, entity.PermissionSubmissionsGrade)
+	if err != nil {
+		return nil, err
+	}
+	if !isJudge {
+		return nil, model.ErrNotJudge
+	}
+
+	assignment, err := j.JudgingRepository.GetAssignment(tx, teamProgressID, judge.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if assignment == nil {
+		assignment = &entity.JudgeAssignment{
+			AssignmentID:   uuid.New(),
+			TeamProgressID: progress.TeamProgressID,
+			JudgeID:        judge.UserID,
+			AssignedBy:     actor.UserID,
+		}
+
+		err = j.JudgingRepository.CreateAssignment(tx, assignment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := j.toAssignmentResponse(tx, *assignment, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (j *JudgingService) UnassignJudge(teamProgressID int, judgeID uuid.UUID) error {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	assignment, err := j.JudgingRepository.GetAssignment(tx, teamProgressID, judgeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrAssignmentNotFound
+		}
+		return err
+	}
+
+	if assignment.SubmittedAt != nil {
+		return model.ErrAssignmentScored
+	}
+
+	err = j.JudgingRepository.DeleteAssignment(tx, assignment.AssignmentID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (j *JudgingService) GetMyAssignments(judgeID uuid.UUID) ([]*model.JudgeAssignmentResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	assignments, err := j.JudgingRepository.GetAssignmentsByJudgeID(tx, judgeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []*model.JudgeAssignmentResponse{}
+	for _, v := range assignments {
+		progress, err := j.SubmissionRepository.GetSubmissionByID(tx, v.TeamProgressID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		assignment, err := j.toAssignmentResponse(tx, v, progress)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, assignment)
+	}
+
+	return response, nil
+}
+
+func (j *JudgingService) SubmitScores(judgeID uuid.UUID, assignmentID uuid.UUID, param model.ScoreSubmissionRequest) (*model.JudgeAssignmentResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	assignment, err := j.JudgingRepository.GetAssignmentByID(tx, assignmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrAssignmentNotFound
+		}
+		return nil, err
+	}
+
+	if assignment.JudgeID != judgeID {
+		return nil, model.ErrAssignmentNotFound
+	}
+
+	progress, err := j.getSubmission(tx, assignment.TeamProgressID)
+	if err != nil {
+		return nil, err
+	}
+
+	if progress.Status != "diproses" {
+		return nil, model.ErrSubmissionDecided
+	}
+
+	criteria, err := j.JudgingRepository.GetRubricByStageID(tx, progress.StageID)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, model.ErrRubricEmpty
+	}
+
+	criterionMap := make(map[int]entity.RubricCriterion)
+	for _, v := range criteria {
+		criterionMap[v.CriterionID] = v
+	}
+
+	if len(param.Scores) != len(criteria) {
+		return nil, model.ErrIncompleteScores
+	}
+
+	var scores []entity.JudgeScore
+	scored := make(map[int]bool)
+	for _, v := range param.Scores {
+		criterion, ok := criterionMap[v.CriterionID]
+		if !ok || scored[v.CriterionID] {
+			return nil, model.ErrIncompleteScores
+		}
+		if v.Score < 0 || v.Score > float64(criterion.MaxScore) {
+			return nil, model.ErrInvalidScore
+		}
+		scored[v.CriterionID] = true
+
+		scores = append(scores, entity.JudgeScore{
+			AssignmentID: assignment.AssignmentID,
+			CriterionID:  v.CriterionID,
+			Score:        v.Score,
+			Comment:      v.Comment,
+		})
+	}
+
+	err = j.JudgingRepository.ReplaceScores(tx, assignment.AssignmentID, scores)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	assignment.Comment = param.Comment
+	assignment.ScoredVersion = progress.CurrentVersion
+	assignment.SubmittedAt = &now
+	assignment.Scores = scores
+
+	err = j.JudgingRepository.UpdateAssignment(tx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := j.toAssignmentResponse(tx, *assignment, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (j *JudgingService) GetStageRanking(stageID int) ([]*model.StageRankingResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	return j.getStageRanking(tx, stageID)
+}
+
+// PassTopTeams meloloskan TopN tim teratas yang masih berhak, tim dengan skor sama dengan tim di batas TopN ikut diloloskan.
+// Tim yang sudah dinyatakan tidak lolos, mengundurkan diri, atau didiskualifikasi tidak diubah.
+func (j *JudgingService) PassTopTeams(actor *entity.User, stageID int, param model.PassTopRequest) (*model.PassTopResponse, error) {
+	tx := j.db.Begin()
+	defer tx.Rollback()
+
+	ranking, err := j.getStageRanking(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var teamIDs []uuid.UUID
+	for _, v := range ranking {
+		teamIDs = append(teamIDs, v.TeamID)
+	}
+
+	teams, err := j.TeamRepository.GetTeamsByIDs(tx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMap := make(map[uuid.UUID]*entity.Team)
+	for i := range teams {
+		teamMap[teams[i].TeamID] = &teams[i]
+	}
+
+	var eligible []*model.StageRankingResponse
+	for _, v := range ranking {
+		team, ok := teamMap[v.TeamID]
+		if !ok || v.Status == "tidak lolos" || !isTeamActive(team) {
+			continue
+		}
+		eligible = append(eligible, v)
+	}
+
+	cutoff := -1.0
+	judged := 0
+	for _, v := range eligible {
+		if v.JudgeCount > 0 {
+			judged++
+			if judged == param.TopN {
+				cutoff = v.Score
+				break
+			}
+		}
+	}
+
+	var response model.PassTopResponse
+	for i, v := range eligible {
+		status := ""
+		if v.JudgeCount > 0 && (i < param.TopN || v.Score == cutoff) {
+			status = "lolos"
+			response.Passed++
+		} else if param.FailOthers && v.Status == "diproses" {
+			status = "tidak lolos"
+			response.Failed++
+		}
+
+		if status == "" || status == v.Status {
+			continue
+		}
+
+		err = j.SubmissionRepository.UpdateSubmissionDecision(tx, v.TeamProgressID, status, actor.UserID, param.Reason)
+		if err != nil {
+			return nil, err
+		}
+
+		if status == "lolos" {
+			err = lockTeam(tx, j.TeamRepository, teamMap[v.TeamID], fmt.Sprintf("passed stage %d", stageID), &actor.UserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// getStageRanking mengurutkan tim berdasarkan rata-rata skor berbobot dari seluruh juri yang sudah menilai.
+func (j *JudgingService) getStageRanking(tx *gorm.DB, stageID int) ([]*model.StageRankingResponse, error) {
+	_, err := j.getStage(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := j.JudgingRepository.GetRubricByStageID(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, model.ErrRubricEmpty
+	}
+
+	progresses, err := j.SubmissionRepository.GetSubmissionsByStageID(tx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var progressIDs []int
+	for _, v := range progresses {
+		progressIDs = append(progressIDs, v.TeamProgressID)
+	}
+
+	assignments, err := j.JudgingRepository.GetAssignmentsByTeamProgressIDs(tx, progressIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	assignmentMap := make(map[int][]entity.JudgeAssignment)
+	for _, v := range assignments {
+		if v.SubmittedAt == nil {
+			continue
+		}
+		assignmentMap[v.TeamProgressID] = append(assignmentMap[v.TeamProgressID], v)
+	}
+
+	var teamIDs []uuid.UUID
+	for _, v := range progresses {
+		teamIDs = append(teamIDs, v.TeamID)
+	}
+
+	teams, err := j.TeamRepository.GetTeamsByIDs(tx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	teamNames := make(map[uuid.UUID]string)
+	for _, v := range teams {
+		teamNames[v.TeamID] = v.TeamName
+	}
+
+	ranking := []*model.StageRankingResponse{}
+	for _, v := range progresses {
+		teamName := teamNames[v.TeamID]
+
+		total := 0.0
+		for _, a := range assignmentMap[v.TeamProgressID] {
+			total += weightedScore(criteria, a.Scores)
+		}
+
+		score := 0.0
+		judgeCount := len(assignmentMap[v.TeamProgressID])
+		if judgeCount > 0 {
+			score = math.Round(total/float64(judgeCount)*100) / 100
+		}
+
+		ranking = append(ranking, &model.StageRankingResponse{
+			TeamProgressID: v.TeamProgressID,
+			TeamID:         v.TeamID,
+			TeamName:       teamName,
+			Status:         v.Status,
+			JudgeCount:     judgeCount,
+			Score:          score,
+		})
+	}
+
+	sort.SliceStable(ranking, func(a, b int) bool {
+		if (ranking[a].JudgeCount > 0) != (ranking[b].JudgeCount > 0) {
+			return ranking[a].JudgeCount > 0
+		}
+		return ranking[a].Score > ranking[b].Score
+	})
+
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+
+	return ranking, nil
+}
+
+func (j *JudgingService) getStage(tx *gorm.DB, stageID int) (entity.Stages, error) {
+	stage, err := j.SubmissionRepository.GetStage(tx, stageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return stage, model.ErrStageNotFound
+		}
+		return stage, err
+	}
+
+	return stage, nil
+}
+
+func (j *JudgingService) getSubmission(tx *gorm.DB, teamProgressID int) (*entity.TeamProgress, error) {
+	progress, err := j.SubmissionRepository.GetSubmissionByID(tx, teamProgressID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (j *JudgingService) toAssignmentResponse(tx *gorm.DB, assignment entity.JudgeAssignment, progress *entity.TeamProgress) (*model.JudgeAssignmentResponse, error) {
+	stage, err := j.getStage(tx, progress.StageID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := j.JudgingRepository.GetRubricByStageID(tx, progress.StageID)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := j.TeamRepository.GetTeamByID(tx, progress.TeamID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	teamName := ""
+	if team != nil {
+		teamName = team.TeamName
+	}
+
+	scores := []model.CriterionScoreResponse{}
+	for _, v := range assignment.Scores {
+		scores = append(scores, model.CriterionScoreResponse{
+			CriterionID: v.CriterionID,
+			Score:       v.Score,
+			Comment:     v.Comment,
+		})
+	}
+
+	fileURL, err := signedFileURL(j.Storage, progress.FileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	scoredLink := ""
+	if assignment.ScoredVersion > 0 {
+		version, err := j.SubmissionRepository.GetSubmissionVersion(tx, progress.TeamProgressID, assignment.ScoredVersion)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		scoredLink = progress.GdriveLink
+		if progress.FileKey != "" {
+			scoredLink = fileURL
+		}
+
+		if version != nil {
+			scoredLink = version.GdriveLink
+			if version.FileKey != "" {
+				scoredLink, err = signedFileURL(j.Storage, version.FileKey)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return &model.JudgeAssignmentResponse{
+		AssignmentID:   assignment.AssignmentID,
+		TeamProgressID: progress.TeamProgressID,
+		TeamID:         progress.TeamID,
+		TeamName:       teamName,
+		StageID:        stage.StageID,
+		StageName:      stage.StageName,
+		GdriveLink:     progress.GdriveLink,
+		FileName:       progress.FileName,
+		FileURL:        fileURL,
+		CurrentVersion: progress.CurrentVersion,
+		ScoredVersion:  assignment.ScoredVersion,
+		ScoredLink:     scoredLink,
+		Comment:        assignment.Comment,
+		SubmittedAt:    assignment.SubmittedAt,
+		Criteria:       toRubricResponse(criteria),
+		Scores:         scores,
+	}, nil
+}
+
+// weightedScore menghitung skor 0-100 dari satu juri berdasarkan bobot tiap kriteria.
+func weightedScore(criteria []entity.RubricCriterion, scores []entity.JudgeScore) float64 {
+	scoreMap := make(map[int]float64)
+	for _, v := range scores {
+		scoreMap[v.CriterionID] = v.Score
+	}
+
+	total, totalWeight := 0.0, 0.0
+	for _, v := range criteria {
+		totalWeight += v.Weight
+		if v.MaxScore > 0 {
+			total += scoreMap[v.CriterionID] / float64(v.MaxScore) * v.Weight
+		}
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return total / totalWeight * 100
+}
+
+func toRubricResponse(criteria []entity.RubricCriterion) []model.RubricCriterionResponse {
+	response := []model.RubricCriterionResponse{}
+	for _, v := range criteria {
+		response = append(response, model.RubricCriterionResponse{
+			CriterionID: v.CriterionID,
+			Name:        v.Name,
+			Description: v.Description,
+			Weight:      v.Weight,
+			MaxScore:    v.MaxScore,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IMemberDocumentService interface {
+	UploadMyKTM(userID uuid.UUID, file *multipart.FileHeader) error
+	UploadMemberKTM(userID uuid.UUID, teamMemberID uuid.UUID, file *multipart.FileHeader) error
+	GetMyTeamDocuments(userID uuid.UUID) (*model.TeamDocumentsResponse, error)
+	GetTeamDocuments(teamID uuid.UUID) (*model.TeamDocumentsResponse, error)
+	ReviewDocument(actor *entity.User, documentID uuid.UUID, param model.ReviewDocumentRequest) error
+}
+
+type MemberDocumentService struct {
+	db                 *gorm.DB
+	DocumentRepository repository.IMemberDocumentRepository
+	TeamRepository     repository.ITeamRepository
+	UserRepository     repository.IUserRepository
+	PaymentRepository  repository.IPaymentRepository
+	Storage            storage.Interface
+}
+
+func NewMemberDocumentService(documentRepository repository.IMemberDocumentRepository, teamRepository repository.ITeamRepository, userRepository repository.IUserRepository, paymentRepository repository.IPaymentRepository, storage storage.Interface) IMemberDocumentService {
+	return &MemberDocumentService{
+		db:                 mariadb.Connection,
+		DocumentRepository: documentRepository,
+		TeamRepository:     teamRepository,
+		UserRepository:     userRepository,
+		PaymentRepository:  paymentRepository,
+		Storage:            storage,
+	}
+}
+
+// UploadMyKTM dipakai ketua maupun anggota untuk mengunggah KTM miliknya sendiri.
+func (m *MemberDocumentService) UploadMyKTM(userID uuid.UUID, file *multipart.FileHeader) error {
+	if file.Size > int64(1024*1024) {
+		return model.ErrDocumentTooLarge
+	}
+
+	tx := m.db.Begin()
+	defer tx.Rollback()
+
+	team, err := m.TeamRepository.GetTeamForUser(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = ensureTeamEditable(team)
+	if err != nil {
+		return err
+	}
+
+	if team.UserID != userID {
+		member, err := m.TeamRepository.GetTeamMemberByUserID(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		return m.storeMemberKTM(tx, team, member, userID, file)
+	}
+
+	user, err := m.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	contentType, err := model.GetDocumentType(file)
+	if err != nil {
+		return err
+	}
+
+	key, err := m.Storage.Upload(file, "ktm", contentType)
+	if err != nil {
+		return err
+	}
+
+	oldKey, err := saveMemberDocument(tx, m.DocumentRepository, team, userID, entity.DocumentOwnerLeader, key, userID)
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	// StudentCardLink tetap diisi karena masih ditampilkan pada detail tim di dashboard admin
+	if oldKey == "" {
+		oldKey = user.StudentCardLink
+	}
+	user.StudentCardLink = key
+
+	err = m.UserRepository.UpdateUser(tx, user)
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	err = syncTeamVerification(tx, m.DocumentRepository, m.TeamRepository, m.PaymentRepository, team)
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	deleteStoredFile(m.Storage, oldKey)
+
+	return nil
+}
+
+func (m *MemberDocumentService) UploadMemberKTM(userID uuid.UUID, teamMemberID uuid.UUID, file *multipart.FileHeader) error {
+	if file.Size > int64(1024*1024) {
+		return model.ErrDocumentTooLarge
+	}
+
+	tx := m.db.Begin()
+	defer tx.Rollback()
+
+	team, err := m.TeamRepository.GetTeamForUser(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = ensureTeamEditable(team)
+	if err != nil {
+		return err
+	}
+
+	members, err := m.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	var member *entity.TeamMember
+	for _, v := range members {
+		if v.TeamMemberID == teamMemberID {
+			member = v
+			break
+		}
+	}
+	if member == nil {
+		return model.ErrMemberNotFound
+	}
+
+	// anggota hanya boleh mengunggah dokumennya sendiri, ketua boleh mengunggah untuk semua anggota
+	if team.UserID != userID && (member.UserID == nil || *member.UserID != userID) {
+		return model.ErrDocumentForbidden
+	}
+
+	return m.storeMemberKTM(tx, team, member, userID, file)
+}
+
+func (m *MemberDocumentService) storeMemberKTM(tx *gorm.DB, team *entity.Team, member *entity.TeamMember, userID uuid.UUID, file *multipart.FileHeader) error {
+	contentType, err := model.GetDocumentType(file)
+	if err != nil {
+		return err
+	}
+
+	key, err := m.Storage.Upload(file, "ktm", contentType)
+	if err != nil {
+		return err
+	}
+
+	oldKey, err := saveMemberDocument(tx, m.DocumentRepository, team, member.TeamMemberID, entity.DocumentOwnerMember, key, userID)
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	err = syncTeamVerification(tx, m.DocumentRepository, m.TeamRepository, m.PaymentRepository, team)
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		deleteStoredFile(m.Storage, key)
+		return err
+	}
+
+	deleteStoredFile(m.Storage, oldKey)
+
+	return nil
+}
+
+func (m *MemberDocumentService) GetMyTeamDocuments(userID uuid.UUID) (*model.TeamDocumentsResponse, error) {
+	tx := m.db.Begin()
+	defer tx.Rollback()
+
+	team, err := m.TeamRepository.GetTeamForUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.getTeamDocuments(tx, team)
+}
+
+func (m *MemberDocumentService) GetTeamDocuments(teamID uuid.UUID) (*model.TeamDocumentsResponse, error) {
+	tx := m.db.Begin()
+	defer tx.Rollback()
+
+	team, err := m.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.getTeamDocuments(tx, team)
+}
+
+func (m *MemberDocumentService) ReviewDocument(actor *entity.User, documentID uuid.UUID, param model.ReviewDocumentRequest) error {
+	if param.Status == "rejected" && param.Reason == "" {
+		return model.ErrRejectionReasonRequired
+	}
+
+	tx := m.db.Begin()
+	defer tx.Rollback()
+
+	document, err := m.DocumentRepository.GetDocumentByID(tx, documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrDocumentNotFound
+		}
+		return err
+	}
+
+	now := time.Now()
+	document.Status = param.Status
+	document.RejectionReason = ""
+	if param.Status == "rejected" {
+		document.RejectionReason = param.Reason
+	}
+	document.ReviewedBy = &actor.UserID
+	document.ReviewedAt = &now
+
+	err = m.DocumentRepository.UpdateDocument(tx, document)
+	if err != nil {
+		return err
+	}
+
+	team, err := m.TeamRepository.GetTeamByID(tx, document.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = syncTeamVerification(tx, m.DocumentRepository, m.TeamRepository, m.PaymentRepository, team)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (m *MemberDocumentService) getTeamDocuments(tx *gorm.DB, team *entity.Team) (*model.TeamDocumentsResponse, error) {
+	leader, err := m.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := m.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := m.DocumentRepository.GetDocumentsByTeamID(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	byOwner := make(map[uuid.UUID]entity.MemberDocument)
+	for _, v := range documents {
+		if v.DocumentType == entity.DocumentKTM {
+			byOwner[v.OwnerID] = v
+		}
+	}
+
+	res := &model.TeamDocumentsResponse{
+		TeamID:      team.TeamID,
+		TeamName:    team.TeamName,
+		AllApproved: documentsApproved(team, members, documents),
+	}
+
+	item, err := m.toDocumentResponse(leader.UserID, entity.DocumentOwnerLeader, leader.FullName, leader.StudentNumber, byOwner)
+	if err != nil {
+		return nil, err
+	}
+	res.Documents = append(res.Documents, item)
+
+	for _, v := range members {
+		item, err := m.toDocumentResponse(v.TeamMemberID, entity.DocumentOwnerMember, v.MemberName, v.StudentNumber, byOwner)
+		if err != nil {
+			return nil, err
+		}
+		res.Documents = append(res.Documents, item)
+	}
+
+	return res, nil
+}
+
+func (m *MemberDocumentService) toDocumentResponse(ownerID uuid.UUID, ownerType string, name string, studentNumber string, byOwner map[uuid.UUID]entity.MemberDocument) (model.MemberDocumentResponse, error) {
+	item := model.MemberDocumentResponse{
+		OwnerID:       ownerID,
+		OwnerType:     ownerType,
+		MemberName:    name,
+		StudentNumber: studentNumber,
+		DocumentType:  entity.DocumentKTM,
+		Status:        "missing",
+	}
+
+	document, ok := byOwner[ownerID]
+	if !ok {
+		return item, nil
+	}
+
+	fileURL, err := signedFileURL(m.Storage, document.FileKey)
+	if err != nil {
+		return item, err
+	}
+
+	item.DocumentID = &document.DocumentID
+	item.Status = document.Status
+	item.RejectionReason = document.RejectionReason
+	item.FileURL = fileURL
+	item.ReviewedAt = document.ReviewedAt
+	item.UpdatedAt = &document.UpdatedAt
+
+	return item, nil
+}
+
+// saveMemberDocument menyimpan KTM baru dengan status pending dan mengembalikan key file lama.
+func saveMemberDocument(tx *gorm.DB, documentRepository repository.IMemberDocumentRepository, team *entity.Team, ownerID uuid.UUID, ownerType string, key string, uploadedBy uuid.UUID) (string, error) {
+	document, err := documentRepository.GetDocumentByOwner(tx, ownerID, entity.DocumentKTM)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+
+		return "", documentRepository.CreateDocument(tx, &entity.MemberDocument{
+			DocumentID:   uuid.New(),
+			TeamID:       team.TeamID,
+			OwnerID:      ownerID,
+			OwnerType:    ownerType,
+			DocumentType: entity.DocumentKTM,
+			FileKey:      key,
+			Status:       "pending",
+			UploadedBy:   uploadedBy,
+		})
+	}
+
+	if document.Status == "approved" {
+		return "", model.ErrDocumentLocked
+	}
+
+	oldKey := document.FileKey
+	document.TeamID = team.TeamID
+	document.FileKey = key
+	document.Status = "pending"
+	document.RejectionReason = ""
+	document.UploadedBy = uploadedBy
+	document.ReviewedBy = nil
+	document.ReviewedAt = nil
+
+	err = documentRepository.UpdateDocument(tx, document)
+	if err != nil {
+		return "", err
+	}
+
+	return oldKey, nil
+}
+
+// documentsApproved bernilai true jika KTM ketua dan seluruh anggota sudah disetujui.
+func documentsApproved(team *entity.Team, members []*entity.TeamMember, documents []entity.MemberDocument) bool {
+	approved := make(map[uuid.UUID]bool)
+	for _, v := range documents {
+		if v.DocumentType == entity.DocumentKTM && v.Status == "approved" {
+			approved[v.OwnerID] = true
+		}
+	}
+
+	if !approved[team.UserID] {
+		return false
+	}
+
+	for _, v := range members {
+		if !approved[v.TeamMemberID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// teamVerificationStatus menentukan status tim setelah pembayaran terverifikasi, tim baru
+// terverifikasi jika semua dokumen anggota sudah disetujui.
+func teamVerificationStatus(tx *gorm.DB, documentRepository repository.IMemberDocumentRepository, teamRepository repository.ITeamRepository, team *entity.Team) (string, error) {
+	members, err := teamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return "", err
+	}
+
+	documents, err := documentRepository.GetDocumentsByTeamID(tx, team.TeamID)
+	if err != nil {
+		return "", err
+	}
+
+	if !documentsApproved(team, members, documents) {
+		return "diproses", nil
+	}
+
+	return "terverifikasi", nil
+}
+
+// syncTeamVerification menyesuaikan status tim yang pembayarannya sudah terverifikasi dengan status dokumen terbaru.
+func syncTeamVerification(tx *gorm.DB, documentRepository repository.IMemberDocumentRepository, teamRepository repository.ITeamRepository, paymentRepository repository.IPaymentRepository, team *entity.Team) error {
+	payment, err := paymentRepository.GetPaymentByTeamID(tx, team.TeamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if payment.Status != "terverifikasi" {
+		return nil
+	}
+
+	status, err := teamVerificationStatus(tx, documentRepository, teamRepository, team)
+	if err != nil {
+		return err
+	}
+
+	if status == team.TeamStatus {
+		return nil
+	}
+
+	return updateTeamStatus(tx, teamRepository, team, status, nil)
+}
@@ -0,0 +1,730 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/mail"
+	"itfest-2025/pkg/payment"
+	"itfest-2025/pkg/storage"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IPaymentService interface {
+	SubmitPayment(userID uuid.UUID, file *multipart.FileHeader, param model.UploadPaymentRequest) (*model.UploadPaymentResponse, error)
+	VerifyPayment(actor *entity.User, teamID uuid.UUID, param model.ReqUpdateStatusTeam) error
+	GetMyPayment(userID uuid.UUID) (*model.PaymentResponse, error)
+	GetTeamPayment(teamID uuid.UUID) (*model.PaymentResponse, error)
+	CreateCharge(userID uuid.UUID) (*model.ChargeResponse, error)
+	HandleWebhook(header http.Header, body []byte) error
+	GetSimulatorCharge(orderID string) (*model.ChargeResponse, error)
+	SimulatePayment(orderID string, param model.SimulatePaymentRequest) error
+}
+
+type PaymentService struct {
+	db                    *gorm.DB
+	PaymentRepository     repository.IPaymentRepository
+	UserRepository        repository.IUserRepository
+	TeamRepository        repository.ITeamRepository
+	CompetitionRepository repository.ICompetitionRepository
+	ReceiptRepository     repository.IReceiptRepository
+	DocumentRepository    repository.IMemberDocumentRepository
+	Storage               storage.Interface
+	Provider              payment.Interface
+	OutboxRepository      repository.IEmailOutboxRepository
+}
+
+func NewPaymentService(paymentRepository repository.IPaymentRepository, userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository, receiptRepository repository.IReceiptRepository, documentRepository repository.IMemberDocumentRepository, storage storage.Interface, provider payment.Interface, outboxRepository repository.IEmailOutboxRepository) IPaymentService {
+	return &PaymentService{
+		db:                    mariadb.Connection,
+		PaymentRepository:     paymentRepository,
+		ReceiptRepository:     receiptRepository,
+		DocumentRepository:    documentRepository,
+		UserRepository:        userRepository,
+		TeamRepository:        teamRepository,
+		CompetitionRepository: competitionRepository,
+		Storage:               storage,
+		Provider:              provider,
+		OutboxRepository:      outboxRepository,
+	}
+}
+
+func (p *PaymentService) SubmitPayment(userID uuid.UUID, file *multipart.FileHeader, param model.UploadPaymentRequest) (*model.UploadPaymentResponse, error) {
+	maxSize := int64(1024 * 1024)
+	if file.Size > maxSize {
+		return nil, model.ErrPaymentFileTooLarge
+	}
+
+	tx := p.db.Begin()
+	defer tx.Rollback()
+
+	user, err := p.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	team, err := p.TeamRepository.GetTeamByID(tx, user.Team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureTeamActive(team)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := getOrCreatePayment(tx, p.PaymentRepository, team, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status == "terverifikasi" {
+		return nil, model.ErrPaymentAlreadyVerified
+	}
+
+	contentType, err := model.GetDocumentType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentKey, err := p.Storage.Upload(file, "payments", contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPayment := user.PaymentTransc
+	user.PaymentTransc = paymentKey
+
+	err = p.UserRepository.UpdateUser(tx, user)
+	if err != nil {
+		deleteStoredFile(p.Storage, paymentKey)
+		return nil, err
+	}
+
+	team.TeamStatus = "diproses"
+
+	err = p.TeamRepository.UpdateTeam(tx, team)
+	if err != nil {
+		deleteStoredFile(p.Storage, paymentKey)
+		return nil, err
+	}
+
+	now := time.Now()
+	fromStatus := payment.Status
+	payment.Amount = param.Amount
+	payment.Channel = param.Channel
+	payment.Method = "manual"
+	payment.ProofFile = paymentKey
+	payment.Status = team.TeamStatus
+	payment.SubmittedAt = &now
+	payment.VerifiedBy = nil
+	payment.VerifiedAt = nil
+	payment.RejectionReason = ""
+
+	err = savePaymentWithHistory(tx, p.PaymentRepository, payment, fromStatus, "", &userID)
+	if err != nil {
+		deleteStoredFile(p.Storage, paymentKey)
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		deleteStoredFile(p.Storage, paymentKey)
+		return nil, err
+	}
+
+	deleteStoredFile(p.Storage, oldPayment)
+
+	res := &model.UploadPaymentResponse{
+		Status: team.TeamStatus,
+	}
+
+	return res, nil
+}
+
+// paymentTransitions adalah perubahan status yang boleh dilakukan admin, status yang sama tidak boleh diulang
+// agar waktu verifikasi dan kwitansi tidak diterbitkan ulang.
+var paymentTransitions = map[string][]string{
+	"belum terverifikasi": {"terverifikasi", "ditolak"},
+	"diproses":            {"terverifikasi", "ditolak", "belum terverifikasi"},
+	"ditolak":             {"terverifikasi", "diproses"},
+	"terverifikasi":       {"ditolak", "belum terverifikasi"},
+}
+
+func (p *PaymentService) VerifyPayment(actor *entity.User, teamID uuid.UUID, param model.ReqUpdateStatusTeam) error {
+	if param.PaymentStatus == "ditolak" && param.Reason == "" {
+		return model.ErrRejectionReasonRequired
+	}
+
+	tx := p.db.Begin()
+	defer tx.Rollback()
+
+	team, err := p.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if team.CompetitionID == entity.UnassignedCompetitionID {
+		return model.ErrPaymentTeamUnregistered
+	}
+
+	leader, err := p.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	payment, err := getOrCreatePayment(tx, p.PaymentRepository, team, leader)
+	if err != nil {
+		return err
+	}
+
+	if !contains(paymentTransitions[payment.Status], param.PaymentStatus) {
+		return model.ErrPaymentTransition
+	}
+
+	if param.PaymentStatus == "terverifikasi" {
+		if payment.Method == "manual" && payment.ProofFile == "" {
+			return model.ErrPaymentProofRequired
+		}
+
+		competition, err := p.CompetitionRepository.GetCompetitionByID(tx, team.CompetitionID)
+		if err != nil {
+			return err
+		}
+
+		amount := teamPaymentAmount(competition, team)
+		if amount > 0 && payment.Amount != amount {
+			return model.ErrPaymentAmountMismatch
+		}
+	}
+
+	teamStatus := param.PaymentStatus
+	if param.PaymentStatus == "terverifikasi" {
+		teamStatus, err = teamVerificationStatus(tx, p.DocumentRepository, p.TeamRepository, team)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = updateTeamStatus(tx, p.TeamRepository, team, teamStatus, &actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	fromStatus := payment.Status
+	payment.Status = param.PaymentStatus
+	payment.VerifiedBy = nil
+	payment.VerifiedAt = nil
+	payment.RejectionReason = ""
+	if param.PaymentStatus == "terverifikasi" || param.PaymentStatus == "ditolak" {
+		payment.VerifiedBy = &actor.UserID
+		payment.VerifiedAt = &now
+	}
+	if param.PaymentStatus == "ditolak" {
+		payment.RejectionReason = param.Reason
+	}
+
+	err = savePaymentWithHistory(tx, p.PaymentRepository, payment, fromStatus, param.Reason, &actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	var receipt *entity.Receipt
+	if param.PaymentStatus == "terverifikasi" {
+		receipt, err = issueReceipt(tx, p.ReceiptRepository, p.CompetitionRepository, team, leader, payment, actor.FullName)
+		if err != nil {
+			return err
+		}
+	} else if fromStatus == "terverifikasi" {
+		err = p.ReceiptRepository.VoidReceipts(tx, team.TeamID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if param.PaymentStatus == "ditolak" {
+		err = queuePaymentRejectedEmail(tx, p.OutboxRepository, leader, team, param.Reason)
+	} else if receipt != nil && fromStatus != "terverifikasi" {
+		err = queuePaymentVerifiedEmail(tx, p.OutboxRepository, receipt)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (p *PaymentService) GetMyPayment(userID uuid.UUID) (*model.PaymentResponse, error) {
+	tx := p.db.Begin()
+	defer tx.Rollback()
+
+	team, err := p.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.CompetitionID == entity.UnassignedCompetitionID {
+		return nil, model.ErrPaymentNotFound
+	}
+
+	return p.getPayment(tx, team.TeamID)
+}
+
+func (p *PaymentService) GetTeamPayment(teamID uuid.UUID) (*model.PaymentResponse, error) {
+	tx := p.db.Begin()
+	defer tx.Rollback()
+
+	return p.getPayment(tx, teamID)
+}
+
+func (p *PaymentService) getPayment(tx *gorm.DB, teamID uuid.UUID) (*model.PaymentResponse, error) {
+	payment, err := p.PaymentRepository.GetPaymentByTeamID(tx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	proofURL, err := signedFileURL(p.Storage, payment.ProofFile)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := []model.PaymentHistoryResponse{}
+	for _, v := range payment.Histories {
+		histories = append(histories, model.PaymentHistoryResponse{
+			FromStatus: v.FromStatus,
+			ToStatus:   v.ToStatus,
+			Amount:     v.Amount,
+			Channel:    v.Channel,
+			Reason:     v.Reason,
+			ChangedBy:  v.ChangedBy,
+			CreatedAt:  v.CreatedAt,
+		})
+	}
+
+	return &model.PaymentResponse{
+		PaymentID:       payment.PaymentID,
+		TeamID:          payment.TeamID,
+		CompetitionID:   payment.CompetitionID,
+		ReferenceCode:   payment.ReferenceCode,
+		Amount:          payment.Amount,
+		ProofURL:        proofURL,
+		Channel:         payment.Channel,
+		Method:          payment.Method,
+		Status:          payment.Status,
+		SubmittedAt:     payment.SubmittedAt,
+		VerifiedBy:      payment.VerifiedBy,
+		VerifiedAt:      payment.VerifiedAt,
+		RejectionReason: payment.RejectionReason,
+		Histories:       histories,
+	}, nil
+}
+
+func (p *PaymentService) CreateCharge(userID uuid.UUID) (*model.ChargeResponse, error) {
+	if _, ok := p.Provider.(*payment.Manual); ok {
+		return nil, payment.ErrGatewayDisabled
+	}
+
+	tx := p.db.Begin()
+	defer tx.Rollback()
+
+	user, err := p.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := p.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// tim dikunci agar dua permintaan bersamaan tidak membuat dua tagihan aktif
+	team, err = p.TeamRepository.LockTeam(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureTeamActive(team)
+	if err != nil {
+		return nil, err
+	}
+
+	competition, err := p.CompetitionRepository.GetCompetitionByID(tx, team.CompetitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+
+	amount := teamPaymentAmount(competition, team)
+	if amount <= 0 {
+		return nil, model.ErrNoRegistrationFee
+	}
+
+	teamPayment, err := getOrCreatePayment(tx, p.PaymentRepository, team, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if teamPayment.Status == "terverifikasi" {
+		return nil, model.ErrPaymentAlreadyVerified
+	}
+
+	charge, err := p.PaymentRepository.GetPendingCharge(tx, team.TeamID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if charge != nil && charge.Amount != amount {
+		// tagihan lama ditutup di gateway lebih dulu agar tim tidak bisa membayar dua tagihan
+		err = p.Provider.ExpireCharge(charge.Reference)
+		if err != nil {
+			return nil, err
+		}
+
+		charge.Status = payment.StatusExpired
+		err = p.PaymentRepository.UpdateCharge(tx, charge)
+		if err != nil {
+			return nil, err
+		}
+		charge = nil
+	}
+
+	if charge == nil {
+		orderID := "ITF-" + uuid.NewString()
+		result, err := p.Provider.CreateCharge(payment.Charge{
+			OrderID:       orderID,
+			Amount:        amount,
+			Description:   fmt.Sprintf("Registrasi %s - %s", competition.CompetitionName, team.TeamName),
+			CustomerName:  user.FullName,
+			CustomerEmail: user.Email,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		charge = &entity.PaymentCharge{
+			ChargeID:    uuid.New(),
+			OrderID:     orderID,
+			PaymentID:   teamPayment.PaymentID,
+			TeamID:      team.TeamID,
+			Provider:    p.Provider.Name(),
+			Reference:   result.Reference,
+			Amount:      amount,
+			Status:      "pending",
+			RedirectURL: result.RedirectURL,
+			ExpiresAt:   result.ExpiresAt,
+		}
+
+		err = p.PaymentRepository.CreateCharge(tx, charge)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toChargeResponse(charge), nil
+}
+
+// HandleWebhook memproses notifikasi gateway, notifikasi yang sama boleh datang berkali-kali.
+func (p *PaymentService) HandleWebhook(header http.Header, body []byte) error {
+	notification, err := p.Provider.ParseWebhook(header, body)
+	if err != nil {
+		return err
+	}
+
+	tx := p.db.Begin()
+	defer tx.Rollback()
+
+	// GetChargeByOrderID mengunci baris tagihan sehingga webhook ganda menunggu lalu berhenti di pengecekan paid
+	charge, err := p.PaymentRepository.GetChargeByOrderID(tx, notification.OrderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrChargeNotFound
+		}
+		return err
+	}
+
+	if charge.Status == "paid" {
+		return nil
+	}
+
+	var receipt *entity.Receipt
+	charge.Notification = string(body)
+	if notification.Reference != "" {
+		charge.Reference = notification.Reference
+	}
+
+	switch notification.Status {
+	case payment.StatusPaid:
+		if notification.Amount != charge.Amount {
+			return model.ErrChargeAmountMismatch
+		}
+
+		receipt, err = p.markChargePaid(tx, charge, notification)
+		if err != nil {
+			return err
+		}
+	case payment.StatusFailed, payment.StatusExpired:
+		charge.Status = notification.Status
+	default:
+		return nil
+	}
+
+	err = p.PaymentRepository.UpdateCharge(tx, charge)
+	if err != nil {
+		return err
+	}
+
+	if receipt != nil {
+		err = queuePaymentVerifiedEmail(tx, p.OutboxRepository, receipt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (p *PaymentService) GetSimulatorCharge(orderID string) (*model.ChargeResponse, error) {
+	if _, ok := p.Provider.(payment.Simulator); !ok {
+		return nil, model.ErrSimulatorDisabled
+	}
+
+	charge, err := p.PaymentRepository.GetChargeByOrderID(p.db, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrChargeNotFound
+		}
+		return nil, err
+	}
+
+	return toChargeResponse(charge), nil
+}
+
+// SimulatePayment mengirim webhook bertanda tangan dari simulator lokal ke alur webhook yang sama dengan gateway.
+func (p *PaymentService) SimulatePayment(orderID string, param model.SimulatePaymentRequest) error {
+	simulator, ok := p.Provider.(payment.Simulator)
+	if !ok {
+		return model.ErrSimulatorDisabled
+	}
+
+	charge, err := p.GetSimulatorCharge(orderID)
+	if err != nil {
+		return err
+	}
+
+	header, body, err := simulator.Simulate(charge.OrderID, charge.Amount, param.Status)
+	if err != nil {
+		return err
+	}
+
+	return p.HandleWebhook(header, body)
+}
+
+// markChargePaid mencatat tagihan lunas lalu memverifikasi payment tim. Payment yang sudah terverifikasi atau milik tim
+// yang sudah tidak aktif tidak diubah dan tidak diberi kwitansi, dananya tetap tercatat di tagihan untuk dikembalikan.
+func (p *PaymentService) markChargePaid(tx *gorm.DB, charge *entity.PaymentCharge, notification *payment.Notification) (*entity.Receipt, error) {
+	paidAt := notification.PaidAt
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	charge.Status = "paid"
+	charge.PaidAt = &paidAt
+
+	team, err := p.TeamRepository.LockTeam(tx, charge.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamPayment, err := p.PaymentRepository.GetPaymentByTeamID(tx, charge.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if teamPayment.Status == "terverifikasi" || !isTeamActive(team) {
+		log.Printf("charge %s paid but payment of team %s is %s and team is %s, payment left unchanged", charge.OrderID, team.TeamID, teamPayment.Status, team.LifecycleStatus)
+		return nil, nil
+	}
+
+	if !contains(paymentTransitions[teamPayment.Status], "terverifikasi") {
+		return nil, model.ErrPaymentTransition
+	}
+
+	now := time.Now()
+	fromStatus := teamPayment.Status
+	teamPayment.Amount = charge.Amount
+	teamPayment.Channel = charge.Provider
+	teamPayment.Method = "gateway"
+	teamPayment.Status = "terverifikasi"
+	teamPayment.SubmittedAt = &paidAt
+	teamPayment.VerifiedBy = nil
+	teamPayment.VerifiedAt = &now
+	teamPayment.RejectionReason = ""
+
+	err = savePaymentWithHistory(tx, p.PaymentRepository, teamPayment, fromStatus, fmt.Sprintf("paid via %s (%s)", charge.Provider, charge.Reference), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	teamStatus, err := teamVerificationStatus(tx, p.DocumentRepository, p.TeamRepository, team)
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateTeamStatus(tx, p.TeamRepository, team, teamStatus, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, err := p.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issueReceipt(tx, p.ReceiptRepository, p.CompetitionRepository, team, leader, teamPayment, "Payment gateway "+charge.Provider)
+}
+
+func toChargeResponse(charge *entity.PaymentCharge) *model.ChargeResponse {
+	return &model.ChargeResponse{
+		OrderID:     charge.OrderID,
+		Provider:    charge.Provider,
+		Amount:      charge.Amount,
+		Status:      charge.Status,
+		RedirectURL: charge.RedirectURL,
+		ExpiresAt:   charge.ExpiresAt,
+	}
+}
+
+// teamPaymentAmount mengembalikan nominal yang harus dibayar tim, harga yang sudah dikunci saat mendaftar diutamakan.
+func teamPaymentAmount(competition *entity.Competition, team *entity.Team) int64 {
+	if team.PricedAt != nil {
+		return team.AppliedPrice
+	}
+
+	return competition.RegistrationFee
+}
+
+// referenceCodeAttempts adalah batas percobaan membuat ulang kode referensi yang bentrok dengan kode tim lain.
+const referenceCodeAttempts = 5
+
+// getOrCreatePayment membuat data payment untuk tim yang bukti bayarnya masih tersimpan di user.
+func getOrCreatePayment(tx *gorm.DB, paymentRepository repository.IPaymentRepository, team *entity.Team, user *entity.User) (*entity.Payment, error) {
+	payment, err := paymentRepository.GetPaymentByTeamID(tx, team.TeamID)
+	if err == nil {
+		// tim yang pindah lomba sebelum membayar ikut memindahkan payment-nya
+		if payment.CompetitionID != team.CompetitionID {
+			payment.CompetitionID = team.CompetitionID
+			err = paymentRepository.UpdatePayment(tx, payment)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if payment.ReferenceCode == "" {
+			err = withReferenceCode(payment, func() error {
+				return paymentRepository.UpdatePayment(tx, payment)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return payment, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	payment = &entity.Payment{
+		PaymentID:     uuid.New(),
+		TeamID:        team.TeamID,
+		CompetitionID: team.CompetitionID,
+		ProofFile:     user.PaymentTransc,
+		Status:        team.TeamStatus,
+	}
+
+	err = withReferenceCode(payment, func() error {
+		return paymentRepository.CreatePayment(tx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// withReferenceCode memberi kode referensi baru lalu menyimpan payment, kode dibuat ulang jika bentrok dengan unique index.
+func withReferenceCode(p *entity.Payment, save func() error) error {
+	var err error
+	for i := 0; i < referenceCodeAttempts; i++ {
+		p.ReferenceCode, err = payment.NewReferenceCode()
+		if err != nil {
+			return err
+		}
+
+		err = save()
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func savePaymentWithHistory(tx *gorm.DB, paymentRepository repository.IPaymentRepository, payment *entity.Payment, fromStatus string, reason string, changedBy *uuid.UUID) error {
+	err := paymentRepository.UpdatePayment(tx, payment)
+	if err != nil {
+		return err
+	}
+
+	return paymentRepository.CreatePaymentHistory(tx, &entity.PaymentHistory{
+		PaymentID:  payment.PaymentID,
+		FromStatus: fromStatus,
+		ToStatus:   payment.Status,
+		Amount:     payment.Amount,
+		Channel:    payment.Channel,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+	})
+}
+
+func queuePaymentVerifiedEmail(tx *gorm.DB, outboxRepository repository.IEmailOutboxRepository, receipt *entity.Receipt) error {
+	return queueEmail(tx, outboxRepository, receipt.LeaderEmail, mail.TemplatePaymentVerified, mail.PaymentVerifiedData{
+		LeaderName:    receipt.LeaderName,
+		TeamName:      receipt.TeamName,
+		Amount:        formatRupiah(receipt.Amount),
+		ReceiptNumber: receipt.Number,
+	}, receiptAttachment(receipt))
+}
+
+func queuePaymentRejectedEmail(tx *gorm.DB, outboxRepository repository.IEmailOutboxRepository, user *entity.User, team *entity.Team, reason string) error {
+	return queueEmail(tx, outboxRepository, user.Email, mail.TemplatePaymentRejected, mail.PaymentRejectedData{
+		Name:     user.FullName,
+		TeamName: team.TeamName,
+		Reason:   reason,
+	})
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/mail"
+	"itfest-2025/pkg/pdf"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IReceiptService interface {
+	GetMyReceipt(userID uuid.UUID) (*model.ReceiptFile, error)
+	GetTeamReceipt(teamID uuid.UUID) (*model.ReceiptFile, error)
+}
+
+type ReceiptService struct {
+	db                *gorm.DB
+	ReceiptRepository repository.IReceiptRepository
+	TeamRepository    repository.ITeamRepository
+}
+
+const receiptSequenceName = "receipt"
+
+func NewReceiptService(receiptRepository repository.IReceiptRepository, teamRepository repository.ITeamRepository) IReceiptService {
+	return &ReceiptService{
+		db:                mariadb.Connection,
+		ReceiptRepository: receiptRepository,
+		TeamRepository:    teamRepository,
+	}
+}
+
+func (r *ReceiptService) GetMyReceipt(userID uuid.UUID) (*model.ReceiptFile, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	team, err := r.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getReceipt(tx, team.TeamID)
+}
+
+func (r *ReceiptService) GetTeamReceipt(teamID uuid.UUID) (*model.ReceiptFile, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	return r.getReceipt(tx, teamID)
+}
+
+func (r *ReceiptService) getReceipt(tx *gorm.DB, teamID uuid.UUID) (*model.ReceiptFile, error) {
+	receipt, err := r.ReceiptRepository.GetActiveReceiptByTeamID(tx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrReceiptNotFound
+		}
+		return nil, err
+	}
+
+	return &model.ReceiptFile{
+		FileName: receiptFileName(receipt),
+		Content:  renderReceipt(receipt),
+	}, nil
+}
+
+// issueReceipt menerbitkan kuitansi untuk pembayaran yang baru diverifikasi.
+// Kuitansi aktif yang sudah ada dipakai lagi agar nomor tidak terbuang.
+func issueReceipt(tx *gorm.DB, receiptRepository repository.IReceiptRepository, competitionRepository repository.ICompetitionRepository, team *entity.Team, leader *entity.User, payment *entity.Payment, verifierName string) (*entity.Receipt, error) {
+	receipt, err := receiptRepository.GetActiveReceiptByTeamID(tx, team.TeamID)
+	if err == nil {
+		return receipt, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	competition, err := competitionRepository.GetCompetitionByID(tx, team.CompetitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence, err := receiptRepository.NextSequence(tx, receiptSequenceName)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiedAt := time.Now()
+	if payment.VerifiedAt != nil {
+		verifiedAt = *payment.VerifiedAt
+	}
+
+	receipt = &entity.Receipt{
+		ReceiptID:       uuid.New(),
+		Number:          fmt.Sprintf("ITF/%d/%06d", verifiedAt.Year(), sequence),
+		Sequence:        sequence,
+		TeamID:          team.TeamID,
+		PaymentID:       payment.PaymentID,
+		TeamName:        team.TeamName,
+		LeaderName:      leader.FullName,
+		LeaderEmail:     leader.Email,
+		CompetitionName: competition.CompetitionName,
+		Amount:          payment.Amount,
+		Method:          payment.Method,
+		VerifiedAt:      verifiedAt,
+		VerifiedBy:      payment.VerifiedBy,
+		VerifierName:    verifierName,
+	}
+
+	err = receiptRepository.CreateReceipt(tx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func renderReceipt(receipt *entity.Receipt) []byte {
+	doc := pdf.New()
+
+	doc.Rect(0, pdf.PageHeight-110, pdf.PageWidth, 110, 0.92)
+	doc.Text(50, pdf.PageHeight-60, 22, pdf.Bold, "KUITANSI PEMBAYARAN")
+	doc.Text(50, pdf.PageHeight-85, 12, pdf.Regular, "IT FEST 2025")
+	doc.Text(360, pdf.PageHeight-60, 11, pdf.Bold, "No. "+receipt.Number)
+	doc.Text(360, pdf.PageHeight-85, 10, pdf.Regular, "Diterbitkan "+receipt.CreatedAt.Format("02/01/2006 15:04"))
+
+	rows := [][2]string{
+		{"Nama Tim", receipt.TeamName},
+		{"Ketua Tim", receipt.LeaderName},
+		{"Kompetisi", receipt.CompetitionName},
+		{"Metode Pembayaran", receipt.Method},
+		{"Tanggal Verifikasi", receipt.VerifiedAt.Format("02/01/2006 15:04")},
+		{"Diverifikasi Oleh", receipt.VerifierName},
+	}
+
+	y := pdf.PageHeight - 160
+	for _, v := range rows {
+		doc.Text(50, y, 11, pdf.Regular, v[0])
+		doc.Text(200, y, 11, pdf.Bold, ": "+v[1])
+		y -= 24
+	}
+
+	y -= 10
+	doc.Line(50, y, pdf.PageWidth-50, y, 1)
+	y -= 30
+	doc.Text(50, y, 14, pdf.Bold, "Jumlah Dibayar")
+	doc.Text(200, y, 14, pdf.Bold, ": "+formatRupiah(receipt.Amount))
+	y -= 20
+	doc.Line(50, y, pdf.PageWidth-50, y, 1)
+
+	doc.Text(50, 80, 9, pdf.Regular, "Kuitansi ini diterbitkan secara elektronik oleh panitia IT FEST 2025 dan sah tanpa tanda tangan.")
+	doc.Text(50, 66, 9, pdf.Regular, "Nomor kuitansi dapat dikonfirmasi ke panitia untuk keperluan administrasi kampus.")
+
+	return doc.Bytes()
+}
+
+func receiptFileName(receipt *entity.Receipt) string {
+	return "kuitansi-" + strings.ReplaceAll(receipt.Number, "/", "-") + ".pdf"
+}
+
+func receiptAttachment(receipt *entity.Receipt) mail.Attachment {
+	return mail.Attachment{
+		FileName:    receiptFileName(receipt),
+		ContentType: "application/pdf",
+		Content:     renderReceipt(receipt),
+	}
+}
+
+// formatRupiah memformat nominal dengan pemisah ribuan titik, misalnya Rp 150.000.
+func formatRupiah(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+
+	return "Rp " + b.String()
+}
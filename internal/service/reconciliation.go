@@ -0,0 +1,788 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+type IReconciliationService interface {
+	ImportStatement(actor *entity.User, file *multipart.FileHeader) (*model.ReconciliationResponse, error)
+	GetReconciliations() ([]model.ReconciliationResponse, error)
+	GetReconciliation(batchID uuid.UUID) (*model.ReconciliationResponse, error)
+	ConfirmReconciliation(actor *entity.User, batchID uuid.UUID, param model.ConfirmReconciliationRequest) (*model.ReconciliationResponse, error)
+}
+
+type ReconciliationService struct {
+	db                       *gorm.DB
+	ReconciliationRepository repository.IReconciliationRepository
+	PaymentRepository        repository.IPaymentRepository
+	TeamRepository           repository.ITeamRepository
+	UserRepository           repository.IUserRepository
+	CompetitionRepository    repository.ICompetitionRepository
+	ReceiptRepository        repository.IReceiptRepository
+	DocumentRepository       repository.IMemberDocumentRepository
+	OutboxRepository         repository.IEmailOutboxRepository
+}
+
+const (
+	maxStatementSize = 5 * 1024 * 1024
+	// reconciliationWindow adalah selisih waktu maksimal antara mutasi dan bukti bayar yang diunggah peserta.
+	reconciliationWindow = 48 * time.Hour
+	maxCandidates        = 10
+)
+
+func NewReconciliationService(reconciliationRepository repository.IReconciliationRepository, paymentRepository repository.IPaymentRepository, teamRepository repository.ITeamRepository, userRepository repository.IUserRepository, competitionRepository repository.ICompetitionRepository, receiptRepository repository.IReceiptRepository, documentRepository repository.IMemberDocumentRepository, outboxRepository repository.IEmailOutboxRepository) IReconciliationService {
+	return &ReconciliationService{
+		db:                       mariadb.Connection,
+		ReconciliationRepository: reconciliationRepository,
+		PaymentRepository:        paymentRepository,
+		TeamRepository:           teamRepository,
+		UserRepository:           userRepository,
+		CompetitionRepository:    competitionRepository,
+		ReceiptRepository:        receiptRepository,
+		DocumentRepository:       documentRepository,
+		OutboxRepository:         outboxRepository,
+	}
+}
+
+type statementEntry struct {
+	row         int
+	at          *time.Time
+	amount      int64
+	description string
+}
+
+type reconciliationCandidate struct {
+	team          entity.Team
+	referenceCode string
+	expected      int64
+	reported      int64
+	submittedAt   *time.Time
+}
+
+func (r *ReconciliationService) ImportStatement(actor *entity.User, file *multipart.FileHeader) (*model.ReconciliationResponse, error) {
+	if file.Size > maxStatementSize {
+		return nil, model.ErrStatementTooLarge
+	}
+
+	rows, err := readStatement(file)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseStatement(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	candidates, err := r.getCandidates(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := matchStatement(entries, candidates)
+	batch := &entity.ReconciliationBatch{
+		BatchID:    uuid.New(),
+		FileName:   filepath.Base(file.Filename),
+		Status:     "review",
+		TotalRows:  len(lines),
+		UploadedBy: actor.UserID,
+		Lines:      lines,
+	}
+	for _, v := range lines {
+		switch v.MatchStatus {
+		case "matched":
+			batch.MatchedRows++
+		case "ambiguous":
+			batch.AmbiguousRows++
+		}
+	}
+
+	err = r.ReconciliationRepository.CreateBatch(tx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.toReconciliationResponse(tx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *ReconciliationService) GetReconciliations() ([]model.ReconciliationResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	batches, err := r.ReconciliationRepository.GetBatches(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model.ReconciliationResponse, 0, len(batches))
+	for _, v := range batches {
+		res = append(res, toBatchResponse(v))
+	}
+
+	return res, nil
+}
+
+func (r *ReconciliationService) GetReconciliation(batchID uuid.UUID) (*model.ReconciliationResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	batch, err := r.ReconciliationRepository.GetBatchByID(tx, batchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrReconciliationNotFound
+		}
+		return nil, err
+	}
+
+	return r.toReconciliationResponse(tx, batch)
+}
+
+// ConfirmReconciliation memverifikasi pembayaran semua baris yang cocok, baris ambigu hanya diproses jika admin memilih timnya.
+func (r *ReconciliationService) ConfirmReconciliation(actor *entity.User, batchID uuid.UUID, param model.ConfirmReconciliationRequest) (*model.ReconciliationResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	batch, err := r.ReconciliationRepository.LockBatch(tx, batchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrReconciliationNotFound
+		}
+		return nil, err
+	}
+
+	if batch.Status == "confirmed" {
+		return nil, model.ErrReconciliationConfirmed
+	}
+
+	decisions := make(map[int]model.ReconciliationDecision)
+	for _, v := range param.Decisions {
+		decisions[v.LineID] = v
+	}
+	for lineID := range decisions {
+		found := false
+		for _, line := range batch.Lines {
+			if line.LineID == lineID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, model.ErrReconciliationLineNotFound
+		}
+	}
+
+	now := time.Now()
+	confirmedTeams := make(map[uuid.UUID]bool)
+	var receipts []*entity.Receipt
+	for i := range batch.Lines {
+		line := &batch.Lines[i]
+		if line.MatchStatus != "matched" && line.MatchStatus != "ambiguous" {
+			continue
+		}
+
+		decision, ok := decisions[line.LineID]
+		if ok && decision.Skip {
+			line.MatchStatus = "skipped"
+			err = r.ReconciliationRepository.UpdateLine(tx, line)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var teamID *uuid.UUID
+		if line.MatchStatus == "matched" {
+			teamID = line.TeamID
+		}
+		if ok && decision.TeamID != nil {
+			if !containsTeamID(lineTeamIDs(*line), *decision.TeamID) {
+				return nil, fmt.Errorf("row %d: %w", line.RowNumber, model.ErrReconciliationNotCandidate)
+			}
+			teamID = decision.TeamID
+		}
+		if teamID == nil {
+			continue
+		}
+
+		if confirmedTeams[*teamID] {
+			return nil, fmt.Errorf("row %d: %w", line.RowNumber, model.ErrReconciliationDuplicateTeam)
+		}
+		confirmedTeams[*teamID] = true
+
+		receipt, err := r.verifyLine(tx, actor, batch, line, *teamID, now)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", line.RowNumber, err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	batch.Status = "confirmed"
+	batch.ConfirmedRows = len(confirmedTeams)
+	batch.ConfirmedBy = &actor.UserID
+	batch.ConfirmedAt = &now
+
+	err = r.ReconciliationRepository.UpdateBatch(tx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.toReconciliationResponse(tx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range receipts {
+		err = queuePaymentVerifiedEmail(tx, r.OutboxRepository, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *ReconciliationService) verifyLine(tx *gorm.DB, actor *entity.User, batch *entity.ReconciliationBatch, line *entity.ReconciliationLine, teamID uuid.UUID, now time.Time) (*entity.Receipt, error) {
+	team, err := r.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	leader, err := r.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := getOrCreatePayment(tx, r.PaymentRepository, team, leader)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status == "terverifikasi" {
+		return nil, model.ErrPaymentAlreadyVerified
+	}
+
+	fromStatus := payment.Status
+	payment.Amount = line.Amount
+	payment.Channel = "transfer bank"
+	payment.Method = "manual"
+	payment.Status = "terverifikasi"
+	if payment.SubmittedAt == nil {
+		payment.SubmittedAt = line.TransactionAt
+	}
+	payment.VerifiedBy = &actor.UserID
+	payment.VerifiedAt = &now
+	payment.RejectionReason = ""
+
+	reason := fmt.Sprintf("rekonsiliasi mutasi %s baris %d", batch.FileName, line.RowNumber)
+	err = savePaymentWithHistory(tx, r.PaymentRepository, payment, fromStatus, reason, &actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamStatus, err := teamVerificationStatus(tx, r.DocumentRepository, r.TeamRepository, team)
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateTeamStatus(tx, r.TeamRepository, team, teamStatus, &actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	line.MatchStatus = "confirmed"
+	line.TeamID = &team.TeamID
+
+	err = r.ReconciliationRepository.UpdateLine(tx, line)
+	if err != nil {
+		return nil, err
+	}
+
+	return issueReceipt(tx, r.ReceiptRepository, r.CompetitionRepository, team, leader, payment, actor.FullName)
+}
+
+// getCandidates mengumpulkan tim yang belum lunas beserta nominal yang seharusnya dibayar.
+func (r *ReconciliationService) getCandidates(tx *gorm.DB) ([]reconciliationCandidate, error) {
+	teams, err := r.TeamRepository.GetUnpaidTeams(tx)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, nil
+	}
+
+	competitions, err := r.CompetitionRepository.GetAllCompetitionsWithStages(tx)
+	if err != nil {
+		return nil, err
+	}
+	fees := make(map[int]int64)
+	for _, v := range competitions {
+		fees[v.CompetitionID] = v.RegistrationFee
+	}
+
+	teamIDs := make([]uuid.UUID, 0, len(teams))
+	for _, v := range teams {
+		teamIDs = append(teamIDs, v.TeamID)
+	}
+
+	payments, err := r.PaymentRepository.GetPaymentsByTeamIDs(tx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+	paymentByTeam := make(map[uuid.UUID]entity.Payment)
+	for _, v := range payments {
+		paymentByTeam[v.TeamID] = v
+	}
+
+	var candidates []reconciliationCandidate
+	for _, v := range teams {
+		candidate := reconciliationCandidate{
+			team:     v,
+			expected: fees[v.CompetitionID],
+		}
+		if v.PricedAt != nil {
+			candidate.expected = v.AppliedPrice
+		}
+		if payment, ok := paymentByTeam[v.TeamID]; ok {
+			candidate.referenceCode = payment.ReferenceCode
+			candidate.reported = payment.Amount
+			candidate.submittedAt = payment.SubmittedAt
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// matchStatement mencocokkan setiap mutasi dengan tim berdasarkan kode unik, nominal, dan waktu transfer.
+// Baris dianggap cocok jika kode unik dan nominal sesuai, atau nominal sesuai dan hanya satu tim
+// yang mengunggah bukti bayar dengan nominal yang sama di sekitar waktu transfer.
+func matchStatement(entries []statementEntry, candidates []reconciliationCandidate) []entity.ReconciliationLine {
+	lines := make([]entity.ReconciliationLine, 0, len(entries))
+	matchedBy := make(map[uuid.UUID][]int)
+
+	for _, entry := range entries {
+		line := entity.ReconciliationLine{
+			RowNumber:     entry.row,
+			TransactionAt: entry.at,
+			Amount:        entry.amount,
+			Description:   entry.description,
+			MatchStatus:   "unmatched",
+		}
+
+		description := strings.ToUpper(entry.description)
+		var codeHits, amountHits, timeHits []reconciliationCandidate
+		for _, v := range candidates {
+			if v.referenceCode != "" && strings.Contains(description, v.referenceCode) {
+				codeHits = append(codeHits, v)
+			}
+			if v.expected > 0 && v.expected == entry.amount {
+				amountHits = append(amountHits, v)
+				if v.reported == entry.amount && withinWindow(v.submittedAt, entry.at) {
+					timeHits = append(timeHits, v)
+				}
+			}
+		}
+
+		switch {
+		case len(codeHits) == 1 && codeHits[0].expected == entry.amount:
+			setLineMatch(&line, codeHits[0], "reference code and amount")
+		case len(codeHits) == 1:
+			setLineCandidates(&line, codeHits, "reference code matches but amount differs")
+		case len(codeHits) > 1:
+			setLineCandidates(&line, codeHits, "multiple reference codes")
+		case len(timeHits) == 1:
+			setLineMatch(&line, timeHits[0], "amount and transfer time")
+		case len(timeHits) > 1:
+			setLineCandidates(&line, timeHits, "multiple teams with same amount and time")
+		case len(amountHits) > 0:
+			setLineCandidates(&line, amountHits, "amount only")
+		}
+
+		if line.TeamID != nil {
+			matchedBy[*line.TeamID] = append(matchedBy[*line.TeamID], len(lines))
+		}
+		lines = append(lines, line)
+	}
+
+	// satu tim yang cocok dengan beberapa mutasi harus ditinjau manual
+	for _, indexes := range matchedBy {
+		if len(indexes) < 2 {
+			continue
+		}
+		for _, i := range indexes {
+			lines[i].MatchStatus = "ambiguous"
+			lines[i].MatchReason = "team matches multiple transactions"
+			lines[i].Candidates = lines[i].TeamID.String()
+			lines[i].TeamID = nil
+		}
+	}
+
+	return lines
+}
+
+func setLineMatch(line *entity.ReconciliationLine, candidate reconciliationCandidate, reason string) {
+	teamID := candidate.team.TeamID
+	line.MatchStatus = "matched"
+	line.MatchReason = reason
+	line.TeamID = &teamID
+}
+
+func setLineCandidates(line *entity.ReconciliationLine, candidates []reconciliationCandidate, reason string) {
+	var ids []string
+	for i, v := range candidates {
+		if i == maxCandidates {
+			break
+		}
+		ids = append(ids, v.team.TeamID.String())
+	}
+
+	line.MatchStatus = "ambiguous"
+	line.MatchReason = reason
+	line.Candidates = strings.Join(ids, ",")
+}
+
+func withinWindow(submittedAt *time.Time, transactionAt *time.Time) bool {
+	if submittedAt == nil || transactionAt == nil {
+		return false
+	}
+
+	diff := submittedAt.Sub(*transactionAt)
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff <= reconciliationWindow
+}
+
+func (r *ReconciliationService) toReconciliationResponse(tx *gorm.DB, batch *entity.ReconciliationBatch) (*model.ReconciliationResponse, error) {
+	res := toBatchResponse(*batch)
+
+	var teamIDs []uuid.UUID
+	for _, line := range batch.Lines {
+		teamIDs = append(teamIDs, lineTeamIDs(line)...)
+	}
+
+	teams := make(map[uuid.UUID]model.ReconciliationTeamResponse)
+	if len(teamIDs) > 0 {
+		found, err := r.TeamRepository.GetTeamsByIDs(tx, teamIDs)
+		if err != nil {
+			return nil, err
+		}
+		payments, err := r.PaymentRepository.GetPaymentsByTeamIDs(tx, teamIDs)
+		if err != nil {
+			return nil, err
+		}
+		codes := make(map[uuid.UUID]string)
+		for _, v := range payments {
+			codes[v.TeamID] = v.ReferenceCode
+		}
+		for _, v := range found {
+			teams[v.TeamID] = model.ReconciliationTeamResponse{
+				TeamID:        v.TeamID,
+				TeamName:      v.TeamName,
+				ReferenceCode: codes[v.TeamID],
+				TeamStatus:    v.TeamStatus,
+			}
+		}
+	}
+
+	res.Lines = []model.ReconciliationLineResponse{}
+	for _, line := range batch.Lines {
+		lineRes := model.ReconciliationLineResponse{
+			LineID:        line.LineID,
+			RowNumber:     line.RowNumber,
+			TransactionAt: line.TransactionAt,
+			Amount:        line.Amount,
+			Description:   line.Description,
+			MatchStatus:   line.MatchStatus,
+			MatchReason:   line.MatchReason,
+			Candidates:    []model.ReconciliationTeamResponse{},
+		}
+		if line.TeamID != nil {
+			if team, ok := teams[*line.TeamID]; ok {
+				lineRes.Team = &team
+			}
+		}
+		for _, id := range splitCandidates(line.Candidates) {
+			if team, ok := teams[id]; ok {
+				lineRes.Candidates = append(lineRes.Candidates, team)
+			}
+		}
+		res.Lines = append(res.Lines, lineRes)
+	}
+
+	return &res, nil
+}
+
+func toBatchResponse(batch entity.ReconciliationBatch) model.ReconciliationResponse {
+	return model.ReconciliationResponse{
+		BatchID:       batch.BatchID,
+		FileName:      batch.FileName,
+		Status:        batch.Status,
+		TotalRows:     batch.TotalRows,
+		MatchedRows:   batch.MatchedRows,
+		AmbiguousRows: batch.AmbiguousRows,
+		ConfirmedRows: batch.ConfirmedRows,
+		UploadedBy:    batch.UploadedBy,
+		ConfirmedBy:   batch.ConfirmedBy,
+		ConfirmedAt:   batch.ConfirmedAt,
+		CreatedAt:     batch.CreatedAt,
+	}
+}
+
+func lineTeamIDs(line entity.ReconciliationLine) []uuid.UUID {
+	ids := splitCandidates(line.Candidates)
+	if line.TeamID != nil {
+		ids = append(ids, *line.TeamID)
+	}
+
+	return ids
+}
+
+func containsTeamID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitCandidates(candidates string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, v := range strings.Split(candidates, ",") {
+		id, err := uuid.Parse(v)
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// readStatement membaca isi mutasi rekening dari file CSV atau sheet pertama XLSX.
+func readStatement(file *multipart.FileHeader) ([][]string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".csv":
+		content, err := io.ReadAll(src)
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+		firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+			reader.Comma = ';'
+		}
+
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, model.ErrStatementFormat
+		}
+		return rows, nil
+	case ".xlsx":
+		f, err := excelize.OpenReader(src)
+		if err != nil {
+			return nil, model.ErrStatementFormat
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, model.ErrStatementEmpty
+		}
+		return f.GetRows(sheets[0])
+	}
+
+	return nil, model.ErrStatementFormat
+}
+
+var (
+	statementDateHeaders        = []string{"tanggal", "tgl", "date", "tanggal transaksi", "tgl transaksi", "transaction date", "posting date", "waktu"}
+	statementDescriptionHeaders = []string{"keterangan", "deskripsi", "description", "berita", "berita transfer", "remark", "remarks", "uraian", "catatan"}
+	statementCreditHeaders      = []string{"kredit", "credit", "cr"}
+	statementAmountHeaders      = []string{"amount", "jumlah", "nominal", "mutasi"}
+	statementTypeHeaders        = []string{"tipe", "type", "jenis", "db/cr", "d/k"}
+	statementTimeLayouts        = []string{
+		"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", time.RFC3339,
+		"02/01/2006 15:04:05", "02/01/2006 15:04", "02/01/2006",
+		"02-01-2006 15:04:05", "02-01-2006 15:04", "02-01-2006",
+		"02/01/06", "2 Jan 2006", "02 Jan 2006 15:04",
+	}
+)
+
+// parseStatement mencari baris header lalu mengambil semua transaksi kredit di bawahnya.
+func parseStatement(rows [][]string) ([]statementEntry, error) {
+	headerRow := -1
+	dateCol, descriptionCol, creditCol, amountCol, typeCol := -1, -1, -1, -1, -1
+	for i := 0; i < len(rows) && i < 10 && headerRow < 0; i++ {
+		for j, cell := range rows[i] {
+			cell = strings.ToLower(strings.TrimSpace(cell))
+			switch {
+			case dateCol < 0 && contains(statementDateHeaders, cell):
+				dateCol = j
+			case descriptionCol < 0 && contains(statementDescriptionHeaders, cell):
+				descriptionCol = j
+			case creditCol < 0 && contains(statementCreditHeaders, cell):
+				creditCol = j
+			case amountCol < 0 && contains(statementAmountHeaders, cell):
+				amountCol = j
+			case typeCol < 0 && contains(statementTypeHeaders, cell):
+				typeCol = j
+			}
+		}
+		if creditCol >= 0 || amountCol >= 0 {
+			headerRow = i
+		} else {
+			dateCol, descriptionCol, typeCol = -1, -1, -1
+		}
+	}
+
+	if headerRow < 0 {
+		return nil, model.ErrStatementHeader
+	}
+	if creditCol >= 0 {
+		amountCol = creditCol
+	}
+
+	var entries []statementEntry
+	for i := headerRow + 1; i < len(rows); i++ {
+		row := rows[i]
+		value := cellAt(row, amountCol)
+		if creditCol < 0 && (isDebit(cellAt(row, typeCol)) || isDebit(value)) {
+			continue
+		}
+
+		amount, ok := parseStatementAmount(value)
+		if !ok || amount <= 0 {
+			continue
+		}
+
+		entry := statementEntry{
+			row:         i + 1,
+			amount:      amount,
+			description: cellAt(row, descriptionCol),
+		}
+		if at, ok := parseStatementTime(cellAt(row, dateCol)); ok {
+			entry.at = &at
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, model.ErrStatementEmpty
+	}
+
+	return entries, nil
+}
+
+// parseStatementAmount menerima format 150000, 150.000, 150,000.00 maupun 150.000,00.
+func parseStatementAmount(value string) (int64, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for _, v := range []string{"RP", "IDR", "CR", " "} {
+		value = strings.ReplaceAll(value, v, "")
+	}
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "(") {
+		return 0, false
+	}
+
+	separator := strings.LastIndexAny(value, ".,")
+	if separator >= 0 {
+		decimals := len(value) - separator - 1
+		if decimals == 1 || decimals == 2 {
+			value = value[:separator]
+		}
+	}
+
+	var digits strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	if digits.Len() == 0 {
+		return 0, false
+	}
+
+	amount, err := strconv.ParseInt(digits.String(), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return amount, true
+}
+
+func parseStatementTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range statementTimeLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func isDebit(value string) bool {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	return value == "D" || value == "DB" || value == "DEBIT" || strings.HasSuffix(value, " DB")
+}
+
+func cellAt(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[col])
+}
@@ -0,0 +1,538 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IRegistrationFormService interface {
+	GetCompetitionForm(competitionID int) ([]model.FormFieldResponse, error)
+	UpdateCompetitionForm(competitionID int, param model.UpdateRegistrationFormRequest) ([]model.FormFieldResponse, error)
+	GetMyRegistrationForm(userID uuid.UUID, competitionID int) (*model.RegistrationFormResponse, error)
+	UploadFormFile(userID uuid.UUID, competitionID int, fieldKey string, file *multipart.FileHeader) (*model.FormAnswerResponse, error)
+}
+
+type RegistrationFormService struct {
+	db                    *gorm.DB
+	FormRepository        repository.IRegistrationFormRepository
+	TeamRepository        repository.ITeamRepository
+	CompetitionRepository repository.ICompetitionRepository
+	Storage               storage.Interface
+}
+
+func NewRegistrationFormService(formRepository repository.IRegistrationFormRepository, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository, storage storage.Interface) IRegistrationFormService {
+	return &RegistrationFormService{
+		db:                    mariadb.Connection,
+		FormRepository:        formRepository,
+		TeamRepository:        teamRepository,
+		CompetitionRepository: competitionRepository,
+		Storage:               storage,
+	}
+}
+
+func (r *RegistrationFormService) GetCompetitionForm(competitionID int) ([]model.FormFieldResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	_, err := r.getCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := r.FormRepository.GetFieldsByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFormFieldResponses(fields), nil
+}
+
+// UpdateCompetitionForm mengganti seluruh skema form lomba, isian dicocokkan berdasarkan key
+// sehingga jawaban tim untuk isian yang tetap ada tidak hilang.
+func (r *RegistrationFormService) UpdateCompetitionForm(competitionID int, param model.UpdateRegistrationFormRequest) ([]model.FormFieldResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	competition, err := r.getCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if competition.IsArchived {
+		return nil, model.ErrCompetitionArchived
+	}
+
+	existing, err := r.FormRepository.GetFieldsByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingMap := make(map[string]entity.RegistrationField)
+	for _, v := range existing {
+		existingMap[v.FieldKey] = v
+	}
+
+	var fields []entity.RegistrationField
+	seen := make(map[string]bool)
+	for i, v := range param.Fields {
+		key := normalizeFieldKey(v.Key)
+		if seen[key] {
+			return nil, model.ErrDuplicateFieldKey
+		}
+		seen[key] = true
+
+		field, ok := existingMap[key]
+		if !ok {
+			field = entity.RegistrationField{
+				CompetitionID: competitionID,
+				FieldKey:      key,
+			}
+		}
+
+		field.Label = strings.TrimSpace(v.Label)
+		field.FieldType = v.Type
+		field.Required = v.Required
+		field.FieldOrder = i + 1
+		field.Options = ""
+		field.MaxLength = 0
+		field.Pattern = ""
+		field.AllowedMimeTypes = ""
+		field.MaxFileSize = 0
+
+		switch v.Type {
+		case entity.FieldText:
+			field.MaxLength = v.MaxLength
+			field.Pattern = v.Pattern
+		case entity.FieldSelect:
+			field.Options = joinLines(v.Options)
+		case entity.FieldFile:
+			field.AllowedMimeTypes = joinMimeTypes(v.AllowedMimeTypes)
+			field.MaxFileSize = v.MaxFileSize
+		}
+
+		err = validateFormField(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			err = r.FormRepository.UpdateField(tx, &field)
+		} else {
+			err = r.FormRepository.CreateField(tx, &field)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	var removedIDs []int
+	for _, v := range existing {
+		if !seen[v.FieldKey] {
+			removedIDs = append(removedIDs, v.FieldID)
+		}
+	}
+
+	removedAnswers, err := r.FormRepository.DeleteFields(tx, removedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range removedAnswers {
+		if v.Field.FieldType == entity.FieldFile {
+			deleteStoredFile(r.Storage, v.Value)
+		}
+	}
+
+	return toFormFieldResponses(fields), nil
+}
+
+func (r *RegistrationFormService) GetMyRegistrationForm(userID uuid.UUID, competitionID int) (*model.RegistrationFormResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	_, err := r.getCompetition(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := r.FormRepository.GetFieldsByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.RegistrationFormResponse{
+		CompetitionID: competitionID,
+		Fields:        toFormFieldResponses(fields),
+		Answers:       []model.FormAnswerResponse{},
+	}
+
+	team, err := r.TeamRepository.GetTeamForUser(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response, nil
+		}
+		return nil, err
+	}
+
+	answers, err := r.FormRepository.GetAnswersByTeamID(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range answers {
+		if v.Field.CompetitionID != competitionID {
+			continue
+		}
+
+		answer, err := r.toFormAnswerResponse(v)
+		if err != nil {
+			return nil, err
+		}
+		response.Answers = append(response.Answers, answer)
+	}
+
+	return response, nil
+}
+
+// UploadFormFile mengunggah file untuk isian bertipe file, jawaban lama beserta file-nya diganti.
+func (r *RegistrationFormService) UploadFormFile(userID uuid.UUID, competitionID int, fieldKey string, file *multipart.FileHeader) (*model.FormAnswerResponse, error) {
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	team, err := r.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotTeamLeader
+		}
+		return nil, err
+	}
+
+	err = ensureTeamEditable(team)
+	if err != nil {
+		return nil, err
+	}
+
+	// file diunggah sebelum tim mendaftar, jadi tim yang belum terdaftar di lomba mana pun tetap boleh mengisi
+	if team.CompetitionID != competitionID && team.CompetitionID != entity.UnassignedCompetitionID {
+		return nil, model.ErrFormTeamMismatch
+	}
+
+	_, err = getRegistrableCompetition(tx, r.CompetitionRepository, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := r.FormRepository.GetFieldsByCompetitionID(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var field *entity.RegistrationField
+	for i := range fields {
+		if fields[i].FieldKey == normalizeFieldKey(fieldKey) {
+			field = &fields[i]
+			break
+		}
+	}
+	if field == nil {
+		return nil, model.ErrFormFieldNotFound
+	}
+
+	if field.FieldType != entity.FieldFile {
+		return nil, model.ErrFormFieldNotAFile
+	}
+
+	if file.Size > fieldFileSize(*field) {
+		return nil, model.ErrFormFileTooLarge
+	}
+
+	contentType, err := model.GetImageType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType = strings.Split(contentType, ";")[0]
+	if !contains(fieldMimeTypes(*field), contentType) {
+		return nil, model.ErrFormFileNotAllowed
+	}
+
+	answers, err := r.FormRepository.GetAnswersByTeamID(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldKey := ""
+	for _, v := range answers {
+		if v.FieldID == field.FieldID {
+			oldKey = v.Value
+		}
+	}
+
+	key, err := r.Storage.Upload(file, fmt.Sprintf("forms/%s/%d", team.TeamID, competitionID), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := &entity.RegistrationAnswer{
+		TeamID:   team.TeamID,
+		FieldID:  field.FieldID,
+		Value:    key,
+		FileName: filepath.Base(file.Filename),
+		Field:    *field,
+	}
+
+	err = r.FormRepository.SaveAnswer(tx, answer)
+	if err != nil {
+		deleteStoredFile(r.Storage, key)
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		deleteStoredFile(r.Storage, key)
+		return nil, err
+	}
+
+	if oldKey != "" && oldKey != key {
+		deleteStoredFile(r.Storage, oldKey)
+	}
+
+	res, err := r.toFormAnswerResponse(*answer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *RegistrationFormService) getCompetition(tx *gorm.DB, competitionID int) (*entity.Competition, error) {
+	competition, err := r.CompetitionRepository.GetCompetitionByID(tx, competitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+
+	return competition, nil
+}
+
+func (r *RegistrationFormService) toFormAnswerResponse(answer entity.RegistrationAnswer) (model.FormAnswerResponse, error) {
+	value := answer.Value
+	if answer.Field.FieldType == entity.FieldFile {
+		signed, err := signedFileURL(r.Storage, value)
+		if err != nil {
+			return model.FormAnswerResponse{}, err
+		}
+		value = signed
+	}
+
+	return model.FormAnswerResponse{
+		Key:      answer.Field.FieldKey,
+		Label:    answer.Field.Label,
+		Type:     answer.Field.FieldType,
+		Value:    value,
+		FileName: answer.FileName,
+	}, nil
+}
+
+// saveRegistrationAnswers memvalidasi jawaban form lomba lalu menyimpannya untuk tim.
+// Isian bertipe file diunggah lewat endpoint terpisah, di sini hanya dicek keberadaannya.
+func saveRegistrationAnswers(tx *gorm.DB, formRepository repository.IRegistrationFormRepository, teamID uuid.UUID, competitionID int, answers map[string]string) error {
+	fields, err := formRepository.GetFieldsByCompetitionID(tx, competitionID)
+	if err != nil {
+		return err
+	}
+
+	fieldMap := make(map[string]entity.RegistrationField)
+	for _, v := range fields {
+		fieldMap[v.FieldKey] = v
+	}
+
+	values := make(map[string]string)
+	for key, value := range answers {
+		key = normalizeFieldKey(key)
+		field, ok := fieldMap[key]
+		if !ok {
+			return fmt.Errorf("%w: %s", model.ErrUnknownFormField, key)
+		}
+		if field.FieldType == entity.FieldFile {
+			return fmt.Errorf("%w: %s must be uploaded as a file", model.ErrInvalidFormAnswer, field.Label)
+		}
+
+		values[key] = strings.TrimSpace(value)
+	}
+
+	existing, err := formRepository.GetAnswersByTeamID(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	existingMap := make(map[int]entity.RegistrationAnswer)
+	for _, v := range existing {
+		existingMap[v.FieldID] = v
+	}
+
+	for _, field := range fields {
+		previous, answered := existingMap[field.FieldID]
+
+		if field.FieldType == entity.FieldFile {
+			if field.Required && (!answered || previous.Value == "") {
+				return fmt.Errorf("%w: %s is required", model.ErrInvalidFormAnswer, field.Label)
+			}
+			continue
+		}
+
+		value := values[field.FieldKey]
+		err = validateFormAnswer(field, value)
+		if err != nil {
+			return err
+		}
+
+		if value == "" && !answered {
+			continue
+		}
+
+		err = formRepository.SaveAnswer(tx, &entity.RegistrationAnswer{
+			TeamID:  teamID,
+			FieldID: field.FieldID,
+			Value:   value,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateFormField memastikan aturan isian form yang dibuat admin dapat dipakai untuk validasi.
+func validateFormField(field entity.RegistrationField) error {
+	if field.FieldKey == "" || field.Label == "" {
+		return fmt.Errorf("%w: key and label are required", model.ErrInvalidFormField)
+	}
+
+	if field.FieldType == entity.FieldSelect && field.Options == "" {
+		return fmt.Errorf("%w: %s needs at least one option", model.ErrInvalidFormField, field.Label)
+	}
+
+	if field.Pattern != "" {
+		_, err := regexp.Compile(field.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %s has an invalid pattern", model.ErrInvalidFormField, field.Label)
+		}
+	}
+
+	return nil
+}
+
+func validateFormAnswer(field entity.RegistrationField, value string) error {
+	if value == "" {
+		if field.Required {
+			return fmt.Errorf("%w: %s is required", model.ErrInvalidFormAnswer, field.Label)
+		}
+		return nil
+	}
+
+	switch field.FieldType {
+	case entity.FieldText:
+		if field.MaxLength > 0 && utf8.RuneCountInString(value) > field.MaxLength {
+			return fmt.Errorf("%w: %s must be at most %d characters", model.ErrInvalidFormAnswer, field.Label, field.MaxLength)
+		}
+		if field.Pattern != "" {
+			matched, err := regexp.MatchString(field.Pattern, value)
+			if err != nil || !matched {
+				return fmt.Errorf("%w: %s has an invalid format", model.ErrInvalidFormAnswer, field.Label)
+			}
+		}
+	case entity.FieldSelect:
+		if !contains(splitLines(field.Options), value) {
+			return fmt.Errorf("%w: %s must be one of the available options", model.ErrInvalidFormAnswer, field.Label)
+		}
+	case entity.FieldURL:
+		parsed, err := url.ParseRequestURI(value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: %s must be a valid URL", model.ErrInvalidFormAnswer, field.Label)
+		}
+	}
+
+	return nil
+}
+
+func normalizeFieldKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// fieldMimeTypes mengembalikan tipe file yang boleh diunggah pada isian, atau tipe default submission jika belum diatur.
+func fieldMimeTypes(field entity.RegistrationField) []string {
+	mimeTypes := field.AllowedMimeTypes
+	if mimeTypes == "" {
+		mimeTypes = entity.DefaultSubmissionMimeTypes
+	}
+
+	return strings.Split(mimeTypes, ",")
+}
+
+func fieldFileSize(field entity.RegistrationField) int64 {
+	if field.MaxFileSize <= 0 {
+		return entity.DefaultSubmissionFileSize
+	}
+
+	return field.MaxFileSize
+}
+
+// formAnswerText menyiapkan jawaban form untuk ditulis ke export, file diganti dengan link unduhan.
+func formAnswerText(s storage.Interface, answer entity.RegistrationAnswer) (string, error) {
+	if answer.Field.FieldType == entity.FieldFile {
+		return storage.URL(s, answer.Value, exportURLExpiry)
+	}
+
+	return answer.Value, nil
+}
+
+func toFormFieldResponses(fields []entity.RegistrationField) []model.FormFieldResponse {
+	response := []model.FormFieldResponse{}
+	for _, v := range fields {
+		var mimeTypes []string
+		if v.FieldType == entity.FieldFile {
+			mimeTypes = fieldMimeTypes(v)
+		}
+
+		response = append(response, model.FormFieldResponse{
+			FieldID:          v.FieldID,
+			Key:              v.FieldKey,
+			Label:            v.Label,
+			Type:             v.FieldType,
+			Required:         v.Required,
+			Options:          splitLines(v.Options),
+			MaxLength:        v.MaxLength,
+			Pattern:          v.Pattern,
+			AllowedMimeTypes: mimeTypes,
+			MaxFileSize:      v.MaxFileSize,
+			Order:            v.FieldOrder,
+		})
+	}
+
+	return response
+}
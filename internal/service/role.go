@@ -0,0 +1,270 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IRoleService interface {
+	GetRoles() ([]*model.RoleResponse, error)
+	GetUserPermissions(user *entity.User) (map[string]bool, error)
+	HasPermission(user *entity.User, permissions ...string) (bool, error)
+	GrantRole(actor *entity.User, userID uuid.UUID, param model.GrantRoleRequest) (*model.UserRolesResponse, error)
+	RevokeRole(actor *entity.User, userID uuid.UUID, roleID int) (*model.UserRolesResponse, error)
+}
+
+type RoleService struct {
+	db             *gorm.DB
+	RoleRepository repository.IRoleRepository
+	UserRepository repository.IUserRepository
+}
+
+func NewRoleService(roleRepository repository.IRoleRepository, userRepository repository.IUserRepository) IRoleService {
+	return &RoleService{
+		db:             mariadb.Connection,
+		RoleRepository: roleRepository,
+		UserRepository: userRepository,
+	}
+}
+
+func (r *RoleService) GetRoles() ([]*model.RoleResponse, error) {
+	roles, err := r.RoleRepository.GetRoles(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []*model.RoleResponse
+	for _, v := range roles {
+		permissions := []string{}
+		for _, p := range v.Permissions {
+			permissions = append(permissions, p.Name)
+		}
+
+		response = append(response, &model.RoleResponse{
+			RoleID:      v.RoleID,
+			RoleName:    v.RoleName,
+			Permissions: permissions,
+		})
+	}
+
+	return response, nil
+}
+
+// GetUserPermissions menggabungkan permission dari role utama user dan role tambahan di user_roles.
+func (r *RoleService) GetUserPermissions(user *entity.User) (map[string]bool, error) {
+	_, permissions, err := r.getUserRolePermissions(r.db, user.UserID, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool)
+	for _, v := range permissions {
+		result[v] = true
+	}
+
+	return result, nil
+}
+
+func (r *RoleService) HasPermission(user *entity.User, permissions ...string) (bool, error) {
+	owned, err := r.GetUserPermissions(user)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range permissions {
+		if owned[v] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *RoleService) GrantRole(actor *entity.User, userID uuid.UUID, param model.GrantRoleRequest) (*model.UserRolesResponse, error) {
+	if param.RoleID == entity.RoleParticipant {
+		return nil, model.ErrParticipantRoleChange
+	}
+
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	_, err := r.RoleRepository.GetRoleByID(tx, param.RoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrRoleNotFound
+		}
+		return nil, err
+	}
+
+	user, err := r.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// role utama peserta tidak diganti karena data tim dan penerima pengumuman masih memfilter role_id peserta,
+	// role tambahan cukup disimpan di user_roles
+	err = r.RoleRepository.GrantRole(tx, userID, param.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.getUserRolesResponse(tx, userID, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (r *RoleService) RevokeRole(actor *entity.User, userID uuid.UUID, roleID int) (*model.UserRolesResponse, error) {
+	if roleID == entity.RoleParticipant {
+		return nil, model.ErrParticipantRoleChange
+	}
+
+	tx := r.db.Begin()
+	defer tx.Rollback()
+
+	user, err := r.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.RoleRepository.RevokeRole(tx, userID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// role utama yang dicabut diganti dengan role tambahan yang tersisa, atau kembali menjadi peserta
+	if user.RoleID == roleID {
+		remaining, err := r.RoleRepository.GetUserRoleIDs(tx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		user.RoleID = entity.RoleParticipant
+		if len(remaining) > 0 {
+			user.RoleID = remaining[0]
+		}
+
+		err = r.RoleRepository.UpdateUserPrimaryRole(tx, userID, user.RoleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := r.getUserRolesResponse(tx, userID, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.UserID == userID && !contains(response.Permissions, entity.PermissionRolesManage) {
+		return nil, model.ErrSelfRoleRevoke
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (r *RoleService) getUserRolePermissions(tx *gorm.DB, userID uuid.UUID, primaryRoleID int) ([]int, []string, error) {
+	roleIDs, err := r.RoleRepository.GetUserRoleIDs(tx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !containsInt(roleIDs, primaryRoleID) {
+		roleIDs = append([]int{primaryRoleID}, roleIDs...)
+	}
+
+	permissions, err := r.RoleRepository.GetPermissionsByRoleIDs(tx, roleIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return roleIDs, permissions, nil
+}
+
+func (r *RoleService) getUserRolesResponse(tx *gorm.DB, userID uuid.UUID, primaryRoleID int) (*model.UserRolesResponse, error) {
+	roleIDs, permissions, err := r.getUserRolePermissions(tx, userID, primaryRoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserRolesResponse{
+		UserID:      userID,
+		RoleIDs:     roleIDs,
+		Permissions: permissions,
+	}, nil
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsInt(values []int, target int) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinLines menyimpan daftar nilai sebagai teks satu nilai per baris, nilai kosong dibuang.
+func joinLines(values []string) string {
+	var cleaned []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			cleaned = append(cleaned, v)
+		}
+	}
+
+	return strings.Join(cleaned, "\n")
+}
+
+func splitLines(values string) []string {
+	if values == "" {
+		return nil
+	}
+
+	return strings.Split(values, "\n")
+}
+
+// envInt membaca konfigurasi angka positif dari environment, nilai kosong atau tidak valid memakai fallback.
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
@@ -4,30 +4,70 @@ import (
 	"itfest-2025/internal/repository"
 	"itfest-2025/pkg/bcrypt"
 	"itfest-2025/pkg/jwt"
-	"itfest-2025/pkg/supabase"
+	"itfest-2025/pkg/mail"
+	"itfest-2025/pkg/payment"
+	"itfest-2025/pkg/storage"
 )
 
 type Service struct {
-	UserService         IUserService
-	TeamService         ITeamService
-	OtpService          IOtpService
-	CompetitionService  ICompetitionService
-	SubmissionService   ISubmissionService
-	ExcelService        IExcelService
-	CountService        ICountService
-	AnnouncementService IAnnouncementService
+	UserService           IUserService
+	TeamService           ITeamService
+	OtpService            IOtpService
+	CompetitionService    ICompetitionService
+	SubmissionService     ISubmissionService
+	ExcelService          IExcelService
+	CountService          ICountService
+	AnnouncementService   IAnnouncementService
+	AuthService           IAuthService
+	RoleService           IRoleService
+	JudgingService        IJudgingService
+	FileService           IFileService
+	PaymentService        IPaymentService
+	VoucherService        IVoucherService
+	ReconciliationService IReconciliationService
+	ReceiptService        IReceiptService
+	InvitationService     ITeamInvitationService
+	DocumentService       IMemberDocumentService
+	DuplicateService      IDuplicateService
+	LifecycleService      ITeamLifecycleService
+	LeadershipService     ITeamLeadershipService
+	WaitlistService       IWaitlistService
+	FormService           IRegistrationFormService
+	EmailTemplateService  IEmailTemplateService
+	OutboxService         IEmailOutboxService
 }
 
-func NewService(repository *repository.Repository, bcrypt bcrypt.Interface, jwtAuth jwt.Interface, supabase supabase.Interface) *Service {
-	teamService := NewTeamService(repository.UserRepository, repository.TeamRepository, repository.CompetitionRepository, repository.SubmissionRepository)
+func NewService(repository *repository.Repository, bcrypt bcrypt.Interface, jwtAuth jwt.Interface, storage storage.Interface, paymentProvider payment.Interface, mailer mail.Interface) *Service {
+	authService := NewAuthService(repository.UserRepository, repository.RefreshTokenRepository, jwtAuth)
+	roleService := NewRoleService(repository.RoleRepository, repository.UserRepository)
+	voucherService := NewVoucherService(repository.VoucherRepository, repository.UserRepository, repository.TeamRepository, repository.CompetitionRepository)
+	waitlistService := NewWaitlistService(repository.WaitlistRepository, repository.TeamRepository, repository.UserRepository, repository.CompetitionRepository, voucherService, repository.OutboxRepository, repository.PaymentRepository)
+	teamService := NewTeamService(repository.UserRepository, repository.TeamRepository, repository.CompetitionRepository, repository.SubmissionRepository, repository.TeamInvitationRepository, repository.MemberDocumentRepository, repository.PaymentRepository, storage)
 	return &Service{
-		UserService:         NewUserService(repository.UserRepository, repository.TeamRepository, repository.OtpRepository, repository.CompetitionRepository, bcrypt, jwtAuth, supabase, teamService),
-		TeamService:         teamService,
-		OtpService:          NewOtpService(repository.OtpRepository, repository.UserRepository),
-		SubmissionService:   NewSubmissionService(repository.SubmissionRepository, repository.TeamRepository),
-		CompetitionService:  NewCompetitionService(repository.CompetitionRepository),
-		ExcelService:        NewExcelService(repository.TeamRepository, repository.CompetitionRepository, repository.UserRepository),
-		CountService:        NewCountService(repository.TeamRepository, repository.UserRepository),
-		AnnouncementService: NewAnnouncementService(repository.UserRepository, repository.TeamRepository, repository.AnnouncementRepository),
+		UserService:           NewUserService(repository.UserRepository, repository.TeamRepository, repository.OtpRepository, repository.CompetitionRepository, bcrypt, jwtAuth, storage, teamService, authService, voucherService, waitlistService, repository.FormRepository, repository.OutboxRepository, repository.PaymentRepository),
+		TeamService:           teamService,
+		OtpService:            NewOtpService(repository.OtpRepository, repository.UserRepository, repository.OutboxRepository),
+		SubmissionService:     NewSubmissionService(repository.SubmissionRepository, repository.TeamRepository, repository.CompetitionRepository, storage),
+		CompetitionService:    NewCompetitionService(repository.CompetitionRepository, waitlistService),
+		ExcelService:          NewExcelService(repository.TeamRepository, repository.CompetitionRepository, repository.UserRepository, repository.FormRepository, storage),
+		CountService:          NewCountService(repository.TeamRepository, repository.UserRepository, repository.CompetitionRepository),
+		AnnouncementService:   NewAnnouncementService(repository.UserRepository, repository.TeamRepository, repository.AnnouncementRepository, repository.CompetitionRepository, repository.SubmissionRepository, repository.OutboxRepository),
+		AuthService:           authService,
+		RoleService:           roleService,
+		JudgingService:        NewJudgingService(repository.JudgingRepository, repository.SubmissionRepository, repository.TeamRepository, repository.UserRepository, roleService, storage),
+		FileService:           NewFileService(storage),
+		PaymentService:        NewPaymentService(repository.PaymentRepository, repository.UserRepository, repository.TeamRepository, repository.CompetitionRepository, repository.ReceiptRepository, repository.MemberDocumentRepository, storage, paymentProvider, repository.OutboxRepository),
+		VoucherService:        voucherService,
+		ReconciliationService: NewReconciliationService(repository.ReconciliationRepository, repository.PaymentRepository, repository.TeamRepository, repository.UserRepository, repository.CompetitionRepository, repository.ReceiptRepository, repository.MemberDocumentRepository, repository.OutboxRepository),
+		ReceiptService:        NewReceiptService(repository.ReceiptRepository, repository.TeamRepository),
+		InvitationService:     NewTeamInvitationService(repository.TeamInvitationRepository, repository.TeamRepository, repository.UserRepository, repository.CompetitionRepository, repository.MemberDocumentRepository, repository.PaymentRepository, repository.OutboxRepository),
+		DocumentService:       NewMemberDocumentService(repository.MemberDocumentRepository, repository.TeamRepository, repository.UserRepository, repository.PaymentRepository, storage),
+		DuplicateService:      NewDuplicateService(repository.TeamRepository),
+		LifecycleService:      NewTeamLifecycleService(repository.TeamRepository, waitlistService),
+		LeadershipService:     NewTeamLeadershipService(repository.TeamRepository, repository.UserRepository, repository.SubmissionRepository, repository.TeamInvitationRepository, repository.MemberDocumentRepository, repository.PaymentRepository, repository.VoucherRepository, repository.FormRepository, waitlistService, storage),
+		WaitlistService:       waitlistService,
+		FormService:           NewRegistrationFormService(repository.FormRepository, repository.TeamRepository, repository.CompetitionRepository, storage),
+		EmailTemplateService:  NewEmailTemplateService(),
+		OutboxService:         NewEmailOutboxService(repository.OutboxRepository, mailer),
 	}
 }
@@ -2,10 +2,15 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"itfest-2025/entity"
 	"itfest-2025/internal/repository"
 	"itfest-2025/model"
 	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,21 +20,27 @@ import (
 type ISubmissionService interface {
 	GetSubmission(param *model.ReqFilterSubmission) ([]entity.TeamProgress, error)
 	GetCurrentStage(userID uuid.UUID) (model.ResStage, error)
-	CreateSubmission(userID uuid.UUID, param *model.ReqSubmission) error
-	UpdateStatusSubmission(teamID string, stageID string, param *model.RequestUpdateStatusSubmission) error
+	CreateSubmission(userID uuid.UUID, param *model.ReqSubmission, file *multipart.FileHeader) error
+	UpdateStatusSubmission(decidedBy uuid.UUID, teamID string, stageID string, param *model.RequestUpdateStatusSubmission) error
+	GetSubmissionHistory(userID uuid.UUID) ([]model.ResSubmissionHistory, error)
+	GetTeamSubmissionHistory(teamID uuid.UUID) ([]model.ResSubmissionHistory, error)
 }
 
 type SubmissionService struct {
-	db                   *gorm.DB
-	SubmissionRepository repository.ISubmissionRepository
-	TeamRepository       repository.ITeamRepository
+	db                    *gorm.DB
+	SubmissionRepository  repository.ISubmissionRepository
+	TeamRepository        repository.ITeamRepository
+	CompetitionRepository repository.ICompetitionRepository
+	Storage               storage.Interface
 }
 
-func NewSubmissionService(submissionRepository repository.ISubmissionRepository, teamRepository repository.ITeamRepository) ISubmissionService {
+func NewSubmissionService(submissionRepository repository.ISubmissionRepository, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository, storage storage.Interface) ISubmissionService {
 	return &SubmissionService{
-		db:                   mariadb.Connection,
-		SubmissionRepository: submissionRepository,
-		TeamRepository:       teamRepository,
+		db:                    mariadb.Connection,
+		SubmissionRepository:  submissionRepository,
+		TeamRepository:        teamRepository,
+		CompetitionRepository: competitionRepository,
+		Storage:               storage,
 	}
 }
 
@@ -64,17 +75,19 @@ func (s *SubmissionService) GetCurrentStage(userID uuid.UUID) (model.ResStage, e
 	} else if err != nil {
 		return data, err
 	}
-	submission, err := s.SubmissionRepository.GetSubmission(&model.ReqFilterSubmission{
-		StageID: data.IDCurrentStage,
-		TeamID: team.TeamID.String(),
-	})
 
-	if submission[0].Status == "diproses" || submission[0].Status == "tidak lolos" {
+	if currentStage.Status == "diproses" || currentStage.Status == "tidak lolos" {
+		stage, err := s.SubmissionRepository.GetStage(tx, currentStage.StageID)
+		if err != nil {
+			return data, err
+		}
+
 		return model.ResStage{
 			IDCurrentStage:    currentStage.StageID,
 			NextStage:         0,
 			IDNextStage:       0,
 			DeadlineNextStage: time.Time{},
+			CanResubmit:       currentStage.Status == "diproses" && time.Now().Before(stageClosesAt(stage.Deadline)),
 		}, nil
 	}
 
@@ -93,44 +106,52 @@ func (s *SubmissionService) GetCurrentStage(userID uuid.UUID) (model.ResStage, e
 	return data, nil
 }
 
-func (s *SubmissionService) CreateSubmission(userID uuid.UUID, param *model.ReqSubmission) error {
+func (s *SubmissionService) CreateSubmission(userID uuid.UUID, param *model.ReqSubmission, file *multipart.FileHeader) error {
 	tx := s.db.Begin()
 	defer tx.Rollback()
 
-	stage, err := s.GetCurrentStage(userID)
+	if (param.GdriveLink == "") == (file == nil) {
+		return model.ErrSubmissionContent
+	}
+
+	team, err := s.TeamRepository.GetTeamByUserID(tx, userID)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	if stage.IDNextStage == 0 {
-		return model.ErrNoStage
+
+	if team.TeamStatus == "ditolak" {
+		return model.ErrUnverifiedAccount
 	}
 
-	team, err := s.TeamRepository.GetTeamByUserID(tx, userID)
+	err = ensureTeamActive(team)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
-	submission, err := s.SubmissionRepository.GetSubmission(&model.ReqFilterSubmission{
-		StageID: stage.IDCurrentStage,
-		TeamID: team.TeamID.String(),
-	})
 
-	if len(submission) > 0 {
-		if submission[0].Status == "tidak lolos" {
+	currentProgress, err := s.SubmissionRepository.GetCurrentStage(team)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	} else if err == nil {
+		if currentProgress.Status == "tidak lolos" {
 			return model.ErrNotPassedPrevious
 		}
-		if submission[0].Status == "diproses" {
-			return model.ErrSubmissionProcessing
+		if currentProgress.Status == "diproses" {
+			return s.resubmit(tx, userID, team, &currentProgress, param, file)
 		}
 	}
 
-	if time.Now().After(stage.DeadlineNextStage) {
-		return model.ErrPassedDeadline
+	stage, err := s.GetCurrentStage(userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if stage.IDNextStage == 0 {
+		return model.ErrNoStage
 	}
 
-	if team.TeamStatus == "ditolak" {
-		return model.ErrUnverifiedAccount
+	if !time.Now().Before(stageClosesAt(stage.DeadlineNextStage)) {
+		return model.ErrPassedDeadline
 	}
 
 	dataStage, err := s.SubmissionRepository.GetStage(tx, stage.IDNextStage)
@@ -138,45 +159,296 @@ func (s *SubmissionService) CreateSubmission(userID uuid.UUID, param *model.ReqS
 		return err
 	}
 
-	if team.CompetitionID == 2 {
-		if team.TeamStatus != "terverifikasi" {
-			return model.ErrUnverifiedAccount
-		}
+	rule, err := getCompetitionRule(tx, s.CompetitionRepository, team.CompetitionID)
+	if err != nil {
+		return err
 	}
-	
-	if team.CompetitionID == 3 {
-		if dataStage.StageOrder == 1 {
-			if team.TeamStatus == "terverifikasi" {
-				return model.ErrUnverifiedAccount
-			}
-		} else {
-			if team.TeamStatus != "terverifikasi" {
-				return model.ErrUnverifiedAccount
-			}
-		}
+
+	if paymentBlocksStage(rule, dataStage.StageOrder) && team.TeamStatus != "terverifikasi" {
+		return model.ErrUnverifiedAccount
 	}
 
-	if team.CompetitionID != 2 && team.CompetitionID != 3 {
-		if team.TeamStatus != "terverifikasi" || dataStage.StageOrder != 1 {
-			return model.ErrUnverifiedAccount
-		}
+	content, err := s.prepareSubmission(dataStage, team, param, file)
+	if err != nil {
+		return err
 	}
 
 	newSubmission := &entity.TeamProgress{
-		StageID:    stage.IDNextStage,
-		Status:     "diproses",
-		TeamID:     team.TeamID,
-		GdriveLink: param.GdriveLink,
+		StageID:        stage.IDNextStage,
+		Status:         "diproses",
+		TeamID:         team.TeamID,
+		GdriveLink:     content.GdriveLink,
+		FileKey:        content.FileKey,
+		FileName:       content.FileName,
+		FileType:       content.FileType,
+		FileSize:       content.FileSize,
+		CurrentVersion: 1,
 	}
 
 	if err := s.SubmissionRepository.CreateSubmission(tx, newSubmission); err != nil {
-		tx.Rollback()
+		deleteStoredFile(s.Storage, content.FileKey)
 		return err
 	}
 
+	content.TeamProgressID = newSubmission.TeamProgressID
+	content.Version = 1
+	content.SubmittedBy = userID
+
+	err = s.SubmissionRepository.CreateSubmissionVersion(tx, content)
+	if err != nil {
+		deleteStoredFile(s.Storage, content.FileKey)
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		deleteStoredFile(s.Storage, content.FileKey)
+		return err
+	}
+
+	return nil
+}
+
+func (s *SubmissionService) UpdateStatusSubmission(decidedBy uuid.UUID, teamID string, stageID string, param *model.RequestUpdateStatusSubmission) error {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+
+	err := s.SubmissionRepository.UpdateStatusSubmission(tx, teamID, stageID, decidedBy, *param)
+	if err != nil {
+		return err
+	}
+
+	// tim yang lolos stage dikunci agar susunan anggotanya tidak berubah di tahap berikutnya
+	if param.SubmissionStatus == "lolos" {
+		id, err := uuid.Parse(teamID)
+		if err != nil {
+			return err
+		}
+
+		team, err := s.TeamRepository.GetTeamByID(tx, id)
+		if err != nil {
+			return err
+		}
+
+		err = lockTeam(tx, s.TeamRepository, team, "passed stage "+stageID, &decidedBy)
+		if err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit().Error
 }
 
-func (s *SubmissionService) UpdateStatusSubmission(teamID string, stageID string, param *model.RequestUpdateStatusSubmission) error {
-	return s.SubmissionRepository.UpdateStatusSubmission(s.db, teamID, stageID, *param)
+func (s *SubmissionService) GetSubmissionHistory(userID uuid.UUID) ([]model.ResSubmissionHistory, error) {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+
+	team, err := s.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getSubmissionHistory(tx, team)
+}
+
+func (s *SubmissionService) GetTeamSubmissionHistory(teamID uuid.UUID) ([]model.ResSubmissionHistory, error) {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+
+	team, err := s.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getSubmissionHistory(tx, team)
+}
+
+// resubmit menyimpan link atau file baru sebagai versi aktif selama submission masih diproses dan belum melewati deadline.
+// Transaksi di-commit di sini agar file yang sudah diunggah bisa dihapus lagi jika commit gagal.
+func (s *SubmissionService) resubmit(tx *gorm.DB, userID uuid.UUID, team *entity.Team, progress *entity.TeamProgress, param *model.ReqSubmission, file *multipart.FileHeader) error {
+	// progress dibaca ulang dengan lock karena versi berikutnya dihitung dari current_version
+	progress, err := s.SubmissionRepository.LockSubmission(tx, progress.TeamProgressID)
+	if err != nil {
+		return err
+	}
+
+	if progress.Status != "diproses" {
+		return model.ErrSubmissionDecided
+	}
+
+	stage, err := s.SubmissionRepository.GetStage(tx, progress.StageID)
+	if err != nil {
+		return err
+	}
+
+	if !time.Now().Before(stageClosesAt(stage.Deadline)) {
+		return model.ErrPassedDeadline
+	}
+
+	if param.GdriveLink != "" && progress.GdriveLink == param.GdriveLink {
+		return model.ErrSameSubmissionLink
+	}
+
+	// submission yang dibuat sebelum ada riwayat versi dicatat dulu sebagai versi aktifnya
+	_, err = s.SubmissionRepository.GetSubmissionVersion(tx, progress.TeamProgressID, progress.CurrentVersion)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = s.SubmissionRepository.CreateSubmissionVersion(tx, &entity.SubmissionVersion{
+			TeamProgressID: progress.TeamProgressID,
+			Version:        progress.CurrentVersion,
+			GdriveLink:     progress.GdriveLink,
+			FileKey:        progress.FileKey,
+			FileName:       progress.FileName,
+			FileType:       progress.FileType,
+			FileSize:       progress.FileSize,
+			SubmittedBy:    userID,
+			CreatedAt:      progress.CreatedAt,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := s.prepareSubmission(stage, team, param, file)
+	if err != nil {
+		return err
+	}
+
+	content.TeamProgressID = progress.TeamProgressID
+	content.Version = progress.CurrentVersion + 1
+	content.SubmittedBy = userID
+
+	err = s.SubmissionRepository.CreateSubmissionVersion(tx, content)
+	if err != nil {
+		deleteStoredFile(s.Storage, content.FileKey)
+		return err
+	}
+
+	err = s.SubmissionRepository.UpdateSubmissionContent(tx, content)
+	if err != nil {
+		deleteStoredFile(s.Storage, content.FileKey)
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		deleteStoredFile(s.Storage, content.FileKey)
+		return err
+	}
+
+	return nil
+}
+
+// prepareSubmission memvalidasi file terhadap aturan stage lalu mengunggahnya ke storage privat.
+func (s *SubmissionService) prepareSubmission(stage entity.Stages, team *entity.Team, param *model.ReqSubmission, file *multipart.FileHeader) (*entity.SubmissionVersion, error) {
+	if file == nil {
+		return &entity.SubmissionVersion{
+			GdriveLink: param.GdriveLink,
+		}, nil
+	}
+
+	if file.Size > stageFileSize(stage.MaxFileSize) {
+		return nil, model.ErrFileTooLarge
+	}
+
+	contentType, err := model.GetImageType(file)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType = strings.Split(contentType, ";")[0]
+	if !contains(stageMimeTypes(stage), contentType) {
+		return nil, model.ErrFileTypeNotAllowed
+	}
+
+	key, err := s.Storage.Upload(file, fmt.Sprintf("submissions/%s/%d", team.TeamID, stage.StageID), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.SubmissionVersion{
+		FileKey:  key,
+		FileName: filepath.Base(file.Filename),
+		FileType: contentType,
+		FileSize: file.Size,
+	}, nil
+}
+
+func (s *SubmissionService) getSubmissionHistory(tx *gorm.DB, team *entity.Team) ([]model.ResSubmissionHistory, error) {
+	stages, err := s.CompetitionRepository.GetStagesByCompetitionID(tx, team.CompetitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stageMap := make(map[int]entity.Stages)
+	for _, v := range stages {
+		stageMap[v.StageID] = v
+	}
+
+	progresses, err := s.SubmissionRepository.GetSubmissionHistory(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := []model.ResSubmissionHistory{}
+	for _, v := range progresses {
+		versions := []model.ResSubmissionVersion{}
+		for _, version := range v.Versions {
+			fileURL, err := signedFileURL(s.Storage, version.FileKey)
+			if err != nil {
+				return nil, err
+			}
+
+			versions = append(versions, model.ResSubmissionVersion{
+				Version:     version.Version,
+				GdriveLink:  version.GdriveLink,
+				FileName:    version.FileName,
+				FileType:    version.FileType,
+				FileSize:    version.FileSize,
+				FileURL:     fileURL,
+				SubmittedBy: version.SubmittedBy,
+				IsActive:    version.Version == v.CurrentVersion,
+				CreatedAt:   version.CreatedAt,
+			})
+		}
+
+		fileURL, err := signedFileURL(s.Storage, v.FileKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(versions) == 0 {
+			versions = append(versions, model.ResSubmissionVersion{
+				Version:    v.CurrentVersion,
+				GdriveLink: v.GdriveLink,
+				FileName:   v.FileName,
+				FileType:   v.FileType,
+				FileSize:   v.FileSize,
+				FileURL:    fileURL,
+				IsActive:   true,
+				CreatedAt:  v.CreatedAt,
+			})
+		}
+
+		history = append(history, model.ResSubmissionHistory{
+			TeamProgressID: v.TeamProgressID,
+			StageID:        v.StageID,
+			Stage:          stageMap[v.StageID].StageName,
+			Deadline:       stageMap[v.StageID].Deadline,
+			Status:         v.Status,
+			CurrentVersion: v.CurrentVersion,
+			GdriveLink:     v.GdriveLink,
+			FileName:       v.FileName,
+			FileURL:        fileURL,
+			Versions:       versions,
+		})
+	}
+
+	return history, nil
+}
+
+// stageClosesAt mengembalikan waktu tutup stage, kolom deadline bertipe date sehingga pengumpulan masih dibuka
+// sampai akhir hari deadline waktu lokal.
+func stageClosesAt(deadline time.Time) time.Time {
+	year, month, day := deadline.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.Local)
 }
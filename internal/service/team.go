@@ -2,10 +2,12 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"itfest-2025/entity"
 	"itfest-2025/internal/repository"
 	"itfest-2025/model"
 	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
 	"strings"
 	"time"
 
@@ -17,7 +19,6 @@ type ITeamService interface {
 	UpsertTeam(userID uuid.UUID, param *model.UpsertTeamRequest) (*model.UpsertTeamResponse, error)
 	GetMembersByUserID(userID uuid.UUID) (*model.TeamInfoResponse, error)
 	GetAllTeam() ([]*model.GetAllTeamsResponse, error)
-	UpdateTeamStatus(id string, req model.ReqUpdateStatusTeam) error
 	GetTeamByID(teamID uuid.UUID) (*model.TeamInfoResponseAdmin, error)
 	GetDetailTeam(teamID uuid.UUID) (*model.TeamDetailProgress, error)
 	GetProgressByUserID(userID uuid.UUID) (*model.TeamDetailProgress, error)
@@ -29,23 +30,27 @@ type TeamService struct {
 	TeamRepository        repository.ITeamRepository
 	CompetitionRepository repository.ICompetitionRepository
 	SubmissionRepository  repository.ISubmissionRepository
+	InvitationRepository  repository.ITeamInvitationRepository
+	DocumentRepository    repository.IMemberDocumentRepository
+	PaymentRepository     repository.IPaymentRepository
+	Storage               storage.Interface
 }
 
-func NewTeamService(userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository, submissionRepository repository.ISubmissionRepository) ITeamService {
+func NewTeamService(userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository, submissionRepository repository.ISubmissionRepository, invitationRepository repository.ITeamInvitationRepository, documentRepository repository.IMemberDocumentRepository, paymentRepository repository.IPaymentRepository, storage storage.Interface) ITeamService {
 	return &TeamService{
 		db:                    mariadb.Connection,
 		UserRepository:        userRepository,
 		TeamRepository:        teamRepository,
 		CompetitionRepository: competitionRepository,
 		SubmissionRepository:  submissionRepository,
+		InvitationRepository:  invitationRepository,
+		DocumentRepository:    documentRepository,
+		PaymentRepository:     paymentRepository,
+		Storage:               storage,
 	}
 }
 
 func (t *TeamService) UpsertTeam(userID uuid.UUID, param *model.UpsertTeamRequest) (*model.UpsertTeamResponse, error) {
-	if len(param.Members) > 2 {
-		return nil, errors.New("maximum of 2 team members allowed")
-	}
-
 	tx := t.db.Begin()
 	defer tx.Rollback()
 
@@ -54,14 +59,79 @@ func (t *TeamService) UpsertTeam(userID uuid.UUID, param *model.UpsertTeamReques
 		return nil, err
 	}
 
+	competitionID := entity.UnassignedCompetitionID
+	reserved := 0
+	previous := make(map[string]uuid.UUID)
+	if team != nil {
+		err = ensureTeamEditable(team)
+		if err != nil {
+			return nil, err
+		}
+
+		competitionID = team.CompetitionID
+		reserved, err = t.reservedMemberSlots(tx, team.TeamID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = t.TeamRepository.GetTeamMemberByUserID(tx, userID)
+		if err == nil {
+			return nil, model.ErrAlreadyInTeam
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	rule, err := getCompetitionRule(tx, t.CompetitionRepository, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved+len(param.Members) > rule.MaxTeamMembers {
+		return nil, fmt.Errorf("%w: maximum of %d team members allowed", model.ErrTeamMemberLimit, rule.MaxTeamMembers)
+	}
+
+	leader, err := t.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	teamID := uuid.Nil
+	studentNumbers := []string{leader.StudentNumber}
+	if team != nil {
+		teamID = team.TeamID
+
+		members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range members {
+			if v.UserID != nil {
+				studentNumbers = append(studentNumbers, v.StudentNumber)
+			}
+		}
+	}
+	for _, v := range param.Members {
+		studentNumbers = append(studentNumbers, v.StudentNumber)
+	}
+
+	err = checkDuplicateParticipants(tx, t.TeamRepository, t.CompetitionRepository, teamID, competitionID, studentNumbers)
+	if err != nil {
+		return nil, err
+	}
+
 	if team == nil {
 		teamID := uuid.New()
 		newTeam := &entity.Team{
-			TeamID:        teamID,
-			TeamName:      param.TeamName,
-			TeamStatus:    "belum terverifikasi",
-			CompetitionID: 1,
-			UserID:        userID,
+			TeamID:          teamID,
+			TeamName:        param.TeamName,
+			TeamStatus:      "belum terverifikasi",
+			CompetitionID:   entity.UnassignedCompetitionID,
+			UserID:          userID,
+			LifecycleStatus: entity.TeamDraft,
 		}
 
 		err := t.TeamRepository.GetTeamByName(tx, param.TeamName)
@@ -82,6 +152,18 @@ func (t *TeamService) UpsertTeam(userID uuid.UUID, param *model.UpsertTeamReques
 			return nil, err
 		}
 
+		members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+		if err != nil {
+			return nil, err
+		}
+
+		// ID anggota dengan NIM yang sama dipertahankan agar dokumen yang sudah diunggah tidak hilang
+		for _, v := range members {
+			if v.UserID == nil && v.StudentNumber != "" {
+				previous[v.StudentNumber] = v.TeamMemberID
+			}
+		}
+
 		err = t.TeamRepository.DeleteTeamMembers(tx, team.TeamID)
 		if err != nil {
 			return nil, err
@@ -89,8 +171,15 @@ func (t *TeamService) UpsertTeam(userID uuid.UUID, param *model.UpsertTeamReques
 	}
 
 	for _, v := range param.Members {
+		memberID, ok := previous[v.StudentNumber]
+		if ok {
+			delete(previous, v.StudentNumber)
+		} else {
+			memberID = uuid.New()
+		}
+
 		member := &entity.TeamMember{
-			TeamMemberID:  uuid.New(),
+			TeamMemberID:  memberID,
 			TeamID:        team.TeamID,
 			MemberName:    v.Name,
 			StudentNumber: v.StudentNumber,
@@ -101,6 +190,21 @@ func (t *TeamService) UpsertTeam(userID uuid.UUID, param *model.UpsertTeamReques
 		}
 	}
 
+	var removedIDs []uuid.UUID
+	for _, v := range previous {
+		removedIDs = append(removedIDs, v)
+	}
+
+	removedDocuments, err := t.DocumentRepository.DeleteDocumentsByOwnerIDs(tx, removedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = syncTeamVerification(tx, t.DocumentRepository, t.TeamRepository, t.PaymentRepository, team)
+	if err != nil {
+		return nil, err
+	}
+
 	var response model.UpsertTeamResponse
 	response.TeamName = team.TeamName
 	response.Members = param.Members
@@ -110,14 +214,40 @@ func (t *TeamService) UpsertTeam(userID uuid.UUID, param *model.UpsertTeamReques
 		return nil, err
 	}
 
+	for _, v := range removedDocuments {
+		deleteStoredFile(t.Storage, v.FileKey)
+	}
+
 	return &response, nil
 }
 
+// reservedMemberSlots menghitung slot yang sudah dipakai anggota tertaut akun dan undangan yang belum dijawab.
+func (t *TeamService) reservedMemberSlots(tx *gorm.DB, teamID uuid.UUID) (int, error) {
+	members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, teamID)
+	if err != nil {
+		return 0, err
+	}
+
+	reserved := 0
+	for _, v := range members {
+		if v.UserID != nil {
+			reserved++
+		}
+	}
+
+	pending, err := t.InvitationRepository.CountPendingInvitations(tx, teamID)
+	if err != nil {
+		return 0, err
+	}
+
+	return reserved + int(pending), nil
+}
+
 func (t *TeamService) GetMembersByUserID(userID uuid.UUID) (*model.TeamInfoResponse, error) {
 	tx := t.db.Begin()
 	defer tx.Rollback()
 
-	team, err := t.TeamRepository.GetTeamByUserID(tx, userID)
+	team, err := t.TeamRepository.GetTeamForUser(tx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +262,7 @@ func (t *TeamService) GetMembersByUserID(userID uuid.UUID) (*model.TeamInfoRespo
 		memberResponse = append(memberResponse, model.TeamMembersResponse{
 			FullName:      v.MemberName,
 			StudentNumber: v.StudentNumber,
+			UserID:        v.UserID,
 		})
 	}
 
@@ -149,6 +280,8 @@ func (t *TeamService) GetMembersByUserID(userID uuid.UUID) (*model.TeamInfoRespo
 	TeamInforResponse := model.TeamInfoResponse{
 		TeamName:            team.TeamName,
 		CompetitionCategory: competition.CompetitionName,
+		LifecycleStatus:     team.LifecycleStatus,
+		LifecycleReason:     team.LifecycleReason,
 		Members:             memberResponse,
 	}
 
@@ -174,11 +307,15 @@ func (t *TeamService) GetAllTeam() ([]*model.GetAllTeamsResponse, error) {
 	}
 
 	for _, v := range user {
+		if !isTeamActive(&v.Team) {
+			continue
+		}
+
 		competition, err := t.CompetitionRepository.GetCompetitionByID(tx, v.Team.CompetitionID)
 		if err != nil {
 			continue
 		}
-		if v.RoleID == 1 {
+		if v.RoleID != entity.RoleParticipant {
 			continue
 		}
 
@@ -190,7 +327,7 @@ func (t *TeamService) GetAllTeam() ([]*model.GetAllTeamsResponse, error) {
 				Name: x.MemberName,
 			})
 		}
-		if v.Team.CompetitionID > 1 {
+		if v.Team.CompetitionID != entity.UnassignedCompetitionID {
 			dataCurrent, _ := t.getProgress(v.Team.TeamID, true)
 			if err == nil {
 				dataStage.CurrentStage = dataCurrent.CurrentStage
@@ -204,6 +341,7 @@ func (t *TeamService) GetAllTeam() ([]*model.GetAllTeamsResponse, error) {
 			PaymentStatus:   v.Team.TeamStatus,
 			CompetitionName: competition.CompetitionName,
 			CurrentStage:    dataStage.CurrentStage,
+			LifecycleStatus: v.Team.LifecycleStatus,
 			TeamMembers:     teamMembers,
 		})
 	}
@@ -211,11 +349,6 @@ func (t *TeamService) GetAllTeam() ([]*model.GetAllTeamsResponse, error) {
 	return res, nil
 }
 
-func (t *TeamService) UpdateTeamStatus(id string, req model.ReqUpdateStatusTeam) error {
-	req.TeamID = id
-	return t.TeamRepository.UpdateTeamStatus(t.db, req)
-}
-
 func (t *TeamService) GetTeamByID(teamID uuid.UUID) (*model.TeamInfoResponseAdmin, error) {
 	tx := t.db.Begin()
 	defer tx.Rollback()
@@ -239,6 +372,21 @@ func (t *TeamService) GetTeamByID(teamID uuid.UUID) (*model.TeamInfoResponseAdmi
 		}
 	}
 
+	paymentKey, studentCardKey, err := teamProofFiles(tx, t.PaymentRepository, t.DocumentRepository, team, user)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentURL, err := signedFileURL(t.Storage, paymentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	studentCardURL, err := signedFileURL(t.Storage, studentCardKey)
+	if err != nil {
+		return nil, err
+	}
+
 	members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
 	if err != nil {
 		members = []*entity.TeamMember{}
@@ -249,6 +397,7 @@ func (t *TeamService) GetTeamByID(teamID uuid.UUID) (*model.TeamInfoResponseAdmi
 		memberResponse = append(memberResponse, model.TeamMembersResponse{
 			FullName:      v.MemberName,
 			StudentNumber: v.StudentNumber,
+			UserID:        v.UserID,
 		})
 	}
 
@@ -272,10 +421,12 @@ func (t *TeamService) GetTeamByID(teamID uuid.UUID) (*model.TeamInfoResponseAdmi
 					LeaderName:          user.FullName,
 					StudentNumber:       user.StudentNumber,
 					PaymentStatus:       team.TeamStatus,
-					PaymentTransc:       user.PaymentTransc,
-					StudentCard:         user.StudentCardLink,
+					PaymentTransc:       paymentURL,
+					StudentCard:         studentCardURL,
 					Members:             memberResponse,
 					PhoneNumber:         user.PhoneNumber,
+					LifecycleStatus:     team.LifecycleStatus,
+					LifecycleReason:     team.LifecycleReason,
 					StageNow:            model.StageNow{},
 				}, nil
 			}
@@ -335,10 +486,12 @@ func (t *TeamService) GetTeamByID(teamID uuid.UUID) (*model.TeamInfoResponseAdmi
 		LeaderName:          user.FullName,
 		StudentNumber:       user.StudentNumber,
 		PaymentStatus:       team.TeamStatus,
-		PaymentTransc:       user.PaymentTransc,
-		StudentCard:         user.StudentCardLink,
+		PaymentTransc:       paymentURL,
+		StudentCard:         studentCardURL,
 		Members:             memberResponse,
 		PhoneNumber:         user.PhoneNumber,
+		LifecycleStatus:     team.LifecycleStatus,
+		LifecycleReason:     team.LifecycleReason,
 		StageNow: model.StageNow{
 			Stage:    stage.StageName,
 			Status:   submission,
@@ -365,7 +518,7 @@ func (t *TeamService) getProgress(ID uuid.UUID, isAdmin bool) (*model.TeamDetail
 	tx := t.db.Begin()
 	defer tx.Rollback()
 
-	team, err := t.TeamRepository.GetTeamByUserID(tx, ID)
+	team, err := t.TeamRepository.GetTeamForUser(tx, ID)
 	if isAdmin {
 		team, err = t.TeamRepository.GetTeamByID(tx, ID)
 	}
@@ -391,37 +544,54 @@ func (t *TeamService) getProgress(ID uuid.UUID, isAdmin bool) (*model.TeamDetail
 		return nil, err
 	}
 
-	competitionName := strings.ToLower(competition.CompetitionName)
-	isBP := strings.Contains(competitionName, "bp") || strings.Contains(competitionName, "business")
-	isUIUX := strings.Contains(competitionName, "ui") || strings.Contains(competitionName, "ux")
+	rule, err := getCompetitionRule(tx, t.CompetitionRepository, team.CompetitionID)
+	if err != nil {
+		return nil, err
+	}
 
-	stages, err := t.SubmissionRepository.GetSubmissionAllStage(tx, team.TeamID, team.CompetitionID)
+	paymentKey, _, err := teamProofFiles(tx, t.PaymentRepository, t.DocumentRepository, team, user)
 	if err != nil {
 		return nil, err
 	}
 
-	// dummy payment stage
-	paymentStage := model.Stages{
-		Stage:      "Payment",
-		GdriveLink: user.PaymentTransc,
-		Status:     team.TeamStatus,
-		Deadline:   time.Time{},
+	paymentURL, err := signedFileURL(t.Storage, paymentKey)
+	if err != nil {
+		return nil, err
 	}
 
-	if isBP {
-		if len(stages) >= 2 {
-			paymentStage.Deadline = stages[1].Deadline
+	stages, err := t.SubmissionRepository.GetSubmissionAllStage(tx, team.TeamID, team.CompetitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.PaymentRequired {
+		// dummy payment stage, diletakkan sebelum stage pertama yang membutuhkan pembayaran
+		paymentStage := model.Stages{
+			Stage:      "Payment",
+			GdriveLink: paymentURL,
+			Status:     team.TeamStatus,
+			Deadline:   time.Time{},
 		}
-		if len(stages) >= 1 {
-			stages = append(stages[:1], append([]model.Stages{paymentStage}, stages[1:]...)...)
-		} else {
-			stages = append(stages, paymentStage)
+
+		position := len(stages)
+		for i, v := range stages {
+			if paymentBlocksStage(rule, v.StageOrder) {
+				position = i
+				break
+			}
 		}
-	} else if isUIUX {
-		if len(stages) >= 1 {
-			paymentStage.Deadline = stages[0].Deadline
+		if position < len(stages) {
+			paymentStage.Deadline = stages[position].Deadline
 		}
-		stages = append([]model.Stages{paymentStage}, stages...)
+
+		stages = append(stages[:position], append([]model.Stages{paymentStage}, stages[position:]...)...)
+	}
+
+	if len(stages) == 0 {
+		return &model.TeamDetailProgress{
+			TeamCompetition: competition.CompetitionName,
+			PaymentStatus:   team.TeamStatus,
+		}, nil
 	}
 
 	index := 0
@@ -462,3 +632,25 @@ func (t *TeamService) getProgress(ID uuid.UUID, isAdmin bool) (*model.TeamDetail
 		Stages:          stages,
 	}, nil
 }
+
+// teamProofFiles mengambil bukti pembayaran dan KTM ketua dari data pembayaran dan dokumen tim sehingga tetap
+// tampil setelah ketua berganti. Kolom di tabel user hanya dipakai untuk tim lama yang belum punya data tersebut.
+func teamProofFiles(tx *gorm.DB, paymentRepository repository.IPaymentRepository, documentRepository repository.IMemberDocumentRepository, team *entity.Team, leader *entity.User) (string, string, error) {
+	paymentKey := leader.PaymentTransc
+	payment, err := paymentRepository.GetPaymentByTeamID(tx, team.TeamID)
+	if err == nil {
+		paymentKey = payment.ProofFile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", err
+	}
+
+	studentCardKey := leader.StudentCardLink
+	document, err := documentRepository.GetDocumentByOwner(tx, team.UserID, entity.DocumentKTM)
+	if err == nil {
+		studentCardKey = document.FileKey
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", err
+	}
+
+	return paymentKey, studentCardKey, nil
+}
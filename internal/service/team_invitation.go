@@ -0,0 +1,505 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ITeamInvitationService interface {
+	InviteMember(userID uuid.UUID, param model.InviteMemberRequest) (*model.InvitationResponse, error)
+	GetTeamInvitations(userID uuid.UUID) ([]model.InvitationResponse, error)
+	CancelInvitation(userID uuid.UUID, invitationID uuid.UUID) error
+	GetMyInvitations(userID uuid.UUID) ([]model.InvitationResponse, error)
+	AcceptInvitation(userID uuid.UUID, invitationID uuid.UUID) error
+	DeclineInvitation(userID uuid.UUID, invitationID uuid.UUID) error
+}
+
+type TeamInvitationService struct {
+	db                    *gorm.DB
+	InvitationRepository  repository.ITeamInvitationRepository
+	TeamRepository        repository.ITeamRepository
+	UserRepository        repository.IUserRepository
+	CompetitionRepository repository.ICompetitionRepository
+	DocumentRepository    repository.IMemberDocumentRepository
+	PaymentRepository     repository.IPaymentRepository
+	OutboxRepository      repository.IEmailOutboxRepository
+}
+
+func NewTeamInvitationService(invitationRepository repository.ITeamInvitationRepository, teamRepository repository.ITeamRepository, userRepository repository.IUserRepository, competitionRepository repository.ICompetitionRepository, documentRepository repository.IMemberDocumentRepository, paymentRepository repository.IPaymentRepository, outboxRepository repository.IEmailOutboxRepository) ITeamInvitationService {
+	return &TeamInvitationService{
+		db:                    mariadb.Connection,
+		InvitationRepository:  invitationRepository,
+		TeamRepository:        teamRepository,
+		UserRepository:        userRepository,
+		CompetitionRepository: competitionRepository,
+		DocumentRepository:    documentRepository,
+		PaymentRepository:     paymentRepository,
+		OutboxRepository:      outboxRepository,
+	}
+}
+
+func (t *TeamInvitationService) InviteMember(userID uuid.UUID, param model.InviteMemberRequest) (*model.InvitationResponse, error) {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	err := t.InvitationRepository.ExpireInvitations(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, err := t.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := t.getLeaderTeam(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// baris tim dikunci sebelum slot dihitung agar dua undangan bersamaan tidak melebihi batas anggota
+	team, err = t.TeamRepository.LockTeam(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureTeamEditable(team)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(param.Email))
+	if email == strings.ToLower(leader.Email) {
+		return nil, model.ErrInviteSelf
+	}
+
+	pending, err := t.InvitationRepository.HasPendingInvitation(tx, team.TeamID, email)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, model.ErrInvitationDuplicate
+	}
+
+	invitee, err := t.UserRepository.GetUser(model.UserParam{
+		Email: email,
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		_, err = t.TeamRepository.GetTeamMemberByUserID(tx, invitee.UserID)
+		if err == nil {
+			return nil, model.ErrAlreadyInTeam
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	// slot undangan baru ikut dihitung agar jumlah anggota tidak melebihi batas saat semua undangan diterima
+	err = t.checkMemberSlot(tx, team, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &entity.TeamInvitation{
+		InvitationID: uuid.New(),
+		TeamID:       team.TeamID,
+		Email:        email,
+		Status:       "pending",
+		InvitedBy:    userID,
+		ExpiresAt:    time.Now().Add(invitationExpiry()),
+	}
+
+	err = t.InvitationRepository.CreateInvitation(tx, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = queueInvitationEmail(tx, t.OutboxRepository, leader, team, invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.Team = *team
+	response := toInvitationResponse(*invitation)
+	return &response, nil
+}
+
+func (t *TeamInvitationService) GetTeamInvitations(userID uuid.UUID) ([]model.InvitationResponse, error) {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	err := t.InvitationRepository.ExpireInvitations(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := t.getLeaderTeam(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := t.InvitationRepository.GetInvitationsByTeamID(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	var response []model.InvitationResponse
+	for _, v := range invitations {
+		v.Team = *team
+		response = append(response, toInvitationResponse(v))
+	}
+
+	return response, nil
+}
+
+func (t *TeamInvitationService) CancelInvitation(userID uuid.UUID, invitationID uuid.UUID) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	err := t.InvitationRepository.ExpireInvitations(tx)
+	if err != nil {
+		return err
+	}
+
+	team, err := t.getLeaderTeam(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := t.getInvitation(tx, invitationID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.TeamID != team.TeamID {
+		return model.ErrInvitationNotFound
+	}
+
+	if invitation.Status != "pending" {
+		return model.ErrInvitationNotPending
+	}
+
+	now := time.Now()
+	invitation.Status = "cancelled"
+	invitation.RespondedAt = &now
+
+	err = t.InvitationRepository.UpdateInvitation(tx, invitation)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (t *TeamInvitationService) GetMyInvitations(userID uuid.UUID) ([]model.InvitationResponse, error) {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	err := t.InvitationRepository.ExpireInvitations(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := t.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := t.InvitationRepository.GetPendingInvitationsByEmail(tx, strings.ToLower(user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	var response []model.InvitationResponse
+	for _, v := range invitations {
+		response = append(response, toInvitationResponse(v))
+	}
+
+	return response, nil
+}
+
+func (t *TeamInvitationService) AcceptInvitation(userID uuid.UUID, invitationID uuid.UUID) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	err := t.InvitationRepository.ExpireInvitations(tx)
+	if err != nil {
+		return err
+	}
+
+	user, invitation, err := t.getOwnInvitation(tx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	team, err := t.TeamRepository.LockTeam(tx, invitation.TeamID)
+	if err != nil {
+		return err
+	}
+	invitation.Team = *team
+
+	err = ensureTeamEditable(&invitation.Team)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.TeamRepository.GetTeamMemberByUserID(tx, userID)
+	if err == nil {
+		return model.ErrAlreadyInTeam
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	// setiap akun mendapat tim kosong saat registrasi, tim itu dilepas selama belum dipakai
+	err = releasePlaceholderTeam(tx, t.TeamRepository, userID)
+	if err != nil {
+		return err
+	}
+
+	// undangan ini sudah termasuk slot yang dipesan, jadi tidak perlu menambah slot baru
+	err = t.checkMemberSlot(tx, &invitation.Team, 0)
+	if err != nil {
+		return err
+	}
+
+	err = t.checkInviteeStudentNumber(tx, &invitation.Team, user)
+	if err != nil {
+		return err
+	}
+
+	member := &entity.TeamMember{
+		TeamMemberID:  uuid.New(),
+		MemberName:    user.FullName,
+		StudentNumber: user.StudentNumber,
+		TeamID:        invitation.TeamID,
+		UserID:        &user.UserID,
+	}
+
+	err = t.TeamRepository.CreateTeamMember(tx, member)
+	if err != nil {
+		return err
+	}
+
+	// KTM yang sudah diunggah saat masih menjadi ketua tim kosong dipindahkan ke keanggotaan baru
+	document, err := t.DocumentRepository.GetDocumentByOwner(tx, user.UserID, entity.DocumentKTM)
+	if err == nil {
+		document.TeamID = member.TeamID
+		document.OwnerID = member.TeamMemberID
+		document.OwnerType = entity.DocumentOwnerMember
+
+		err = t.DocumentRepository.UpdateDocument(tx, document)
+	} else if errors.Is(err, gorm.ErrRecordNotFound) && user.StudentCardLink != "" {
+		_, err = saveMemberDocument(tx, t.DocumentRepository, &invitation.Team, member.TeamMemberID, entity.DocumentOwnerMember, user.StudentCardLink, user.UserID)
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	invitation.Status = "accepted"
+	invitation.RespondedAt = &now
+	invitation.AcceptedBy = &user.UserID
+
+	err = t.InvitationRepository.UpdateInvitation(tx, invitation)
+	if err != nil {
+		return err
+	}
+
+	// anggota baru belum punya KTM yang disetujui sehingga tim perlu diverifikasi ulang
+	err = syncTeamVerification(tx, t.DocumentRepository, t.TeamRepository, t.PaymentRepository, &invitation.Team)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (t *TeamInvitationService) DeclineInvitation(userID uuid.UUID, invitationID uuid.UUID) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	err := t.InvitationRepository.ExpireInvitations(tx)
+	if err != nil {
+		return err
+	}
+
+	_, invitation, err := t.getOwnInvitation(tx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	invitation.Status = "declined"
+	invitation.RespondedAt = &now
+
+	err = t.InvitationRepository.UpdateInvitation(tx, invitation)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// getLeaderTeam memastikan hanya ketua tim yang dapat mengelola undangan.
+func (t *TeamInvitationService) getLeaderTeam(tx *gorm.DB, userID uuid.UUID) (*entity.Team, error) {
+	team, err := t.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotTeamLeader
+		}
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (t *TeamInvitationService) getInvitation(tx *gorm.DB, invitationID uuid.UUID) (*entity.TeamInvitation, error) {
+	invitation, err := t.InvitationRepository.GetInvitationByID(tx, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// getOwnInvitation mengambil undangan yang masih menunggu jawaban dan ditujukan ke email user.
+func (t *TeamInvitationService) getOwnInvitation(tx *gorm.DB, userID uuid.UUID, invitationID uuid.UUID) (*entity.User, *entity.TeamInvitation, error) {
+	user, err := t.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invitation, err := t.getInvitation(tx, invitationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if invitation.Email != strings.ToLower(user.Email) {
+		return nil, nil, model.ErrInvitationEmailMismatch
+	}
+
+	if invitation.Status == "expired" {
+		return nil, nil, model.ErrInvitationExpired
+	}
+
+	if invitation.Status != "pending" {
+		return nil, nil, model.ErrInvitationNotPending
+	}
+
+	return user, invitation, nil
+}
+
+func (t *TeamInvitationService) checkMemberSlot(tx *gorm.DB, team *entity.Team, extra int) error {
+	rule, err := getCompetitionRule(tx, t.CompetitionRepository, team.CompetitionID)
+	if err != nil {
+		return err
+	}
+
+	members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	pending, err := t.InvitationRepository.CountPendingInvitations(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	if len(members)+int(pending)+extra > rule.MaxTeamMembers {
+		return fmt.Errorf("%w: maximum of %d team members allowed", model.ErrTeamMemberLimit, rule.MaxTeamMembers)
+	}
+
+	return nil
+}
+
+func (t *TeamInvitationService) checkInviteeStudentNumber(tx *gorm.DB, team *entity.Team, user *entity.User) error {
+	leader, err := t.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	studentNumbers := []string{leader.StudentNumber, user.StudentNumber}
+	for _, v := range members {
+		studentNumbers = append(studentNumbers, v.StudentNumber)
+	}
+
+	return checkDuplicateParticipants(tx, t.TeamRepository, t.CompetitionRepository, team.TeamID, team.CompetitionID, studentNumbers)
+}
+
+// invitationExpiry membaca masa berlaku undangan dalam jam dari TEAM_INVITATION_EXP_TIME, default 72 jam.
+func invitationExpiry() time.Duration {
+	expiresIn, err := strconv.Atoi(os.Getenv("TEAM_INVITATION_EXP_TIME"))
+	if err != nil || expiresIn <= 0 {
+		expiresIn = 72
+	}
+
+	return time.Duration(expiresIn) * time.Hour
+}
+
+func queueInvitationEmail(tx *gorm.DB, outboxRepository repository.IEmailOutboxRepository, leader *entity.User, team *entity.Team, invitation *entity.TeamInvitation) error {
+	return queueEmail(tx, outboxRepository, invitation.Email, mail.TemplateTeamInvitation, mail.TeamInvitationData{
+		LeaderName: leader.FullName,
+		TeamName:   team.TeamName,
+		ExpiresAt:  invitation.ExpiresAt.Format("02 January 2006 15:04"),
+	})
+}
+
+func toInvitationResponse(invitation entity.TeamInvitation) model.InvitationResponse {
+	return model.InvitationResponse{
+		InvitationID: invitation.InvitationID,
+		TeamID:       invitation.TeamID,
+		TeamName:     invitation.Team.TeamName,
+		Email:        invitation.Email,
+		Status:       invitation.Status,
+		ExpiresAt:    invitation.ExpiresAt,
+		RespondedAt:  invitation.RespondedAt,
+		CreatedAt:    invitation.CreatedAt,
+	}
+}
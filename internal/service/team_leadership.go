@@ -0,0 +1,391 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ITeamLeadershipService interface {
+	TransferLeadership(userID uuid.UUID, param model.TransferLeadershipRequest) error
+	TransferTeamLeadership(teamID uuid.UUID, param model.TransferLeadershipRequest) error
+	DissolveTeam(userID uuid.UUID) error
+	DissolveTeamByID(teamID uuid.UUID) error
+}
+
+type TeamLeadershipService struct {
+	db                   *gorm.DB
+	TeamRepository       repository.ITeamRepository
+	UserRepository       repository.IUserRepository
+	SubmissionRepository repository.ISubmissionRepository
+	InvitationRepository repository.ITeamInvitationRepository
+	DocumentRepository   repository.IMemberDocumentRepository
+	PaymentRepository    repository.IPaymentRepository
+	VoucherRepository    repository.IVoucherRepository
+	FormRepository       repository.IRegistrationFormRepository
+	WaitlistService      IWaitlistService
+	Storage              storage.Interface
+}
+
+func NewTeamLeadershipService(teamRepository repository.ITeamRepository, userRepository repository.IUserRepository, submissionRepository repository.ISubmissionRepository, invitationRepository repository.ITeamInvitationRepository, documentRepository repository.IMemberDocumentRepository, paymentRepository repository.IPaymentRepository, voucherRepository repository.IVoucherRepository, formRepository repository.IRegistrationFormRepository, waitlistService IWaitlistService, storage storage.Interface) ITeamLeadershipService {
+	return &TeamLeadershipService{
+		db:                   mariadb.Connection,
+		TeamRepository:       teamRepository,
+		UserRepository:       userRepository,
+		SubmissionRepository: submissionRepository,
+		InvitationRepository: invitationRepository,
+		DocumentRepository:   documentRepository,
+		PaymentRepository:    paymentRepository,
+		VoucherRepository:    voucherRepository,
+		FormRepository:       formRepository,
+		WaitlistService:      waitlistService,
+		Storage:              storage,
+	}
+}
+
+func (t *TeamLeadershipService) TransferLeadership(userID uuid.UUID, param model.TransferLeadershipRequest) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.getLeaderTeam(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	return t.transferLeadership(tx, team, param)
+}
+
+func (t *TeamLeadershipService) TransferTeamLeadership(teamID uuid.UUID, param model.TransferLeadershipRequest) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	return t.transferLeadership(tx, team, param)
+}
+
+func (t *TeamLeadershipService) DissolveTeam(userID uuid.UUID) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.getLeaderTeam(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	return t.dissolveTeam(tx, team)
+}
+
+func (t *TeamLeadershipService) DissolveTeamByID(teamID uuid.UUID) error {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	return t.dissolveTeam(tx, team)
+}
+
+// transferLeadership menukar posisi ketua dengan anggota yang punya akun, KTM keduanya ikut ditukar.
+// Ketua lama tetap menjadi anggota kecuali diminta dikeluarkan dari tim.
+func (t *TeamLeadershipService) transferLeadership(tx *gorm.DB, team *entity.Team, param model.TransferLeadershipRequest) error {
+	err := ensureTeamEditable(team)
+	if err != nil {
+		return err
+	}
+
+	members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	var newLeader *entity.TeamMember
+	for _, v := range members {
+		if v.TeamMemberID == param.TeamMemberID {
+			newLeader = v
+			break
+		}
+	}
+	if newLeader == nil || newLeader.UserID == nil {
+		return model.ErrNewLeaderNotMember
+	}
+
+	err = releasePlaceholderTeam(tx, t.TeamRepository, *newLeader.UserID)
+	if err != nil {
+		return err
+	}
+
+	formerLeader, err := t.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = t.TeamRepository.DeleteTeamMember(tx, newLeader.TeamMemberID)
+	if err != nil {
+		return err
+	}
+
+	err = t.TeamRepository.UpdateTeamLeader(tx, team.TeamID, *newLeader.UserID)
+	if err != nil {
+		return err
+	}
+	team.UserID = *newLeader.UserID
+
+	document, err := t.DocumentRepository.GetDocumentByOwner(tx, newLeader.TeamMemberID, entity.DocumentKTM)
+	if err == nil {
+		document.OwnerID = *newLeader.UserID
+		document.OwnerType = entity.DocumentOwnerLeader
+
+		err = t.DocumentRepository.UpdateDocument(tx, document)
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var removedDocuments []entity.MemberDocument
+	if param.RemoveFormerLeader {
+		removedDocuments, err = t.DocumentRepository.DeleteDocumentsByOwnerIDs(tx, []uuid.UUID{formerLeader.UserID})
+		if err != nil {
+			return err
+		}
+
+		// ketua lama kembali memiliki tim kosong seperti akun yang baru mendaftar
+		err = t.TeamRepository.CreateTeam(tx, newPlaceholderTeam(formerLeader.UserID))
+		if err != nil {
+			return err
+		}
+	} else {
+		member := &entity.TeamMember{
+			TeamMemberID:  uuid.New(),
+			MemberName:    formerLeader.FullName,
+			StudentNumber: formerLeader.StudentNumber,
+			TeamID:        team.TeamID,
+			UserID:        &formerLeader.UserID,
+		}
+
+		err = t.TeamRepository.CreateTeamMember(tx, member)
+		if err != nil {
+			return err
+		}
+
+		document, err := t.DocumentRepository.GetDocumentByOwner(tx, formerLeader.UserID, entity.DocumentKTM)
+		if err == nil {
+			document.OwnerID = member.TeamMemberID
+			document.OwnerType = entity.DocumentOwnerMember
+
+			err = t.DocumentRepository.UpdateDocument(tx, document)
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	err = syncTeamVerification(tx, t.DocumentRepository, t.TeamRepository, t.PaymentRepository, team)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	for _, v := range removedDocuments {
+		deleteStoredFile(t.Storage, v.FileKey)
+	}
+
+	return nil
+}
+
+// dissolveTeam membubarkan tim yang belum terverifikasi sehingga nama tim dapat dipakai lagi.
+// Anggota, progres, dokumen, undangan, pembayaran yang belum diproses, dan voucher tim ikut dibersihkan.
+func (t *TeamLeadershipService) dissolveTeam(tx *gorm.DB, team *entity.Team) error {
+	err := ensureTeamEditable(team)
+	if err != nil {
+		return err
+	}
+
+	if team.TeamStatus == "terverifikasi" {
+		return model.ErrTeamNotDissolvable
+	}
+
+	payment, err := t.PaymentRepository.GetPaymentByTeamID(tx, team.TeamID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if payment != nil && (payment.Status == "terverifikasi" || payment.Status == "diproses") {
+		return model.ErrTeamNotDissolvable
+	}
+
+	_, err = t.PaymentRepository.GetPendingCharge(tx, team.TeamID)
+	if err == nil {
+		return model.ErrTeamNotDissolvable
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	members, err := t.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	ownerIDs := []uuid.UUID{team.UserID}
+	for _, v := range members {
+		ownerIDs = append(ownerIDs, v.TeamMemberID)
+	}
+
+	removedDocuments, err := t.DocumentRepository.DeleteDocumentsByOwnerIDs(tx, ownerIDs)
+	if err != nil {
+		return err
+	}
+
+	err = t.TeamRepository.DeleteAllTeamMembers(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	progresses, err := t.SubmissionRepository.DeleteSubmissionsByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = t.PaymentRepository.DeletePaymentsByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = releaseRedemption(tx, t.VoucherRepository, team)
+	if err != nil {
+		return err
+	}
+
+	err = t.InvitationRepository.DeleteInvitationsByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	answers, err := t.FormRepository.DeleteAnswersByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = t.WaitlistService.CancelTeamWaitlist(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = t.TeamRepository.DeleteTeam(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	// ketua dan anggota yang punya akun kembali memiliki tim kosong seperti akun yang baru mendaftar
+	err = t.TeamRepository.CreateTeam(tx, newPlaceholderTeam(team.UserID))
+	if err != nil {
+		return err
+	}
+
+	for _, v := range members {
+		if v.UserID == nil {
+			continue
+		}
+
+		err = t.TeamRepository.CreateTeam(tx, newPlaceholderTeam(*v.UserID))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = t.WaitlistService.PromoteWaitlist(tx, team.CompetitionID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	for _, v := range removedDocuments {
+		deleteStoredFile(t.Storage, v.FileKey)
+	}
+	for _, v := range progresses {
+		deleteStoredFile(t.Storage, v.FileKey)
+		for _, version := range v.Versions {
+			deleteStoredFile(t.Storage, version.FileKey)
+		}
+	}
+	if payment != nil {
+		deleteStoredFile(t.Storage, payment.ProofFile)
+	}
+	for _, v := range answers {
+		if v.Field.FieldType == entity.FieldFile {
+			deleteStoredFile(t.Storage, v.Value)
+		}
+	}
+
+	return nil
+}
+
+func (t *TeamLeadershipService) getLeaderTeam(tx *gorm.DB, userID uuid.UUID) (*entity.Team, error) {
+	team, err := t.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotTeamLeader
+		}
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// newPlaceholderTeam membuat tim kosong yang dimiliki setiap akun sejak registrasi.
+func newPlaceholderTeam(userID uuid.UUID) *entity.Team {
+	return &entity.Team{
+		TeamID:          uuid.New(),
+		TeamName:        "",
+		TeamStatus:      "belum terverifikasi",
+		UserID:          userID,
+		CompetitionID:   entity.UnassignedCompetitionID,
+		LifecycleStatus: entity.TeamDraft,
+	}
+}
+
+// releasePlaceholderTeam menghapus tim kosong milik user, tim yang sudah dipakai membuat user dianggap sudah punya tim.
+func releasePlaceholderTeam(tx *gorm.DB, teamRepository repository.ITeamRepository, userID uuid.UUID) error {
+	team, err := teamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	members, err := teamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	if team.CompetitionID != entity.UnassignedCompetitionID || len(members) > 0 {
+		return model.ErrAlreadyInTeam
+	}
+
+	return teamRepository.DeleteTeam(tx, team.TeamID)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// teamTransitions berisi perpindahan tahapan tim yang diizinkan, withdrawn dan disqualified bersifat final.
+var teamTransitions = map[string][]string{
+	entity.TeamDraft:        {entity.TeamRegistered, entity.TeamWithdrawn},
+	entity.TeamRegistered:   {entity.TeamLocked, entity.TeamWithdrawn, entity.TeamDisqualified},
+	entity.TeamLocked:       {entity.TeamRegistered, entity.TeamWithdrawn, entity.TeamDisqualified},
+	entity.TeamWithdrawn:    {},
+	entity.TeamDisqualified: {},
+}
+
+type ITeamLifecycleService interface {
+	WithdrawTeam(userID uuid.UUID, param model.WithdrawTeamRequest) (*model.TeamLifecycleResponse, error)
+	UpdateLifecycle(actor *entity.User, teamID uuid.UUID, param model.UpdateLifecycleRequest) (*model.TeamLifecycleResponse, error)
+	GetTeamLifecycle(teamID uuid.UUID) (*model.TeamLifecycleResponse, error)
+}
+
+type TeamLifecycleService struct {
+	db              *gorm.DB
+	TeamRepository  repository.ITeamRepository
+	WaitlistService IWaitlistService
+}
+
+func NewTeamLifecycleService(teamRepository repository.ITeamRepository, waitlistService IWaitlistService) ITeamLifecycleService {
+	return &TeamLifecycleService{
+		db:              mariadb.Connection,
+		TeamRepository:  teamRepository,
+		WaitlistService: waitlistService,
+	}
+}
+
+func (t *TeamLifecycleService) WithdrawTeam(userID uuid.UUID, param model.WithdrawTeamRequest) (*model.TeamLifecycleResponse, error) {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotTeamLeader
+		}
+		return nil, err
+	}
+
+	err = changeTeamLifecycle(tx, t.TeamRepository, team, entity.TeamWithdrawn, param.Reason, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.releaseSlot(tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.getTeamLifecycle(tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (t *TeamLifecycleService) UpdateLifecycle(actor *entity.User, teamID uuid.UUID, param model.UpdateLifecycleRequest) (*model.TeamLifecycleResponse, error) {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = changeTeamLifecycle(tx, t.TeamRepository, team, param.Status, param.Reason, &actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.releaseSlot(tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.getTeamLifecycle(tx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (t *TeamLifecycleService) GetTeamLifecycle(teamID uuid.UUID) (*model.TeamLifecycleResponse, error) {
+	tx := t.db.Begin()
+	defer tx.Rollback()
+
+	team, err := t.TeamRepository.GetTeamByID(tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.getTeamLifecycle(tx, team)
+}
+
+// releaseSlot mengeluarkan tim yang mundur atau didiskualifikasi dari antrean dan memajukan antrean lombanya.
+func (t *TeamLifecycleService) releaseSlot(tx *gorm.DB, team *entity.Team) error {
+	if isTeamActive(team) {
+		return nil
+	}
+
+	err := t.WaitlistService.CancelTeamWaitlist(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	return t.WaitlistService.PromoteWaitlist(tx, team.CompetitionID)
+}
+
+func (t *TeamLifecycleService) getTeamLifecycle(tx *gorm.DB, team *entity.Team) (*model.TeamLifecycleResponse, error) {
+	histories, err := t.TeamRepository.GetLifecycleHistory(tx, team.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.TeamLifecycleResponse{
+		TeamID:             team.TeamID,
+		TeamName:           team.TeamName,
+		LifecycleStatus:    team.LifecycleStatus,
+		LifecycleReason:    team.LifecycleReason,
+		LifecycleChangedAt: team.LifecycleChangedAt,
+		History:            []model.LifecycleHistoryResponse{},
+	}
+	for _, v := range histories {
+		res.History = append(res.History, model.LifecycleHistoryResponse{
+			FromStatus: v.FromStatus,
+			ToStatus:   v.ToStatus,
+			Reason:     v.Reason,
+			ChangedBy:  v.ChangedBy,
+			CreatedAt:  v.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// changeTeamLifecycle memindahkan tahapan tim sesuai teamTransitions dan mencatat riwayatnya.
+func changeTeamLifecycle(tx *gorm.DB, teamRepository repository.ITeamRepository, team *entity.Team, status string, reason string, changedBy *uuid.UUID) error {
+	if status == entity.TeamDisqualified && reason == "" {
+		return model.ErrLifecycleReasonRequired
+	}
+
+	from := team.LifecycleStatus
+	if from == "" {
+		from = entity.TeamDraft
+	}
+
+	if !contains(teamTransitions[from], status) {
+		return model.ErrInvalidLifecycleTransition
+	}
+
+	now := time.Now()
+	team.LifecycleStatus = status
+	team.LifecycleReason = reason
+	team.LifecycleChangedAt = &now
+
+	err := teamRepository.UpdateLifecycle(tx, team)
+	if err != nil {
+		return err
+	}
+
+	return teamRepository.CreateLifecycleHistory(tx, &entity.TeamLifecycleHistory{
+		TeamID:     team.TeamID,
+		FromStatus: from,
+		ToStatus:   status,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+	})
+}
+
+// registerTeam menandai tim draft sebagai terdaftar, tim yang sudah terdaftar dibiarkan.
+func registerTeam(tx *gorm.DB, teamRepository repository.ITeamRepository, team *entity.Team, changedBy *uuid.UUID) error {
+	if team.LifecycleStatus != entity.TeamDraft && team.LifecycleStatus != "" {
+		return nil
+	}
+
+	return changeTeamLifecycle(tx, teamRepository, team, entity.TeamRegistered, "", changedBy)
+}
+
+// lockTeam mengunci tim terdaftar agar anggotanya tidak bisa diubah lagi tanpa dibuka admin.
+func lockTeam(tx *gorm.DB, teamRepository repository.ITeamRepository, team *entity.Team, reason string, changedBy *uuid.UUID) error {
+	if team.LifecycleStatus != entity.TeamRegistered {
+		return nil
+	}
+
+	return changeTeamLifecycle(tx, teamRepository, team, entity.TeamLocked, reason, changedBy)
+}
+
+// updateTeamStatus memperbarui status verifikasi tim, tim yang terverifikasi langsung dikunci.
+func updateTeamStatus(tx *gorm.DB, teamRepository repository.ITeamRepository, team *entity.Team, status string, changedBy *uuid.UUID) error {
+	err := teamRepository.UpdateTeamStatus(tx, model.ReqUpdateStatusTeam{
+		TeamID:        team.TeamID.String(),
+		PaymentStatus: status,
+	})
+	if err != nil {
+		return err
+	}
+
+	team.TeamStatus = status
+	if status != "terverifikasi" {
+		return nil
+	}
+
+	return lockTeam(tx, teamRepository, team, "team verified", changedBy)
+}
+
+func isTeamActive(team *entity.Team) bool {
+	return team.LifecycleStatus != entity.TeamWithdrawn && team.LifecycleStatus != entity.TeamDisqualified
+}
+
+// ensureTeamActive menolak aksi peserta untuk tim yang sudah mundur atau didiskualifikasi.
+func ensureTeamActive(team *entity.Team) error {
+	if !isTeamActive(team) {
+		return model.ErrTeamInactive
+	}
+
+	return nil
+}
+
+// ensureTeamEditable menolak perubahan anggota pada tim yang terkunci maupun tidak aktif.
+func ensureTeamEditable(team *entity.Team) error {
+	err := ensureTeamActive(team)
+	if err != nil {
+		return err
+	}
+
+	if team.LifecycleStatus == entity.TeamLocked {
+		return model.ErrTeamLocked
+	}
+
+	return nil
+}
@@ -10,8 +10,7 @@ import (
 	"itfest-2025/pkg/database/mariadb"
 	"itfest-2025/pkg/jwt"
 	"itfest-2025/pkg/mail"
-	"itfest-2025/pkg/supabase"
-	"mime/multipart"
+	"itfest-2025/pkg/storage"
 	"os"
 	"strconv"
 	"time"
@@ -23,15 +22,13 @@ import (
 type IUserService interface {
 	Register(param *model.UserRegister) (model.RegisterResponse, error)
 	Login(param model.UserLogin) (model.LoginResponse, error)
-	UploadPayment(userID uuid.UUID, file *multipart.FileHeader) (*model.UploadPaymentResponse, error)
-	UploadKTM(userID uuid.UUID, file *multipart.FileHeader) error
 	VerifyUser(param model.VerifyUser) error
 	UpdateProfile(userID uuid.UUID, param model.UpdateProfile) (*model.UpdateProfile, error)
 	GetUserProfile(userID uuid.UUID) (model.UserProfile, error)
 	GetMyTeamProfile(userID uuid.UUID) (*model.UserTeamProfile, error)
-	ChangePassword(email string) (string, error)
+	ChangePassword(email string) error
 	ChangePasswordAfterVerify(param model.ResetPasswordRequest) error
-	VerifyOtpChangePassword(param model.VerifyToken) error
+	VerifyOtpChangePassword(param model.VerifyToken) (*model.VerifyTokenResponse, error)
 	CompetitionRegistration(userID uuid.UUID, competitionID int, param model.CompetitionRegistrationRequest) error
 	GetUserPaymentStatus() ([]*model.GetUserPaymentStatus, error)
 	GetTotalParticipant() (*model.GetTotalParticipant, error)
@@ -47,10 +44,16 @@ type UserService struct {
 	CompetitionRepository repository.ICompetitionRepository
 	BCrypt                bcrypt.Interface
 	JwtAuth               jwt.Interface
-	Supabase              supabase.Interface
+	Storage               storage.Interface
+	AuthService           IAuthService
+	VoucherService        IVoucherService
+	WaitlistService       IWaitlistService
+	FormRepository        repository.IRegistrationFormRepository
+	OutboxRepository      repository.IEmailOutboxRepository
+	PaymentRepository     repository.IPaymentRepository
 }
 
-func NewUserService(userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, otpRepository repository.IOtpRepository, competitionRepository repository.ICompetitionRepository, bcrypt bcrypt.Interface, jwtAuth jwt.Interface, supabase supabase.Interface, teamService ITeamService) IUserService {
+func NewUserService(userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, otpRepository repository.IOtpRepository, competitionRepository repository.ICompetitionRepository, bcrypt bcrypt.Interface, jwtAuth jwt.Interface, storage storage.Interface, teamService ITeamService, authService IAuthService, voucherService IVoucherService, waitlistService IWaitlistService, formRepository repository.IRegistrationFormRepository, outboxRepository repository.IEmailOutboxRepository, paymentRepository repository.IPaymentRepository) IUserService {
 	return &UserService{
 		db:                    mariadb.Connection,
 		UserRepository:        userRepository,
@@ -59,8 +62,14 @@ func NewUserService(userRepository repository.IUserRepository, teamRepository re
 		CompetitionRepository: competitionRepository,
 		BCrypt:                bcrypt,
 		JwtAuth:               jwtAuth,
-		Supabase:              supabase,
+		Storage:               storage,
 		TeamService:           teamService,
+		AuthService:           authService,
+		VoucherService:        voucherService,
+		WaitlistService:       waitlistService,
+		FormRepository:        formRepository,
+		OutboxRepository:      outboxRepository,
+		PaymentRepository:     paymentRepository,
 	}
 }
 
@@ -97,7 +106,7 @@ func (u *UserService) Register(param *model.UserRegister) (model.RegisterRespons
 		Email:         param.Email,
 		Password:      hash,
 		StatusAccount: "inactive",
-		RoleID:        2,
+		RoleID:        entity.RoleParticipant,
 	}
 
 	_, err = u.UserRepository.CreateUser(tx, user)
@@ -105,20 +114,12 @@ func (u *UserService) Register(param *model.UserRegister) (model.RegisterRespons
 		return result, err
 	}
 
-	token, err := u.JwtAuth.CreateJWTToken(user.UserID, false)
+	token, err := u.AuthService.IssueToken(tx, user)
 	if err != nil {
-		return result, errors.New("failed to create token")
-	}
-
-	team := &entity.Team{
-		TeamID:        uuid.New(),
-		TeamName:      "",
-		TeamStatus:    "belum terverifikasi",
-		UserID:        user.UserID,
-		CompetitionID: 1,
+		return result, err
 	}
 
-	err = u.TeamRepository.CreateTeam(tx, team)
+	err = u.TeamRepository.CreateTeam(tx, newPlaceholderTeam(user.UserID))
 	if err != nil {
 		return result, err
 	}
@@ -135,103 +136,7 @@ func (u *UserService) Register(param *model.UserRegister) (model.RegisterRespons
 		return result, err
 	}
 
-	err = mail.SendEmail(user.Email, "OTP Verification", fmt.Sprintf(`
-		<!DOCTYPE html>
-		<html lang="id">
-		<head>
-			<style>
-				body, table, td, a {
-					-webkit-text-size-adjust: 100%%;
-					-ms-text-size-adjust: 100%%;
-				}
-
-				table, td {
-					mso-table-lspace: 0pt;
-					mso-table-rspace: 0pt;
-				}
-
-				img {
-					-ms-interpolation-mode: bicubic;
-					border: 0;
-					height: auto;
-					line-height: 100%%;
-					outline: none;
-					text-decoration: none;
-				}
-
-				body {
-					height: 100%% !important;
-					margin: 0 !important;
-					padding: 0 !important;
-					width: 100%% !important;
-				}
-			</style>
-		</head>
-
-		<body style="margin: 0; padding: 0; background-color: #030D35; background: linear-gradient(to bottom, #030D35 0%%, #19217C 100%%);">
-			<table border="0" cellpadding="0" cellspacing="0" width="100%%" style="max-width: 600px; margin: 0 auto;">
-				<tr>
-					<td align="center" valign="top" style="padding: 40px 20px 20px 20px;">
-						<table border="0" cellpadding="0" cellspacing="0" width="100%%">
-
-							<tr>
-								<td align="center" style="padding-bottom: 20px;">
-									<img src="https://i.imgur.com/3fcE9Ll.png" width="300" alt="IT FEST 2025 Logo" style="display: block; width: 300px; max-width: 100%%; min-width: 100px; font-family: Arial, sans-serif; color: #ffffff;">
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 20px 0;">
-									<img src="https://i.imgur.com/dgvL3Gf.png" width="200" alt="Email Icon" style="display: block; width: 200px;">
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 10px 0; font-family: Arial, sans-serif; font-size: 24px; font-weight: bold; color: #ffffff;">
-									Kode Verifikasi Anda
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 10px 20px; font-family: Arial, sans-serif; font-size: 16px; line-height: 1.5; color: #d1d1d1;">
-									Gunakan kode di bawah ini untuk menyelesaikan proses verifikasi email Anda. Kode ini hanya berlaku selama 5 menit.
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 30px 0;">
-									<table border="0" cellspacing="0" cellpadding="0" width="100%%" style="max-width: 576px;">
-										<tr>
-											<td align="center" style="border-radius: 8px; background-color: #072547; padding: 20px 25px;">
-												<div style="font-family: Arial, sans-serif; font-size: 36px; font-weight: bold; color: #85FFF5; letter-spacing: 5px; text-shadow: 0px 0px 15px rgba(255,255,255,0.6);">
-													%s
-												</div>
-											</td>
-										</tr>
-									</table>
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 30px 20px 20px 20px; font-family: Arial, sans-serif; font-size: 14px; line-height: 1.5; color: #a0a0a0;">
-									Jika Anda tidak merasa mendaftar untuk IT FEST, abaikan saja email ini.
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 0 20px 40px 20px; font-family: Arial, sans-serif; font-size: 12px; line-height: 1.5; color: #a0a0a0 !important;">
-									Keluarga Besar Mahasiswa Departemen Sistem Informasi<br>
-									Universitas Brawijaya
-								</td>
-							</tr>
-
-						</table>
-					</td>
-				</tr>
-			</table>
-		</body>
-		</html>
-		`, code))
+	err = queueEmail(tx, u.OutboxRepository, user.Email, mail.TemplateOtpVerification, mail.OtpData{Code: code})
 
 	if err != nil {
 		return result, err
@@ -242,14 +147,14 @@ func (u *UserService) Register(param *model.UserRegister) (model.RegisterRespons
 		return result, err
 	}
 
-	result.Token = token
+	result.Token = token.Token
+	result.RefreshToken = token.RefreshToken
+	result.ExpiresAt = token.ExpiresAt
 
 	return result, nil
 }
 
 func (u *UserService) Login(param model.UserLogin) (model.LoginResponse, error) {
-	var isAdmin bool
-
 	tx := u.db.Begin()
 	defer tx.Rollback()
 
@@ -262,121 +167,24 @@ func (u *UserService) Login(param model.UserLogin) (model.LoginResponse, error)
 		return result, errors.New("email or password is wrong")
 	}
 
-	if user.RoleID == 1 {
-		isAdmin = true
-	} else {
-		isAdmin = false
-	}
-
 	err = u.BCrypt.CompareAndHashPassword(user.Password, param.Password)
 	if err != nil {
 		return result, errors.New("email or password is wrong")
 	}
 
-	token, err := u.JwtAuth.CreateJWTToken(user.UserID, isAdmin)
+	result, err = u.AuthService.IssueToken(tx, user)
 	if err != nil {
-		return result, errors.New("failed to create token")
+		return result, err
 	}
 
-	result.Token = token
-
 	err = tx.Commit().Error
 	if err != nil {
-		return result, nil
+		return result, err
 	}
 
 	return result, nil
 }
 
-func (u *UserService) UploadPayment(userID uuid.UUID, file *multipart.FileHeader) (*model.UploadPaymentResponse, error) {
-	maxSize := int64(1024 * 1024)
-	if file.Size > maxSize {
-		return nil, errors.New("file size exceeds maximum limit of 1MB")
-	}
-
-	tx := u.db.Begin()
-	defer tx.Rollback()
-
-	user, err := u.UserRepository.GetUser(model.UserParam{
-		UserID: userID,
-	})
-	if err != nil {
-		return nil, errors.New("user not found")
-	}
-
-	team, err := u.TeamRepository.GetTeamByID(tx, user.Team.TeamID)
-	if err != nil {
-		return nil, err
-	}
-
-	paymentURL, err := u.Supabase.UploadFile(file)
-	if err != nil {
-		return nil, err
-	}
-
-	user.PaymentTransc = paymentURL
-
-	err = u.UserRepository.UpdateUser(tx, user)
-	if err != nil {
-		return nil, err
-	}
-
-	team.TeamStatus = "diproses"
-
-	err = u.TeamRepository.UpdateTeam(tx, team)
-	if err != nil {
-		return nil, err
-	}
-
-	err = tx.Commit().Error
-	if err != nil {
-		return nil, err
-	}
-
-	res := &model.UploadPaymentResponse{
-		Status: team.TeamStatus,
-	}
-
-	return res, nil
-}
-
-func (u *UserService) UploadKTM(userID uuid.UUID, file *multipart.FileHeader) error {
-	maxSize := int64(1024 * 1024)
-	if file.Size > maxSize {
-		return errors.New("file size exceeds maximum limit of 1MB")
-	}
-
-	tx := u.db.Begin()
-	defer tx.Rollback()
-
-	user, err := u.UserRepository.GetUser(model.UserParam{
-		UserID: userID,
-	})
-	if err != nil {
-		return err
-	}
-
-	ktmURL, err := u.Supabase.UploadFile(file)
-	if err != nil {
-		return err
-	}
-
-	user.StudentCardLink = ktmURL
-
-	err = u.UserRepository.UpdateUser(tx, user)
-	if err != nil {
-		return err
-	}
-
-	err = tx.Commit().Error
-	if err != nil {
-		return err
-	}
-
-	return nil
-
-}
-
 func (u *UserService) VerifyUser(param model.VerifyUser) error {
 	tx := u.db.Begin()
 	defer tx.Rollback()
@@ -501,11 +309,22 @@ func (u *UserService) GetMyTeamProfile(userID uuid.UUID) (*model.UserTeamProfile
 		return nil, err
 	}
 
-	team, err := u.TeamRepository.GetTeamByUserID(tx, userID)
+	team, err := u.TeamRepository.GetTeamForUser(tx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	// anggota yang tertaut akun melihat profil tim atas nama ketuanya
+	leader := user
+	if team.UserID != userID {
+		leader, err = u.UserRepository.GetUser(model.UserParam{
+			UserID: team.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	members, err := u.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
 	if err != nil {
 		return nil, err
@@ -516,6 +335,7 @@ func (u *UserService) GetMyTeamProfile(userID uuid.UUID) (*model.UserTeamProfile
 		memberResponse = append(memberResponse, model.MemberResponse{
 			FullName:      v.MemberName,
 			StudentNumber: v.StudentNumber,
+			UserID:        v.UserID,
 		})
 	}
 
@@ -525,7 +345,7 @@ func (u *UserService) GetMyTeamProfile(userID uuid.UUID) (*model.UserTeamProfile
 	}
 
 	dl := time.Time{}
-	if team.CompetitionID > 1 {
+	if team.CompetitionID != entity.UnassignedCompetitionID {
 		stage, err := u.TeamService.GetProgressByUserID(userID)
 		if err == nil && stage != nil && len(stage.Stages) > 0 {
 			for _, s := range stage.Stages {
@@ -540,11 +360,13 @@ func (u *UserService) GetMyTeamProfile(userID uuid.UUID) (*model.UserTeamProfile
 	}
 
 	TeamProfileResponse := &model.UserTeamProfile{
-		LeaderName:          user.FullName,
+		LeaderName:          leader.FullName,
 		TeamName:            team.TeamName,
-		StudentNumber:       user.StudentNumber,
+		StudentNumber:       leader.StudentNumber,
 		Deadline:            dl,
 		CompetitionCategory: competititon.CompetitionName,
+		LifecycleStatus:     team.LifecycleStatus,
+		LifecycleReason:     team.LifecycleReason,
 		Members:             memberResponse,
 	}
 
@@ -557,7 +379,8 @@ func (u *UserService) GetMyTeamProfile(userID uuid.UUID) (*model.UserTeamProfile
 
 }
 
-func (u *UserService) ChangePassword(email string) (string, error) {
+// ChangePassword hanya mengirim OTP, token reset baru diberikan setelah OTP diverifikasi.
+func (u *UserService) ChangePassword(email string) error {
 	tx := u.db.Begin()
 	defer tx.Rollback()
 
@@ -565,7 +388,7 @@ func (u *UserService) ChangePassword(email string) (string, error) {
 		Email: email,
 	})
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	otp := mail.GenerateCode()
@@ -575,173 +398,92 @@ func (u *UserService) ChangePassword(email string) (string, error) {
 		Code:   otp,
 	})
 	if err != nil {
-		return "", err
-	}
-
-	err = mail.SendEmail(user.Email, "OTP Atur Ulang Kata Sandi", fmt.Sprintf(`
-		<!DOCTYPE html>
-		<html lang="id">
-		<head>
-			<style>
-				body, table, td, a {
-					-webkit-text-size-adjust: 100%%;
-					-ms-text-size-adjust: 100%%;
-				}
-
-				table, td {
-					mso-table-lspace: 0pt;
-					mso-table-rspace: 0pt;
-				}
-
-				img {
-					-ms-interpolation-mode: bicubic;
-					border: 0;
-					height: auto;
-					line-height: 100%%;
-					outline: none;
-					text-decoration: none;
-				}
-
-				body {
-					height: 100%% !important;
-					margin: 0 !important;
-					padding: 0 !important;
-					width: 100%% !important;
-				}
-			</style>
-		</head>
-
-		<body style="margin: 0; padding: 0; background-color: #030D35; background: linear-gradient(to bottom, #030D35 0%%, #19217C 100%%);">
-			<table border="0" cellpadding="0" cellspacing="0" width="100%%" style="max-width: 600px; margin: 0 auto;">
-				<tr>
-					<td align="center" valign="top" style="padding: 40px 20px 20px 20px;">
-						<table border="0" cellpadding="0" cellspacing="0" width="100%%">
-
-							<tr>
-								<td align="center" style="padding-bottom: 20px;">
-									<img src="https://i.imgur.com/3fcE9Ll.png" width="300" alt="IT FEST 2025 Logo" style="display: block; width: 300px; max-width: 100%%; min-width: 100px; font-family: Arial, sans-serif; color: #ffffff;">
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 20px 0;">
-									<img src="https://i.imgur.com/dgvL3Gf.png" width="200" alt="Email Icon" style="display: block; width: 200px;">
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 10px 0; font-family: Arial, sans-serif; font-size: 24px; font-weight: bold; color: #ffffff;">
-									Kode Atur Ulang Kata Sandi Anda
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 10px 20px; font-family: Arial, sans-serif; font-size: 16px; line-height: 1.5; color: #d1d1d1;">
-									Kami menerima permintaan untuk mengatur ulang kata sandi akun IT FEST Anda. Gunakan kode di bawah ini pada halaman yang tersedia. Kode ini hanya berlaku selama 5 menit.
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 30px 0;">
-									<table border="0" cellspacing="0" cellpadding="0" width="100%%" style="max-width: 576px;">
-										<tr>
-											<td align="center" style="border-radius: 8px; background-color: #072547; padding: 20px 25px;">
-												<div style="font-family: Arial, sans-serif; font-size: 36px; font-weight: bold; color: #85FFF5; letter-spacing: 5px; text-shadow: 0px 0px 15px rgba(255,255,255,0.6);">
-													%s
-												</div>
-											</td>
-										</tr>
-									</table>
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 30px 20px 20px 20px; font-family: Arial, sans-serif; font-size: 14px; line-height: 1.5; color: #a0a0a0;">
-									Jika Anda tidak merasa mendaftar untuk IT FEST, abaikan saja email ini.
-								</td>
-							</tr>
-
-							<tr>
-								<td align="center" style="padding: 0 20px 40px 20px; font-family: Arial, sans-serif; font-size: 12px; line-height: 1.5; color: #a0a0a0 !important;">
-									Keluarga Besar Mahasiswa Departemen Sistem Informasi<br>
-									Universitas Brawijaya
-								</td>
-							</tr>
-
-						</table>
-					</td>
-				</tr>
-			</table>
-		</body>
-		</html>
-	`, otp))
-	if err != nil {
-		return "", err
-	}
-
-	jwtToken, err := u.JwtAuth.CreateJWTToken(user.UserID, false)
-	if err != nil {
-		return "", nil
+		return err
 	}
 
-	err = tx.Commit().Error
+	err = queueEmail(tx, u.OutboxRepository, user.Email, mail.TemplatePasswordReset, mail.OtpData{Code: otp})
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return jwtToken, nil
+	return tx.Commit().Error
 }
 
-func (u *UserService) VerifyOtpChangePassword(param model.VerifyToken) error {
+func (u *UserService) VerifyOtpChangePassword(param model.VerifyToken) (*model.VerifyTokenResponse, error) {
 	tx := u.db.Begin()
 	defer tx.Rollback()
 
+	user, err := u.UserRepository.GetUser(model.UserParam{
+		Email: param.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	otp, err := u.OtpRepository.GetOtp(tx, model.GetOtp{
-		UserID: param.UserID,
+		UserID: user.UserID,
 		Code:   param.OTP,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if otp.Code != param.OTP {
-		return errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
 	expiredTime, err := strconv.Atoi(os.Getenv("EXPIRED_OTP"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	expiredThreshold := time.Now().UTC().Add(-time.Duration(expiredTime) * time.Minute)
 	if otp.UpdatedAt.Before(expiredThreshold) {
-		return errors.New("token expired")
+		return nil, errors.New("token expired")
 	}
 
 	err = u.OtpRepository.DeleteOtp(tx, otp)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	resetToken, err := u.JwtAuth.CreateResetToken(user)
+	if err != nil {
+		return nil, err
 	}
 
 	err = tx.Commit().Error
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &model.VerifyTokenResponse{
+		ResetToken: resetToken,
+	}, nil
 }
 
+// ChangePasswordAfterVerify hanya menerima token reset, token menjadi tidak berlaku setelah dipakai
+// karena seluruh sesi dicabut dan TokenVersion user bertambah.
 func (u *UserService) ChangePasswordAfterVerify(param model.ResetPasswordRequest) error {
 	tx := u.db.Begin()
 	defer tx.Rollback()
 
+	claims, err := u.JwtAuth.ValidateToken(param.ResetToken)
+	if err != nil || claims.Purpose != jwt.PurposePasswordReset {
+		return model.ErrInvalidResetToken
+	}
+
 	user, err := u.UserRepository.GetUser(model.UserParam{
-		UserID: param.UserID,
+		UserID: claims.UserID,
 	})
 	if err != nil {
 		return err
 	}
 
+	if user.TokenVersion != claims.TokenVersion {
+		return model.ErrInvalidResetToken
+	}
+
 	if param.NewPassword != param.ConfirmPassword {
 		return errors.New("password mismatch")
 	}
@@ -763,6 +505,11 @@ func (u *UserService) ChangePasswordAfterVerify(param model.ResetPasswordRequest
 		return err
 	}
 
+	err = u.AuthService.RevokeAllSessions(tx, user.UserID)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit().Error
 	if err != nil {
 		return err
@@ -782,6 +529,16 @@ func (u *UserService) CompetitionRegistration(userID uuid.UUID, competitionID in
 		return err
 	}
 
+	competition, err := getRegistrableCompetition(tx, u.CompetitionRepository, competitionID)
+	if err != nil {
+		return err
+	}
+
+	err = checkRegistrationWindow(competition, time.Now())
+	if err != nil {
+		return err
+	}
+
 	user.FullName = param.FullName
 	user.StudentNumber = param.StudentNumber
 	user.University = param.University
@@ -797,12 +554,94 @@ func (u *UserService) CompetitionRegistration(userID uuid.UUID, competitionID in
 		return err
 	}
 
+	err = ensureTeamEditable(team)
+	if err != nil {
+		return err
+	}
+
+	members, err := u.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	studentNumbers := []string{user.StudentNumber}
+	for _, v := range members {
+		studentNumbers = append(studentNumbers, v.StudentNumber)
+	}
+
+	err = checkDuplicateParticipants(tx, u.TeamRepository, u.CompetitionRepository, team.TeamID, competitionID, studentNumbers)
+	if err != nil {
+		return err
+	}
+
+	err = saveRegistrationAnswers(tx, u.FormRepository, team.TeamID, competitionID, param.Answers)
+	if err != nil {
+		return err
+	}
+
+	previousCompetitionID := team.CompetitionID
+	if previousCompetitionID != competitionID {
+		_, err = u.CompetitionRepository.LockCompetition(tx, competitionID)
+		if err != nil {
+			return err
+		}
+
+		full, err := competitionFull(tx, u.TeamRepository, competition)
+		if err != nil {
+			return err
+		}
+
+		// hanya tim baru yang masuk daftar tunggu, tim yang pindah lomba tetap di lomba lamanya
+		if full && previousCompetitionID != entity.UnassignedCompetitionID {
+			return model.ErrCompetitionFull
+		} else if full {
+			position, err := u.WaitlistService.JoinWaitlist(tx, team, competitionID, param.VoucherCode)
+			if err != nil {
+				return err
+			}
+
+			err = tx.Commit().Error
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("%w (you are #%d on waitlist)", model.ErrCompetitionFull, position)
+		}
+	}
+
+	err = u.VoucherService.ApplyRegistrationPrice(tx, user, team, competition, param.VoucherCode)
+	if err != nil {
+		return err
+	}
+
 	team.CompetitionID = competitionID
 	err = u.TeamRepository.UpdateTeam(tx, team)
 	if err != nil {
 		return err
 	}
 
+	err = registerTeam(tx, u.TeamRepository, team, &userID)
+	if err != nil {
+		return err
+	}
+
+	// payment dibuat saat mendaftar agar peserta sudah tahu kode referensi sebelum transfer
+	_, err = getOrCreatePayment(tx, u.PaymentRepository, team, user)
+	if err != nil {
+		return err
+	}
+
+	err = u.WaitlistService.CancelTeamWaitlist(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	// tim yang pindah lomba melepas slotnya sehingga antrean lomba lama bisa maju
+	err = u.WaitlistService.PromoteWaitlist(tx, previousCompetitionID)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit().Error
 	if err != nil {
 		return err
@@ -823,15 +662,24 @@ func (u *UserService) GetUserPaymentStatus() ([]*model.GetUserPaymentStatus, err
 	}
 
 	for _, v := range users {
+		if !isTeamActive(&v.Team) {
+			continue
+		}
+
 		competition, err := u.CompetitionRepository.GetCompetitionByID(tx, v.Team.CompetitionID)
 		if err != nil {
 			continue
 		}
+
+		paymentURL, err := signedFileURL(u.Storage, v.PaymentTransc)
+		if err != nil {
+			return nil, err
+		}
 		res = append(res, &model.GetUserPaymentStatus{
 			FullName:        v.FullName,
 			StudentNumber:   v.StudentNumber,
 			Email:           v.Email,
-			PaymentTransc:   v.PaymentTransc,
+			PaymentTransc:   paymentURL,
 			TeamName:        v.Team.TeamName,
 			TeamStatus:      v.Team.TeamStatus,
 			CompetitionName: competition.CompetitionName,
@@ -842,12 +690,6 @@ func (u *UserService) GetUserPaymentStatus() ([]*model.GetUserPaymentStatus, err
 }
 
 func (u *UserService) GetTotalParticipant() (*model.GetTotalParticipant, error) {
-
-	var (
-		totalUIUX int
-		totalBP   int
-	)
-
 	tx := u.db.Begin()
 	defer tx.Rollback()
 
@@ -856,17 +698,30 @@ func (u *UserService) GetTotalParticipant() (*model.GetTotalParticipant, error)
 		return nil, err
 	}
 
+	competitions, err := u.CompetitionRepository.GetAllCompetitions(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[int]int64)
 	for _, v := range users {
-		if v.Team.CompetitionID == 2 {
-			totalUIUX++
-		} else if v.Team.CompetitionID == 3 {
-			totalBP++
+		if isTeamActive(&v.Team) {
+			totals[v.Team.CompetitionID]++
 		}
 	}
 
 	res := &model.GetTotalParticipant{
-		TotalUIUX: totalUIUX,
-		TotalBP:   totalBP,
+		Competitions: []model.CompetitionCount{},
+	}
+	res.TotalUIUX = int(totals[legacyUIUXCompetitionID])
+	res.TotalBP = int(totals[legacyBusinessCompetitionID])
+	for _, v := range competitions {
+		res.Total += totals[v.CompetitionID]
+		res.Competitions = append(res.Competitions, model.CompetitionCount{
+			CompetitionID:   v.CompetitionID,
+			CompetitionName: v.CompetitionName,
+			Total:           totals[v.CompetitionID],
+		})
 	}
 
 	return res, nil
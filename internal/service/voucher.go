@@ -0,0 +1,450 @@
+package service
+
+import (
+	"errors"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IVoucherService interface {
+	CreateVoucher(actor *entity.User, param model.CreateVoucherRequest) (*model.VoucherResponse, error)
+	UpdateVoucher(voucherID int, param model.UpdateVoucherRequest) (*model.VoucherResponse, error)
+	GetVouchers() ([]model.VoucherResponse, error)
+	GetVoucher(voucherID int) (*model.VoucherResponse, error)
+	GetRegistrationPrice(userID uuid.UUID, competitionID int, voucherCode string) (*model.RegistrationPriceResponse, error)
+	ApplyRegistrationPrice(tx *gorm.DB, user *entity.User, team *entity.Team, competition *entity.Competition, voucherCode string) error
+}
+
+type VoucherService struct {
+	db                    *gorm.DB
+	VoucherRepository     repository.IVoucherRepository
+	UserRepository        repository.IUserRepository
+	TeamRepository        repository.ITeamRepository
+	CompetitionRepository repository.ICompetitionRepository
+}
+
+func NewVoucherService(voucherRepository repository.IVoucherRepository, userRepository repository.IUserRepository, teamRepository repository.ITeamRepository, competitionRepository repository.ICompetitionRepository) IVoucherService {
+	return &VoucherService{
+		db:                    mariadb.Connection,
+		VoucherRepository:     voucherRepository,
+		UserRepository:        userRepository,
+		TeamRepository:        teamRepository,
+		CompetitionRepository: competitionRepository,
+	}
+}
+
+func (v *VoucherService) CreateVoucher(actor *entity.User, param model.CreateVoucherRequest) (*model.VoucherResponse, error) {
+	tx := v.db.Begin()
+	defer tx.Rollback()
+
+	if param.DiscountType == entity.DiscountPercent && param.DiscountValue > 100 {
+		return nil, model.ErrVoucherInvalidDiscount
+	}
+
+	code := normalizeVoucherCode(param.Code)
+	_, err := v.VoucherRepository.GetVoucherByCode(tx, code)
+	if err == nil {
+		return nil, model.ErrVoucherCodeTaken
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if param.CompetitionID != nil {
+		_, err = v.CompetitionRepository.GetCompetitionByID(tx, *param.CompetitionID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, model.ErrCompetitionNotFound
+			}
+			return nil, err
+		}
+	}
+
+	voucher := &entity.Voucher{
+		Code:                code,
+		CompetitionID:       param.CompetitionID,
+		DiscountType:        param.DiscountType,
+		DiscountValue:       param.DiscountValue,
+		MaxUses:             param.MaxUses,
+		ExpiresAt:           param.ExpiresAt,
+		AllowedUniversities: joinLines(param.AllowedUniversities),
+		IsActive:            true,
+		CreatedBy:           actor.UserID,
+	}
+
+	err = v.VoucherRepository.CreateVoucher(tx, voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := toVoucherResponse(*voucher, false)
+	return &res, nil
+}
+
+func (v *VoucherService) UpdateVoucher(voucherID int, param model.UpdateVoucherRequest) (*model.VoucherResponse, error) {
+	tx := v.db.Begin()
+	defer tx.Rollback()
+
+	voucher, err := v.getVoucher(tx, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	if param.DiscountType != "" {
+		voucher.DiscountType = param.DiscountType
+	}
+	if param.DiscountValue != nil {
+		voucher.DiscountValue = *param.DiscountValue
+	}
+	if param.MaxUses != nil {
+		voucher.MaxUses = *param.MaxUses
+	}
+	if param.ExpiresAt.Set {
+		voucher.ExpiresAt = param.ExpiresAt.Value
+	}
+	if param.AllowedUniversities != nil {
+		voucher.AllowedUniversities = joinLines(param.AllowedUniversities)
+	}
+	if param.IsActive != nil {
+		voucher.IsActive = *param.IsActive
+	}
+
+	if voucher.DiscountType == entity.DiscountPercent && voucher.DiscountValue > 100 {
+		return nil, model.ErrVoucherInvalidDiscount
+	}
+
+	err = v.VoucherRepository.UpdateVoucher(tx, voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := toVoucherResponse(*voucher, true)
+	return &res, nil
+}
+
+func (v *VoucherService) GetVouchers() ([]model.VoucherResponse, error) {
+	tx := v.db.Begin()
+	defer tx.Rollback()
+
+	vouchers, err := v.VoucherRepository.GetVouchers(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model.VoucherResponse, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		res = append(res, toVoucherResponse(voucher, false))
+	}
+
+	return res, nil
+}
+
+func (v *VoucherService) GetVoucher(voucherID int) (*model.VoucherResponse, error) {
+	tx := v.db.Begin()
+	defer tx.Rollback()
+
+	voucher, err := v.getVoucher(tx, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toVoucherResponse(*voucher, true)
+	return &res, nil
+}
+
+// GetRegistrationPrice menghitung harga registrasi tanpa memakai kuota voucher.
+func (v *VoucherService) GetRegistrationPrice(userID uuid.UUID, competitionID int, voucherCode string) (*model.RegistrationPriceResponse, error) {
+	tx := v.db.Begin()
+	defer tx.Rollback()
+
+	user, err := v.UserRepository.GetUser(model.UserParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := v.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	competition, err := getRegistrableCompetition(tx, v.CompetitionRepository, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	basePrice, _ := registrationBasePrice(team, competition, now)
+	res := &model.RegistrationPriceResponse{
+		CompetitionID: competition.CompetitionID,
+		BasePrice:     basePrice,
+		IsEarlyBird:   basePrice != competition.RegistrationFee,
+		FinalPrice:    basePrice,
+	}
+
+	if voucherCode == "" {
+		return res, nil
+	}
+
+	voucher, err := v.VoucherRepository.GetVoucherByCode(tx, normalizeVoucherCode(voucherCode))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+
+	redeemed := team.VoucherID != nil && *team.VoucherID == voucher.VoucherID
+	err = checkVoucher(voucher, competition.CompetitionID, user.University, now, redeemed)
+	if err != nil {
+		return nil, err
+	}
+
+	res.VoucherCode = voucher.Code
+	res.Discount = voucherDiscount(voucher, basePrice)
+	res.FinalPrice = basePrice - res.Discount
+
+	return res, nil
+}
+
+// ApplyRegistrationPrice menghitung dan menyimpan harga registrasi tim, termasuk memakai kuota voucher.
+// Voucher yang sebelumnya dipakai tim akan dilepas terlebih dahulu.
+func (v *VoucherService) ApplyRegistrationPrice(tx *gorm.DB, user *entity.User, team *entity.Team, competition *entity.Competition, voucherCode string) error {
+	if team.TeamStatus == "terverifikasi" && team.PricedAt != nil {
+		return model.ErrRegistrationPaid
+	}
+
+	now := time.Now()
+	basePrice, priced := registrationBasePrice(team, competition, now)
+	if priced && voucherCode == "" {
+		return nil
+	}
+
+	var voucher *entity.Voucher
+	var err error
+	if voucherCode != "" {
+		voucher, err = v.VoucherRepository.GetVoucherByCode(tx, normalizeVoucherCode(voucherCode))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrVoucherNotFound
+			}
+			return err
+		}
+
+		if priced && team.VoucherID != nil && *team.VoucherID == voucher.VoucherID {
+			return nil
+		}
+	}
+
+	err = releaseRedemption(tx, v.VoucherRepository, team)
+	if err != nil {
+		return err
+	}
+
+	team.BasePrice = basePrice
+	team.Discount = 0
+	team.AppliedPrice = basePrice
+	team.VoucherID = nil
+	team.PricedAt = &now
+
+	if voucher == nil {
+		return nil
+	}
+
+	voucher, err = v.VoucherRepository.LockVoucher(tx, voucher.VoucherID)
+	if err != nil {
+		return err
+	}
+
+	err = checkVoucher(voucher, competition.CompetitionID, user.University, now, false)
+	if err != nil {
+		return err
+	}
+
+	discount := voucherDiscount(voucher, basePrice)
+	err = v.VoucherRepository.CreateRedemption(tx, &entity.VoucherRedemption{
+		VoucherID:     voucher.VoucherID,
+		TeamID:        team.TeamID,
+		UserID:        user.UserID,
+		CompetitionID: competition.CompetitionID,
+		BasePrice:     basePrice,
+		Discount:      discount,
+		FinalPrice:    basePrice - discount,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = v.VoucherRepository.AddVoucherUsage(tx, voucher.VoucherID, 1)
+	if err != nil {
+		return err
+	}
+
+	team.Discount = discount
+	team.AppliedPrice = basePrice - discount
+	team.VoucherID = &voucher.VoucherID
+
+	return nil
+}
+
+// registrationBasePrice mengembalikan harga dasar registrasi, tim yang sudah punya harga di lomba yang sama
+// tetap memakai harga lamanya agar harga early bird tidak hilang saat mendaftar ulang.
+func registrationBasePrice(team *entity.Team, competition *entity.Competition, now time.Time) (int64, bool) {
+	if team.PricedAt != nil && team.CompetitionID == competition.CompetitionID {
+		return team.BasePrice, true
+	}
+
+	return currentFee(*competition, now), false
+}
+
+// releaseRedemption melepas voucher yang dipakai tim dan mengembalikan kuotanya.
+func releaseRedemption(tx *gorm.DB, voucherRepository repository.IVoucherRepository, team *entity.Team) error {
+	redemption, err := voucherRepository.GetRedemptionByTeamID(tx, team.TeamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = voucherRepository.LockVoucher(tx, redemption.VoucherID)
+	if err != nil {
+		return err
+	}
+
+	err = voucherRepository.DeleteRedemption(tx, redemption)
+	if err != nil {
+		return err
+	}
+
+	return voucherRepository.AddVoucherUsage(tx, redemption.VoucherID, -1)
+}
+
+func (v *VoucherService) getVoucher(tx *gorm.DB, voucherID int) (*entity.Voucher, error) {
+	voucher, err := v.VoucherRepository.GetVoucherByID(tx, voucherID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+
+	return voucher, nil
+}
+
+// getRegistrableCompetition mengambil kompetisi yang masih bisa didaftari peserta.
+func getRegistrableCompetition(tx *gorm.DB, competitionRepository repository.ICompetitionRepository, competitionID int) (*entity.Competition, error) {
+	if competitionID == entity.UnassignedCompetitionID {
+		return nil, model.ErrCompetitionNotFound
+	}
+
+	competition, err := competitionRepository.GetCompetitionByID(tx, competitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+
+	if competition.IsArchived {
+		return nil, model.ErrCompetitionArchived
+	}
+
+	return competition, nil
+}
+
+// checkVoucher memastikan voucher bisa dipakai, redeemed menandakan tim sudah memegang kuota voucher ini.
+func checkVoucher(voucher *entity.Voucher, competitionID int, university string, now time.Time, redeemed bool) error {
+	if !voucher.IsActive {
+		return model.ErrVoucherInactive
+	}
+	if voucher.ExpiresAt != nil && !now.Before(*voucher.ExpiresAt) {
+		return model.ErrVoucherExpired
+	}
+	if voucher.CompetitionID != nil && *voucher.CompetitionID != competitionID {
+		return model.ErrVoucherNotApplicable
+	}
+	if !redeemed && voucher.MaxUses > 0 && voucher.UsedCount >= voucher.MaxUses {
+		return model.ErrVoucherExhausted
+	}
+
+	universities := splitLines(voucher.AllowedUniversities)
+	if len(universities) == 0 {
+		return nil
+	}
+	for _, v := range universities {
+		if strings.EqualFold(v, strings.TrimSpace(university)) {
+			return nil
+		}
+	}
+
+	return model.ErrVoucherUniversity
+}
+
+func voucherDiscount(voucher *entity.Voucher, basePrice int64) int64 {
+	discount := voucher.DiscountValue
+	if voucher.DiscountType == entity.DiscountPercent {
+		discount = basePrice * voucher.DiscountValue / 100
+	}
+	if discount > basePrice {
+		return basePrice
+	}
+
+	return discount
+}
+
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func toVoucherResponse(voucher entity.Voucher, withRedemptions bool) model.VoucherResponse {
+	res := model.VoucherResponse{
+		VoucherID:           voucher.VoucherID,
+		Code:                voucher.Code,
+		CompetitionID:       voucher.CompetitionID,
+		DiscountType:        voucher.DiscountType,
+		DiscountValue:       voucher.DiscountValue,
+		MaxUses:             voucher.MaxUses,
+		UsedCount:           voucher.UsedCount,
+		ExpiresAt:           voucher.ExpiresAt,
+		AllowedUniversities: splitLines(voucher.AllowedUniversities),
+		IsActive:            voucher.IsActive,
+		CreatedAt:           voucher.CreatedAt,
+	}
+
+	for _, v := range voucher.Redemptions {
+		res.TotalDiscount += v.Discount
+		res.TotalRevenue += v.FinalPrice
+		if withRedemptions {
+			res.Redemptions = append(res.Redemptions, model.VoucherRedemptionResponse{
+				TeamID:        v.TeamID,
+				UserID:        v.UserID,
+				CompetitionID: v.CompetitionID,
+				BasePrice:     v.BasePrice,
+				Discount:      v.Discount,
+				FinalPrice:    v.FinalPrice,
+				CreatedAt:     v.CreatedAt,
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"itfest-2025/entity"
+	"itfest-2025/internal/repository"
+	"itfest-2025/model"
+	"itfest-2025/pkg/database/mariadb"
+	"itfest-2025/pkg/mail"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IWaitlistService interface {
+	GetMyWaitlist(userID uuid.UUID) (*model.WaitlistResponse, error)
+	LeaveWaitlist(userID uuid.UUID) error
+	GetCompetitionWaitlist(competitionID int) ([]model.WaitlistResponse, error)
+	JoinWaitlist(tx *gorm.DB, team *entity.Team, competitionID int, voucherCode string) (int, error)
+	CancelTeamWaitlist(tx *gorm.DB, teamID uuid.UUID) error
+	PromoteWaitlist(tx *gorm.DB, competitionID int) error
+}
+
+type WaitlistService struct {
+	db                    *gorm.DB
+	WaitlistRepository    repository.IWaitlistRepository
+	TeamRepository        repository.ITeamRepository
+	UserRepository        repository.IUserRepository
+	CompetitionRepository repository.ICompetitionRepository
+	VoucherService        IVoucherService
+	OutboxRepository      repository.IEmailOutboxRepository
+	PaymentRepository     repository.IPaymentRepository
+}
+
+func NewWaitlistService(waitlistRepository repository.IWaitlistRepository, teamRepository repository.ITeamRepository, userRepository repository.IUserRepository, competitionRepository repository.ICompetitionRepository, voucherService IVoucherService, outboxRepository repository.IEmailOutboxRepository, paymentRepository repository.IPaymentRepository) IWaitlistService {
+	return &WaitlistService{
+		db:                    mariadb.Connection,
+		WaitlistRepository:    waitlistRepository,
+		TeamRepository:        teamRepository,
+		UserRepository:        userRepository,
+		CompetitionRepository: competitionRepository,
+		VoucherService:        voucherService,
+		OutboxRepository:      outboxRepository,
+		PaymentRepository:     paymentRepository,
+	}
+}
+
+func (w *WaitlistService) GetMyWaitlist(userID uuid.UUID) (*model.WaitlistResponse, error) {
+	tx := w.db.Begin()
+	defer tx.Rollback()
+
+	entry, err := w.getLeaderEntry(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := w.position(tx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := w.TeamRepository.GetTeamByID(tx, entry.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	entry.Team = *team
+
+	res := toWaitlistResponse(*entry, position)
+	return &res, nil
+}
+
+func (w *WaitlistService) LeaveWaitlist(userID uuid.UUID) error {
+	tx := w.db.Begin()
+	defer tx.Rollback()
+
+	entry, err := w.getLeaderEntry(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	entry.Status = entity.WaitlistCancelled
+	err = w.WaitlistRepository.UpdateEntry(tx, entry)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (w *WaitlistService) GetCompetitionWaitlist(competitionID int) ([]model.WaitlistResponse, error) {
+	tx := w.db.Begin()
+	defer tx.Rollback()
+
+	_, err := w.CompetitionRepository.GetCompetitionByID(tx, competitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+
+	entries, err := w.WaitlistRepository.GetWaitingEntries(tx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []model.WaitlistResponse{}
+	for i, v := range entries {
+		response = append(response, toWaitlistResponse(v, i+1))
+	}
+
+	return response, nil
+}
+
+// JoinWaitlist memasukkan tim ke antrean lomba yang penuh dan mengembalikan nomor antreannya.
+// Tim hanya bisa mengantre di satu lomba, antrean di lomba lain otomatis dibatalkan.
+func (w *WaitlistService) JoinWaitlist(tx *gorm.DB, team *entity.Team, competitionID int, voucherCode string) (int, error) {
+	entry, err := w.WaitlistRepository.GetWaitingEntryByTeamID(tx, team.TeamID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	if entry != nil && entry.CompetitionID == competitionID {
+		entry.VoucherCode = voucherCode
+		err = w.WaitlistRepository.UpdateEntry(tx, entry)
+		if err != nil {
+			return 0, err
+		}
+
+		return w.position(tx, entry)
+	}
+
+	if entry != nil {
+		entry.Status = entity.WaitlistCancelled
+		err = w.WaitlistRepository.UpdateEntry(tx, entry)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	entry = &entity.CompetitionWaitlist{
+		CompetitionID: competitionID,
+		TeamID:        team.TeamID,
+		VoucherCode:   voucherCode,
+		Status:        entity.WaitlistWaiting,
+	}
+
+	err = w.WaitlistRepository.CreateEntry(tx, entry)
+	if err != nil {
+		return 0, err
+	}
+
+	return w.position(tx, entry)
+}
+
+func (w *WaitlistService) CancelTeamWaitlist(tx *gorm.DB, teamID uuid.UUID) error {
+	entry, err := w.WaitlistRepository.GetWaitingEntryByTeamID(tx, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	entry.Status = entity.WaitlistCancelled
+	return w.WaitlistRepository.UpdateEntry(tx, entry)
+}
+
+// PromoteWaitlist mendaftarkan tim dari antrean secara berurutan selama kuota lomba masih tersedia.
+// Tim yang sudah tidak memenuhi syarat saat gilirannya tiba dikeluarkan dari antrean.
+func (w *WaitlistService) PromoteWaitlist(tx *gorm.DB, competitionID int) error {
+	if competitionID == entity.UnassignedCompetitionID {
+		return nil
+	}
+
+	competition, err := w.CompetitionRepository.LockCompetition(tx, competitionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if competition.IsArchived {
+		return nil
+	}
+
+	for {
+		full, err := competitionFull(tx, w.TeamRepository, competition)
+		if err != nil {
+			return err
+		}
+		if full {
+			return nil
+		}
+
+		entry, err := w.WaitlistRepository.GetFirstWaitingEntry(tx, competitionID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		err = w.promoteEntry(tx, competition, entry)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (w *WaitlistService) promoteEntry(tx *gorm.DB, competition *entity.Competition, entry *entity.CompetitionWaitlist) error {
+	team, err := w.TeamRepository.GetTeamByID(tx, entry.TeamID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if team == nil || ensureTeamEditable(team) != nil || team.CompetitionID != entity.UnassignedCompetitionID {
+		entry.Status = entity.WaitlistCancelled
+		return w.WaitlistRepository.UpdateEntry(tx, entry)
+	}
+
+	leader, err := w.UserRepository.GetUser(model.UserParam{
+		UserID: team.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	members, err := w.TeamRepository.GetTeamMemberByTeamID(tx, team.TeamID)
+	if err != nil {
+		return err
+	}
+
+	studentNumbers := []string{leader.StudentNumber}
+	for _, v := range members {
+		studentNumbers = append(studentNumbers, v.StudentNumber)
+	}
+
+	err = checkDuplicateParticipants(tx, w.TeamRepository, w.CompetitionRepository, team.TeamID, competition.CompetitionID, studentNumbers)
+	if errors.Is(err, model.ErrDuplicateStudentNumber) {
+		entry.Status = entity.WaitlistCancelled
+		return w.WaitlistRepository.UpdateEntry(tx, entry)
+	} else if err != nil {
+		return err
+	}
+
+	// voucher yang dipakai saat mengantre bisa saja sudah tidak berlaku, tim tetap didaftarkan dengan harga normal
+	err = w.VoucherService.ApplyRegistrationPrice(tx, leader, team, competition, entry.VoucherCode)
+	if err != nil && entry.VoucherCode != "" {
+		err = w.VoucherService.ApplyRegistrationPrice(tx, leader, team, competition, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	team.CompetitionID = competition.CompetitionID
+	err = w.TeamRepository.UpdateTeam(tx, team)
+	if err != nil {
+		return err
+	}
+
+	err = registerTeam(tx, w.TeamRepository, team, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = getOrCreatePayment(tx, w.PaymentRepository, team, leader)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entry.Status = entity.WaitlistPromoted
+	entry.PromotedAt = &now
+
+	err = w.WaitlistRepository.UpdateEntry(tx, entry)
+	if err != nil {
+		return err
+	}
+
+	return queueWaitlistPromotionEmail(tx, w.OutboxRepository, leader, team, competition)
+}
+
+func (w *WaitlistService) getLeaderEntry(tx *gorm.DB, userID uuid.UUID) (*entity.CompetitionWaitlist, error) {
+	team, err := w.TeamRepository.GetTeamByUserID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrNotTeamLeader
+		}
+		return nil, err
+	}
+
+	entry, err := w.WaitlistRepository.GetWaitingEntryByTeamID(tx, team.TeamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrWaitlistNotFound
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (w *WaitlistService) position(tx *gorm.DB, entry *entity.CompetitionWaitlist) (int, error) {
+	ahead, err := w.WaitlistRepository.CountEntriesAhead(tx, entry)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(ahead) + 1, nil
+}
+
+// checkRegistrationWindow memastikan pendaftaran lomba sedang dibuka pada waktu now.
+func checkRegistrationWindow(competition *entity.Competition, now time.Time) error {
+	if competition.RegistrationOpen != nil && now.Before(*competition.RegistrationOpen) {
+		return fmt.Errorf("%w, registration opens at %s", model.ErrRegistrationNotOpen, competition.RegistrationOpen.Format("02 January 2006 15:04"))
+	}
+	if competition.RegistrationClose != nil && !now.Before(*competition.RegistrationClose) {
+		return model.ErrRegistrationClosed
+	}
+
+	return nil
+}
+
+// competitionFull mengecek kuota tim lomba, MaxTeams 0 berarti tanpa batas.
+func competitionFull(tx *gorm.DB, teamRepository repository.ITeamRepository, competition *entity.Competition) (bool, error) {
+	if competition.MaxTeams <= 0 {
+		return false, nil
+	}
+
+	count, err := teamRepository.GetCount(tx, competition.CompetitionID)
+	if err != nil {
+		return false, err
+	}
+
+	return count >= int64(competition.MaxTeams), nil
+}
+
+// validateRegistrationWindow memastikan waktu buka pendaftaran lebih awal dari waktu tutupnya.
+func validateRegistrationWindow(competition *entity.Competition) error {
+	if competition.RegistrationOpen != nil && competition.RegistrationClose != nil &&
+		!competition.RegistrationOpen.Before(*competition.RegistrationClose) {
+		return model.ErrRegistrationWindow
+	}
+
+	return nil
+}
+
+func queueWaitlistPromotionEmail(tx *gorm.DB, outboxRepository repository.IEmailOutboxRepository, leader *entity.User, team *entity.Team, competition *entity.Competition) error {
+	return queueEmail(tx, outboxRepository, leader.Email, mail.TemplateWaitlistPromoted, mail.WaitlistPromotedData{
+		LeaderName:      leader.FullName,
+		CompetitionName: competition.CompetitionName,
+		TeamName:        team.TeamName,
+	})
+}
+
+func toWaitlistResponse(entry entity.CompetitionWaitlist, position int) model.WaitlistResponse {
+	return model.WaitlistResponse{
+		WaitlistID:      entry.WaitlistID,
+		CompetitionID:   entry.CompetitionID,
+		CompetitionName: entry.Competition.CompetitionName,
+		TeamID:          entry.TeamID,
+		TeamName:        entry.Team.TeamName,
+		Position:        position,
+		Status:          entry.Status,
+		PromotedAt:      entry.PromotedAt,
+		CreatedAt:       entry.CreatedAt,
+	}
+}
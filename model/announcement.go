@@ -3,16 +3,76 @@ package model
 import (
 	"errors"
 	"time"
+
+	"github.com/google/uuid"
 )
 
-var ErrUserRecordNotFound = errors.New("Not Found data user")
+var (
+	ErrStageNotInCompetition      = errors.New("stage does not belong to the targeted competition")
+	ErrSubmissionStatusNeedsStage = errors.New("submission status target requires a stage")
+	ErrAnnouncementNotFound       = errors.New("announcement not found")
+	ErrPublishAtRequired          = errors.New("publish_at is required for scheduled announcements")
+	ErrPublishAtInPast            = errors.New("publish_at must be in the future")
+	ErrAnnouncementPublished      = errors.New("published announcement cannot be moved back to draft or scheduled")
+	ErrAnnouncementTargetLocked   = errors.New("target of a published announcement can only be changed together with resend_email")
+)
 
 type RequestAnnouncement struct {
-	Message string `json:"message" binding:"required"`
+	Title            string     `json:"title" binding:"omitempty,max=255"`
+	Message          string     `json:"message" binding:"required"`
+	CompetitionID    *int       `json:"competition_id"`
+	StageID          *int       `json:"stage_id"`
+	SubmissionStatus string     `json:"submission_status" binding:"omitempty,oneof=diproses lolos 'tidak lolos'"`
+	PaymentStatus    string     `json:"payment_status" binding:"omitempty,oneof='belum terverifikasi' terverifikasi ditolak diproses"`
+	Status           string     `json:"status" binding:"omitempty,oneof=draft scheduled published"`
+	PublishAt        *time.Time `json:"publish_at"`
+}
+
+type UpdateAnnouncementRequest struct {
+	Title            string     `json:"title" binding:"omitempty,max=255"`
+	Message          string     `json:"message" binding:"required"`
+	CompetitionID    *int       `json:"competition_id"`
+	StageID          *int       `json:"stage_id"`
+	SubmissionStatus string     `json:"submission_status" binding:"omitempty,oneof=diproses lolos 'tidak lolos'"`
+	PaymentStatus    string     `json:"payment_status" binding:"omitempty,oneof='belum terverifikasi' terverifikasi ditolak diproses"`
+	Status           string     `json:"status" binding:"omitempty,oneof=draft scheduled published"`
+	PublishAt        *time.Time `json:"publish_at"`
+	ResendEmail      bool       `json:"resend_email"`
 }
 
 type ResponseAnnouncement struct {
-	AnnouncementID string    `json:"id_announcement"`
-	Message        string    `json:"message_announcement"`
-	Date           time.Time `json:"date_announcement"`
+	AnnouncementID   string     `json:"id_announcement"`
+	Title            string     `json:"title_announcement"`
+	Message          string     `json:"message_announcement"`
+	Date             time.Time  `json:"date_announcement"`
+	CompetitionID    *int       `json:"competition_id"`
+	StageID          *int       `json:"stage_id"`
+	SubmissionStatus string     `json:"submission_status"`
+	PaymentStatus    string     `json:"payment_status"`
+	Status           string     `json:"status"`
+	PublishAt        *time.Time `json:"publish_at"`
+	PublishedAt      *time.Time `json:"published_at"`
+	RecipientCount   int        `json:"recipient_count"`
+}
+
+type SendAnnouncementResponse struct {
+	AnnouncementID uuid.UUID `json:"announcement_id"`
+	Status         string    `json:"status"`
+	Recipients     int       `json:"recipients"`
+}
+
+type AnnouncementRevisionResponse struct {
+	RevisionID       int        `json:"revision_id"`
+	Action           string     `json:"action"`
+	Title            string     `json:"title"`
+	Message          string     `json:"message"`
+	CompetitionID    *int       `json:"competition_id"`
+	StageID          *int       `json:"stage_id"`
+	SubmissionStatus string     `json:"submission_status"`
+	PaymentStatus    string     `json:"payment_status"`
+	Status           string     `json:"status"`
+	PublishAt        *time.Time `json:"publish_at"`
+	EmailResent      bool       `json:"email_resent"`
+	ChangedBy        *uuid.UUID `json:"changed_by"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
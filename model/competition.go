@@ -1,7 +1,126 @@
 package model
 
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCompetitionNotFound  = errors.New("competition not found")
+	ErrCompetitionArchived  = errors.New("competition is archived")
+	ErrStageNotFound        = errors.New("stage not found")
+	ErrDuplicateStageOrder  = errors.New("stage order must be unique within a competition")
+	ErrStageDeadlineOrder   = errors.New("stage deadlines must increase with stage order")
+	ErrStageReorderMismatch = errors.New("stage ids must contain every stage of the competition exactly once")
+	ErrEarlyBirdWindow      = errors.New("early bird start must be before its end and its fee must not exceed the registration fee")
+)
+
 type GetAllCompetitionsResponse struct {
+	CompetitionID     int        `json:"competition_id"`
+	CompetitionName   string     `json:"competition_name"`
+	Description       string     `json:"description"`
+	RegistrationFee   int64      `json:"registration_fee"`
+	CurrentFee        int64      `json:"current_fee"`
+	RegistrationOpen  *time.Time `json:"registration_open"`
+	RegistrationClose *time.Time `json:"registration_close"`
+	MaxTeams          int        `json:"max_teams"`
+}
+
+type CreateCompetitionRequest struct {
+	CompetitionName   string                  `json:"competition_name" binding:"required,max=70"`
+	Description       string                  `json:"description" binding:"required"`
+	Deadline          time.Time               `json:"deadline"`
+	RegistrationFee   int64                   `json:"registration_fee" binding:"min=0"`
+	EarlyBirdFee      int64                   `json:"early_bird_fee" binding:"min=0"`
+	EarlyBirdStart    *time.Time              `json:"early_bird_start"`
+	EarlyBirdEnd      *time.Time              `json:"early_bird_end"`
+	RegistrationOpen  *time.Time              `json:"registration_open"`
+	RegistrationClose *time.Time              `json:"registration_close"`
+	MaxTeams          int                     `json:"max_teams" binding:"min=0"`
+	Stages            []StageRequest          `json:"stages" binding:"dive"`
+	Rule              *CompetitionRuleRequest `json:"rule"`
+}
+
+type UpdateCompetitionRequest struct {
+	CompetitionName   string     `json:"competition_name" binding:"omitempty,max=70"`
+	Description       string     `json:"description"`
+	Deadline          *time.Time `json:"deadline"`
+	RegistrationFee   *int64     `json:"registration_fee" binding:"omitempty,min=0"`
+	EarlyBirdFee      *int64     `json:"early_bird_fee" binding:"omitempty,min=0"`
+	EarlyBirdStart    *time.Time `json:"early_bird_start"`
+	EarlyBirdEnd      *time.Time `json:"early_bird_end"`
+	RegistrationOpen  *time.Time `json:"registration_open"`
+	RegistrationClose *time.Time `json:"registration_close"`
+	MaxTeams          *int       `json:"max_teams" binding:"omitempty,min=0"`
+}
+
+type ArchiveCompetitionRequest struct {
+	IsArchived bool `json:"is_archived"`
+}
+
+type StageRequest struct {
+	StageName        string    `json:"stage_name" binding:"required,max=20"`
+	StageOrder       int       `json:"stage_order" binding:"required,min=1"`
+	Deadline         time.Time `json:"deadline" binding:"required"`
+	AllowedMimeTypes []string  `json:"allowed_mime_types"`
+	MaxFileSize      int64     `json:"max_file_size" binding:"omitempty,min=1"`
+}
+
+type UpdateStageRequest struct {
+	StageName        string     `json:"stage_name" binding:"omitempty,max=20"`
+	Deadline         *time.Time `json:"deadline"`
+	AllowedMimeTypes []string   `json:"allowed_mime_types"`
+	MaxFileSize      int64      `json:"max_file_size" binding:"omitempty,min=1"`
+}
+
+type ReorderStagesRequest struct {
+	StageIDs []int `json:"stage_ids" binding:"required,min=1"`
+	// Deadlines berisi deadline baru per stage id, deadline tetap harus naik sesuai urutan baru
+	Deadlines map[int]time.Time `json:"deadlines"`
+}
+
+type CompetitionDetailResponse struct {
+	CompetitionID     int                     `json:"competition_id"`
+	CompetitionName   string                  `json:"competition_name"`
+	Description       string                  `json:"description"`
+	Deadline          time.Time               `json:"deadline"`
+	RegistrationFee   int64                   `json:"registration_fee"`
+	EarlyBirdFee      int64                   `json:"early_bird_fee"`
+	EarlyBirdStart    *time.Time              `json:"early_bird_start"`
+	EarlyBirdEnd      *time.Time              `json:"early_bird_end"`
+	IsArchived        bool                    `json:"is_archived"`
+	RegistrationOpen  *time.Time              `json:"registration_open"`
+	RegistrationClose *time.Time              `json:"registration_close"`
+	MaxTeams          int                     `json:"max_teams"`
+	Stages            []StageResponse         `json:"stages"`
+	Rule              CompetitionRuleResponse `json:"rule"`
+}
+
+type StageResponse struct {
+	StageID          int       `json:"stage_id"`
+	StageName        string    `json:"stage_name"`
+	StageOrder       int       `json:"stage_order"`
+	Deadline         time.Time `json:"deadline"`
+	AllowedMimeTypes []string  `json:"allowed_mime_types"`
+	MaxFileSize      int64     `json:"max_file_size"`
+}
+
+type CompetitionRuleRequest struct {
+	PaymentRequired   bool `json:"payment_required"`
+	PaymentStageOrder int  `json:"payment_stage_order" binding:"omitempty,min=1"`
+	MaxTeamMembers    *int `json:"max_team_members" binding:"required,min=1"`
+	FreeFirstStage    bool `json:"free_first_stage"`
+}
+
+type CompetitionRuleResponse struct {
+	PaymentRequired   bool `json:"payment_required"`
+	PaymentStageOrder int  `json:"payment_stage_order"`
+	MaxTeamMembers    int  `json:"max_team_members"`
+	FreeFirstStage    bool `json:"free_first_stage"`
+}
+
+type CompetitionCount struct {
 	CompetitionID   int    `json:"competition_id"`
 	CompetitionName string `json:"competition_name"`
-	Description     string `json:"description"`
+	Total           int64  `json:"total"`
 }
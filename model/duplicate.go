@@ -0,0 +1,19 @@
+package model
+
+import "errors"
+
+var (
+	ErrDuplicateStudentNumber = errors.New("student number is already registered in this competition")
+)
+
+type DuplicateGroup struct {
+	CompetitionID int           `json:"competition_id"`
+	Reason        string        `json:"reason"`
+	Similarity    float64       `json:"similarity"`
+	Participants  []Participant `json:"participants"`
+}
+
+type DuplicateReportResponse struct {
+	Total  int              `json:"total"`
+	Groups []DuplicateGroup `json:"groups"`
+}
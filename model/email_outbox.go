@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailNotFound       = errors.New("email not found")
+	ErrEmailNotRequeueable = errors.New("email has already been sent or is being sent")
+	ErrInvalidEmailStatus  = errors.New("email status must be one of pending, sending, sent or dead")
+)
+
+type OutboxEmailResponse struct {
+	EmailID       int        `json:"email_id"`
+	Recipient     string     `json:"recipient"`
+	Subject       string     `json:"subject"`
+	Template      string     `json:"template"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type RequeueEmailsResponse struct {
+	Requeued int64 `json:"requeued"`
+}
@@ -0,0 +1,13 @@
+package model
+
+type EmailTemplateResponse struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+}
+
+type EmailPreviewResponse struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
@@ -1,10 +1,20 @@
 package model
 
 import (
+	"errors"
 	"mime/multipart"
 	"net/http"
+	"slices"
+	"strings"
 )
 
+var (
+	ErrDocumentFileType = errors.New("file must be a jpeg, png, webp image or pdf")
+)
+
+// DocumentFileTypes adalah tipe file bukti bayar dan KTM, hanya tipe ini yang boleh dibuka langsung di browser.
+var DocumentFileTypes = []string{"image/jpeg", "image/png", "image/webp", "application/pdf"}
+
 type Image struct {
 	File *multipart.FileHeader `form:"file" validate:"required, image_type,image_size"`
 }
@@ -25,3 +35,18 @@ func GetImageType(file *multipart.FileHeader) (string, error) {
 
 	return http.DetectContentType(buffer), nil
 }
+
+// GetDocumentType mendeteksi tipe file dari isinya, bukan dari nama file, lalu menolak selain gambar dan pdf.
+func GetDocumentType(file *multipart.FileHeader) (string, error) {
+	contentType, err := GetImageType(file)
+	if err != nil {
+		return "", err
+	}
+
+	contentType = strings.Split(contentType, ";")[0]
+	if !slices.Contains(DocumentFileTypes, contentType) {
+		return "", ErrDocumentFileType
+	}
+
+	return contentType, nil
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRubricLocked       = errors.New("rubric cannot be changed after scores have been submitted")
+	ErrRubricEmpty        = errors.New("stage does not have a rubric")
+	ErrSubmissionNotFound = errors.New("submission not found")
+	ErrAssignmentNotFound = errors.New("judge assignment not found")
+	ErrNotJudge           = errors.New("user does not have judging permission")
+	ErrAssignmentScored   = errors.New("judge assignment has already been scored")
+	ErrInvalidScore       = errors.New("score must be between 0 and the criterion max score")
+	ErrIncompleteScores   = errors.New("every rubric criterion must be scored exactly once")
+	ErrSubmissionDecided  = errors.New("submission has already been decided")
+)
+
+type RubricRequest struct {
+	Criteria []RubricCriterionRequest `json:"criteria" binding:"required,min=1,dive"`
+}
+
+type RubricCriterionRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight" binding:"required,gt=0"`
+	MaxScore    int     `json:"max_score" binding:"required,min=1"`
+}
+
+type RubricCriterionResponse struct {
+	CriterionID int     `json:"criterion_id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+	MaxScore    int     `json:"max_score"`
+}
+
+type AssignJudgeRequest struct {
+	JudgeID uuid.UUID `json:"judge_id" binding:"required"`
+}
+
+type ScoreSubmissionRequest struct {
+	Comment string                  `json:"comment"`
+	Scores  []CriterionScoreRequest `json:"scores" binding:"required,min=1,dive"`
+}
+
+type CriterionScoreRequest struct {
+	CriterionID int     `json:"criterion_id" binding:"required"`
+	Score       float64 `json:"score" binding:"min=0"`
+	Comment     string  `json:"comment"`
+}
+
+type CriterionScoreResponse struct {
+	CriterionID int     `json:"criterion_id"`
+	Score       float64 `json:"score"`
+	Comment     string  `json:"comment"`
+}
+
+type JudgeAssignmentResponse struct {
+	AssignmentID   uuid.UUID                 `json:"assignment_id"`
+	TeamProgressID int                       `json:"team_progress_id"`
+	TeamID         uuid.UUID                 `json:"team_id"`
+	TeamName       string                    `json:"team_name"`
+	StageID        int                       `json:"stage_id"`
+	StageName      string                    `json:"stage_name"`
+	GdriveLink     string                    `json:"gdrive_link"`
+	FileName       string                    `json:"file_name"`
+	FileURL        string                    `json:"file_url"`
+	CurrentVersion int                       `json:"current_version"`
+	ScoredVersion  int                       `json:"scored_version"`
+	ScoredLink     string                    `json:"scored_link"`
+	Comment        string                    `json:"comment"`
+	SubmittedAt    *time.Time                `json:"submitted_at"`
+	Criteria       []RubricCriterionResponse `json:"criteria"`
+	Scores         []CriterionScoreResponse  `json:"scores"`
+}
+
+type StageRankingResponse struct {
+	Rank           int       `json:"rank"`
+	TeamProgressID int       `json:"team_progress_id"`
+	TeamID         uuid.UUID `json:"team_id"`
+	TeamName       string    `json:"team_name"`
+	Status         string    `json:"status"`
+	JudgeCount     int       `json:"judge_count"`
+	Score          float64   `json:"score"`
+}
+
+type PassTopRequest struct {
+	TopN       int    `json:"top_n" binding:"required,min=1"`
+	FailOthers bool   `json:"fail_others"`
+	Reason     string `json:"reason"`
+}
+
+type PassTopResponse struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
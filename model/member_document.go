@@ -0,0 +1,42 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDocumentNotFound  = errors.New("document not found")
+	ErrMemberNotFound    = errors.New("team member not found")
+	ErrDocumentForbidden = errors.New("only the team leader or the member can upload this document")
+	ErrDocumentLocked    = errors.New("approved document cannot be replaced")
+	ErrDocumentTooLarge  = errors.New("file size exceeds maximum limit of 1MB")
+)
+
+type ReviewDocumentRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+	Reason string `json:"reason"`
+}
+
+type MemberDocumentResponse struct {
+	DocumentID      *uuid.UUID `json:"document_id"`
+	OwnerID         uuid.UUID  `json:"owner_id"`
+	OwnerType       string     `json:"owner_type"`
+	MemberName      string     `json:"member_name"`
+	StudentNumber   string     `json:"student_number"`
+	DocumentType    string     `json:"document_type"`
+	Status          string     `json:"status"`
+	RejectionReason string     `json:"rejection_reason"`
+	FileURL         string     `json:"file_url"`
+	ReviewedAt      *time.Time `json:"reviewed_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+type TeamDocumentsResponse struct {
+	TeamID      uuid.UUID                `json:"team_id"`
+	TeamName    string                   `json:"team_name"`
+	AllApproved bool                     `json:"all_approved"`
+	Documents   []MemberDocumentResponse `json:"documents"`
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPaymentNotFound         = errors.New("payment not found")
+	ErrPaymentAlreadyVerified  = errors.New("payment has already been verified")
+	ErrRejectionReasonRequired = errors.New("rejection reason is required")
+	ErrPaymentFileTooLarge     = errors.New("file size exceeds maximum limit of 1MB")
+	ErrNoRegistrationFee       = errors.New("competition does not have a registration fee")
+	ErrChargeNotFound          = errors.New("payment charge not found")
+	ErrChargeAmountMismatch    = errors.New("paid amount does not match the charge amount")
+	ErrSimulatorDisabled       = errors.New("payment simulator is not enabled")
+	ErrPaymentTransition       = errors.New("payment status cannot be changed to the requested status")
+	ErrPaymentProofRequired    = errors.New("payment cannot be verified before proof of payment is uploaded")
+	ErrPaymentAmountMismatch   = errors.New("reported amount does not match the registration fee")
+	ErrPaymentTeamUnregistered = errors.New("team is not registered in any competition")
+)
+
+type UploadPaymentRequest struct {
+	Amount  int64  `form:"amount" json:"amount" binding:"omitempty,min=0"`
+	Channel string `form:"channel" json:"channel" binding:"omitempty,max=50"`
+}
+
+type PaymentResponse struct {
+	PaymentID       uuid.UUID                `json:"payment_id"`
+	TeamID          uuid.UUID                `json:"team_id"`
+	CompetitionID   int                      `json:"competition_id"`
+	ReferenceCode   string                   `json:"reference_code"`
+	Amount          int64                    `json:"amount"`
+	ProofURL        string                   `json:"proof_url"`
+	Channel         string                   `json:"channel"`
+	Method          string                   `json:"method"`
+	Status          string                   `json:"status"`
+	SubmittedAt     *time.Time               `json:"submitted_at"`
+	VerifiedBy      *uuid.UUID               `json:"verified_by"`
+	VerifiedAt      *time.Time               `json:"verified_at"`
+	RejectionReason string                   `json:"rejection_reason"`
+	Histories       []PaymentHistoryResponse `json:"histories"`
+}
+
+type PaymentHistoryResponse struct {
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Amount     int64      `json:"amount"`
+	Channel    string     `json:"channel"`
+	Reason     string     `json:"reason"`
+	ChangedBy  *uuid.UUID `json:"changed_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ChargeResponse struct {
+	OrderID     string    `json:"order_id"`
+	Provider    string    `json:"provider"`
+	Amount      int64     `json:"amount"`
+	Status      string    `json:"status"`
+	RedirectURL string    `json:"redirect_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type SimulatePaymentRequest struct {
+	Status string `form:"status" json:"status" binding:"required,oneof=paid failed expired"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "errors"
+
+var ErrReceiptNotFound = errors.New("receipt not found, payment has not been verified")
+
+type ReceiptFile struct {
+	FileName string
+	Content  []byte
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStatementTooLarge           = errors.New("bank statement exceeds maximum size of 5MB")
+	ErrStatementFormat             = errors.New("bank statement must be a CSV or XLSX file")
+	ErrStatementHeader             = errors.New("bank statement must have an amount column")
+	ErrStatementEmpty              = errors.New("bank statement does not contain any credit transaction")
+	ErrReconciliationNotFound      = errors.New("reconciliation batch not found")
+	ErrReconciliationConfirmed     = errors.New("reconciliation batch has already been confirmed")
+	ErrReconciliationLineNotFound  = errors.New("reconciliation line not found in this batch")
+	ErrReconciliationDuplicateTeam = errors.New("a team can only be confirmed once per batch")
+	ErrReconciliationNotCandidate  = errors.New("team is not a candidate for this reconciliation line")
+)
+
+type ReconciliationResponse struct {
+	BatchID       uuid.UUID                    `json:"batch_id"`
+	FileName      string                       `json:"file_name"`
+	Status        string                       `json:"status"`
+	TotalRows     int                          `json:"total_rows"`
+	MatchedRows   int                          `json:"matched_rows"`
+	AmbiguousRows int                          `json:"ambiguous_rows"`
+	ConfirmedRows int                          `json:"confirmed_rows"`
+	UploadedBy    uuid.UUID                    `json:"uploaded_by"`
+	ConfirmedBy   *uuid.UUID                   `json:"confirmed_by"`
+	ConfirmedAt   *time.Time                   `json:"confirmed_at"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	Lines         []ReconciliationLineResponse `json:"lines,omitempty"`
+}
+
+type ReconciliationLineResponse struct {
+	LineID        int                          `json:"line_id"`
+	RowNumber     int                          `json:"row_number"`
+	TransactionAt *time.Time                   `json:"transaction_at"`
+	Amount        int64                        `json:"amount"`
+	Description   string                       `json:"description"`
+	MatchStatus   string                       `json:"match_status"`
+	MatchReason   string                       `json:"match_reason"`
+	Team          *ReconciliationTeamResponse  `json:"team"`
+	Candidates    []ReconciliationTeamResponse `json:"candidates"`
+}
+
+type ReconciliationTeamResponse struct {
+	TeamID        uuid.UUID `json:"team_id"`
+	TeamName      string    `json:"team_name"`
+	ReferenceCode string    `json:"reference_code"`
+	TeamStatus    string    `json:"team_status"`
+}
+
+type ConfirmReconciliationRequest struct {
+	Decisions []ReconciliationDecision `json:"decisions" binding:"dive"`
+}
+
+type ReconciliationDecision struct {
+	LineID int        `json:"line_id" binding:"required"`
+	TeamID *uuid.UUID `json:"team_id"`
+	Skip   bool       `json:"skip"`
+}
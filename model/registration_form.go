@@ -0,0 +1,59 @@
+package model
+
+import "errors"
+
+var (
+	ErrFormFieldNotFound  = errors.New("registration form field not found")
+	ErrDuplicateFieldKey  = errors.New("registration form field keys must be unique within a competition")
+	ErrInvalidFormField   = errors.New("invalid registration form field")
+	ErrInvalidFormAnswer  = errors.New("invalid registration form answer")
+	ErrUnknownFormField   = errors.New("answer does not match any registration form field")
+	ErrFormFileNotAllowed = errors.New("file type is not allowed for this form field")
+	ErrFormFileTooLarge   = errors.New("file exceeds the size limit of this form field")
+	ErrFormFieldNotAFile  = errors.New("registration form field does not accept files")
+	ErrFormTeamMismatch   = errors.New("team is registered in a different competition")
+)
+
+type FormFieldRequest struct {
+	Key              string   `json:"key" binding:"required,max=50"`
+	Label            string   `json:"label" binding:"required,max=100"`
+	Type             string   `json:"type" binding:"required,oneof=text select url file"`
+	Required         bool     `json:"required"`
+	Options          []string `json:"options"`
+	MaxLength        int      `json:"max_length" binding:"min=0"`
+	Pattern          string   `json:"pattern" binding:"max=255"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+	MaxFileSize      int64    `json:"max_file_size" binding:"min=0"`
+}
+
+type UpdateRegistrationFormRequest struct {
+	Fields []FormFieldRequest `json:"fields" binding:"dive"`
+}
+
+type FormFieldResponse struct {
+	FieldID          int      `json:"field_id"`
+	Key              string   `json:"key"`
+	Label            string   `json:"label"`
+	Type             string   `json:"type"`
+	Required         bool     `json:"required"`
+	Options          []string `json:"options"`
+	MaxLength        int      `json:"max_length"`
+	Pattern          string   `json:"pattern"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+	MaxFileSize      int64    `json:"max_file_size"`
+	Order            int      `json:"order"`
+}
+
+type FormAnswerResponse struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	FileName string `json:"file_name"`
+}
+
+type RegistrationFormResponse struct {
+	CompetitionID int                  `json:"competition_id"`
+	Fields        []FormFieldResponse  `json:"fields"`
+	Answers       []FormAnswerResponse `json:"answers"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNotFound          = errors.New("role not found")
+	ErrParticipantRoleChange = errors.New("participant role cannot be granted or revoked")
+	ErrSelfRoleRevoke        = errors.New("cannot revoke your own role management access")
+	ErrPermissionDenied      = errors.New("user dont have access")
+)
+
+type GrantRoleRequest struct {
+	RoleID int `json:"role_id" binding:"required"`
+}
+
+type RoleResponse struct {
+	RoleID      int      `json:"role_id"`
+	RoleName    string   `json:"role_name"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserRolesResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	RoleIDs     []int     `json:"role_ids"`
+	Permissions []string  `json:"permissions"`
+}
@@ -13,10 +13,14 @@ var (
 	ErrSubmissionProcessing = errors.New("submission sedang diproses")
 	ErrPassedDeadline       = errors.New("submission ditolak karena sudah melewati deadline")
 	ErrNoStage              = errors.New("submission ditolak karena stage tidak tersedia")
+	ErrSameSubmissionLink   = errors.New("submission ditolak karena link sama dengan versi aktif")
+	ErrSubmissionContent    = errors.New("submission harus berisi salah satu dari link gdrive atau file")
+	ErrFileTypeNotAllowed   = errors.New("tipe file tidak diizinkan untuk stage ini")
+	ErrFileTooLarge         = errors.New("ukuran file melebihi batas stage ini")
 )
 
 type ReqSubmission struct {
-	GdriveLink string `json:"gdrive_link" binding:"required,url"`
+	GdriveLink string `form:"gdrive_link" json:"gdrive_link" binding:"omitempty,url"`
 }
 type ReqFilterSubmission struct {
 	StageID int    `form:"stage_id" json:"stage_id"`
@@ -36,6 +40,7 @@ type ResStage struct {
 	NextStage         int       `json:"next_stage"`
 	IDNextStage       int       `json:"id_next_stage"`
 	DeadlineNextStage time.Time `json:"deadline_next_stage"`
+	CanResubmit       bool      `json:"can_resubmit"`
 }
 
 type ResCurrentSubmission struct {
@@ -48,4 +53,30 @@ type ResCurrentSubmission struct {
 
 type RequestUpdateStatusSubmission struct {
 	SubmissionStatus string `json:"submission_status" binding:"oneof='diproses' 'lolos' 'tidak lolos'"`
+	Reason           string `json:"reason"`
+}
+
+type ResSubmissionHistory struct {
+	TeamProgressID int                    `json:"team_progress_id"`
+	StageID        int                    `json:"stage_id"`
+	Stage          string                 `json:"stage"`
+	Deadline       time.Time              `json:"deadline"`
+	Status         string                 `json:"status"`
+	CurrentVersion int                    `json:"current_version"`
+	GdriveLink     string                 `json:"gdrive_link"`
+	FileName       string                 `json:"file_name"`
+	FileURL        string                 `json:"file_url"`
+	Versions       []ResSubmissionVersion `json:"versions"`
+}
+
+type ResSubmissionVersion struct {
+	Version     int       `json:"version"`
+	GdriveLink  string    `json:"gdrive_link"`
+	FileName    string    `json:"file_name"`
+	FileType    string    `json:"file_type"`
+	FileSize    int64     `json:"file_size"`
+	FileURL     string    `json:"file_url"`
+	SubmittedBy uuid.UUID `json:"submitted_by"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
 }
@@ -1,11 +1,14 @@
 package model
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var ErrTeamMemberLimit = errors.New("team member limit exceeded")
+
 type AddTeamMemberRequest struct {
 	MemberName string    `json:"member_name" binding:"required"`
 	TeamID     uuid.UUID `json:"team_id"`
@@ -29,12 +32,15 @@ type TeamMemberRequest struct {
 type TeamInfoResponse struct {
 	TeamName            string                `json:"team_name"`
 	CompetitionCategory string                `json:"competition_category"`
+	LifecycleStatus     string                `json:"lifecycle_status"`
+	LifecycleReason     string                `json:"lifecycle_reason"`
 	Members             []TeamMembersResponse `json:"members"`
 }
 
 type TeamMembersResponse struct {
-	FullName      string `json:"full_name"`
-	StudentNumber string `json:"student_number"`
+	FullName      string     `json:"full_name"`
+	StudentNumber string     `json:"student_number"`
+	UserID        *uuid.UUID `json:"user_id"`
 }
 
 type GetAllTeamsResponse struct {
@@ -45,6 +51,7 @@ type GetAllTeamsResponse struct {
 	PaymentStatus   string           `json:"payment_status"`
 	CompetitionName string           `json:"competition_name"`
 	CurrentStage    string           `json:"current_stage"`
+	LifecycleStatus string           `json:"lifecycle_status"`
 	TeamMembers     []GetTeamMembers `json:"team_members"`
 }
 
@@ -55,6 +62,7 @@ type GetTeamMembers struct {
 type ReqUpdateStatusTeam struct {
 	TeamID        string `json:"team_id"`
 	PaymentStatus string `json:"payment_status" binding:"oneof='belum terverifikasi' 'terverifikasi' 'ditolak' 'diproses'"`
+	Reason        string `json:"reason"`
 }
 
 type TeamInfoResponseAdmin struct {
@@ -66,6 +74,8 @@ type TeamInfoResponseAdmin struct {
 	PaymentTransc       string                `json:"payment_transaction"`
 	StudentCard         string                `json:"student_card"`
 	PhoneNumber         string                `json:"phone_number"`
+	LifecycleStatus     string                `json:"lifecycle_status"`
+	LifecycleReason     string                `json:"lifecycle_reason"`
 	Members             []TeamMembersResponse `json:"members"`
 	StageNow            StageNow              `json:"progress"`
 }
@@ -87,8 +97,21 @@ type TeamDetailProgress struct {
 
 type Stages struct {
 	StageID    int       `json:"stage_id"`
+	StageOrder int       `json:"stage_order"`
 	Stage      string    `json:"stage_name"`
 	Deadline   time.Time `json:"stage_deadline"`
 	GdriveLink string    `json:"link_submission"`
 	Status     string    `json:"status_submission"`
 }
+
+type Participant struct {
+	TeamID        uuid.UUID  `json:"team_id"`
+	TeamName      string     `json:"team_name"`
+	CompetitionID int        `json:"competition_id"`
+	UserID        *uuid.UUID `json:"user_id"`
+	TeamMemberID  *uuid.UUID `json:"team_member_id"`
+	Role          string     `json:"role"`
+	Name          string     `json:"name"`
+	StudentNumber string     `json:"student_number"`
+	University    string     `json:"university"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvitationNotPending    = errors.New("invitation is no longer pending")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to a different email")
+	ErrInvitationDuplicate     = errors.New("a pending invitation for this email already exists")
+	ErrInviteSelf              = errors.New("team leader cannot invite themselves")
+	ErrAlreadyInTeam           = errors.New("user already belongs to a team")
+	ErrNotTeamLeader           = errors.New("only the team leader can manage the team")
+)
+
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type InvitationResponse struct {
+	InvitationID uuid.UUID  `json:"invitation_id"`
+	TeamID       uuid.UUID  `json:"team_id"`
+	TeamName     string     `json:"team_name"`
+	Email        string     `json:"email"`
+	Status       string     `json:"status"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RespondedAt  *time.Time `json:"responded_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNewLeaderNotMember = errors.New("new leader must be a team member with an account")
+	ErrTeamNotDissolvable = errors.New("team is verified or has a payment in progress and cannot be dissolved")
+)
+
+type TransferLeadershipRequest struct {
+	TeamMemberID       uuid.UUID `json:"team_member_id" binding:"required"`
+	RemoveFormerLeader bool      `json:"remove_former_leader"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTeamLocked                 = errors.New("team is locked, contact the committee to unlock it")
+	ErrTeamInactive               = errors.New("team has withdrawn or been disqualified")
+	ErrInvalidLifecycleTransition = errors.New("team cannot move to this status")
+	ErrLifecycleReasonRequired    = errors.New("reason is required to disqualify a team")
+)
+
+type UpdateLifecycleRequest struct {
+	Status string `json:"status" binding:"required,oneof=registered locked withdrawn disqualified"`
+	Reason string `json:"reason"`
+}
+
+type WithdrawTeamRequest struct {
+	Reason string `json:"reason"`
+}
+
+type LifecycleHistoryResponse struct {
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Reason     string     `json:"reason"`
+	ChangedBy  *uuid.UUID `json:"changed_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type TeamLifecycleResponse struct {
+	TeamID             uuid.UUID                  `json:"team_id"`
+	TeamName           string                     `json:"team_name"`
+	LifecycleStatus    string                     `json:"lifecycle_status"`
+	LifecycleReason    string                     `json:"lifecycle_reason"`
+	LifecycleChangedAt *time.Time                 `json:"lifecycle_changed_at"`
+	History            []LifecycleHistoryResponse `json:"history"`
+}
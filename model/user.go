@@ -1,11 +1,18 @@
 package model
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
+	ErrSessionRevoked      = errors.New("session has been revoked")
+	ErrInvalidResetToken   = errors.New("reset token is invalid or expired")
+)
+
 type UserRegister struct {
 	Email           string `json:"email" binding:"required,email"`
 	Password        string `json:"password" binding:"required,min=8"`
@@ -13,7 +20,9 @@ type UserRegister struct {
 }
 
 type RegisterResponse struct {
-	Token string `json:"token"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
 
 type UserLogin struct {
@@ -22,7 +31,13 @@ type UserLogin struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type UserProfile struct {
@@ -34,10 +49,12 @@ type UserProfile struct {
 }
 
 type CompetitionRegistrationRequest struct {
-	FullName      string `json:"full_name" binding:"required"`
-	StudentNumber string `json:"student_number" binding:"required"`
-	University    string `json:"university" binding:"required"`
-	PhoneNumber   string `json:"phone_number" binding:"required,min=10,max=15,numeric"`
+	FullName      string            `json:"full_name" binding:"required"`
+	StudentNumber string            `json:"student_number" binding:"required"`
+	University    string            `json:"university" binding:"required"`
+	PhoneNumber   string            `json:"phone_number" binding:"required,min=10,max=15,numeric"`
+	VoucherCode   string            `json:"voucher_code" binding:"omitempty,max=30"`
+	Answers       map[string]string `json:"answers"`
 }
 
 type UpdateProfile struct {
@@ -65,14 +82,18 @@ type ForgotPasswordRequest struct {
 }
 
 type VerifyToken struct {
-	UserID uuid.UUID `json:"user_id"`
-	OTP    string    `json:"otp" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+	OTP   string `json:"otp" binding:"required"`
+}
+
+type VerifyTokenResponse struct {
+	ResetToken string `json:"reset_token"`
 }
 
 type ResetPasswordRequest struct {
-	UserID          uuid.UUID `json:"user_id"`
-	NewPassword     string    `json:"new_password" binding:"required,min=8"`
-	ConfirmPassword string    `json:"confirm_password" binding:"required,min=8"`
+	ResetToken      string `json:"reset_token" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,min=8"`
 }
 
 type UserTeamProfile struct {
@@ -81,12 +102,15 @@ type UserTeamProfile struct {
 	StudentNumber       string           `json:"student_number"`
 	CompetitionCategory string           `json:"competition_category"`
 	Deadline            time.Time        `json:"deadline"`
+	LifecycleStatus     string           `json:"lifecycle_status"`
+	LifecycleReason     string           `json:"lifecycle_reason"`
 	Members             []MemberResponse `json:"members"`
 }
 
 type MemberResponse struct {
-	FullName      string `json:"full_name"`
-	StudentNumber string `json:"student_number"`
+	FullName      string     `json:"full_name"`
+	StudentNumber string     `json:"student_number"`
+	UserID        *uuid.UUID `json:"user_id"`
 }
 
 type GetUserPaymentStatus struct {
@@ -100,8 +124,10 @@ type GetUserPaymentStatus struct {
 }
 
 type GetTotalParticipant struct {
-	TotalUIUX int `json:"total_uiux"`
-	TotalBP   int `json:"total_bp"`
+	TotalUIUX    int                `json:"total_uiux"`
+	TotalBP      int                `json:"total_bp"`
+	Total        int64              `json:"total"`
+	Competitions []CompetitionCount `json:"competitions"`
 }
 
 type UploadPaymentResponse struct {
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVoucherNotFound        = errors.New("voucher not found")
+	ErrVoucherCodeTaken       = errors.New("voucher code is already in use")
+	ErrVoucherInvalidDiscount = errors.New("percent discount must be between 1 and 100")
+	ErrVoucherInactive        = errors.New("voucher is no longer active")
+	ErrVoucherExpired         = errors.New("voucher has expired")
+	ErrVoucherExhausted       = errors.New("voucher usage limit has been reached")
+	ErrVoucherNotApplicable   = errors.New("voucher is not valid for this competition")
+	ErrVoucherUniversity      = errors.New("voucher is not valid for your university")
+	ErrRegistrationPaid       = errors.New("registration has already been paid and can no longer be changed")
+)
+
+type CreateVoucherRequest struct {
+	Code                string     `json:"code" binding:"required,alphanum,max=30"`
+	CompetitionID       *int       `json:"competition_id" binding:"omitempty,min=1"`
+	DiscountType        string     `json:"discount_type" binding:"required,oneof=percent fixed"`
+	DiscountValue       int64      `json:"discount_value" binding:"required,min=1"`
+	MaxUses             int        `json:"max_uses" binding:"min=0"`
+	ExpiresAt           *time.Time `json:"expires_at"`
+	AllowedUniversities []string   `json:"allowed_universities"`
+}
+
+type UpdateVoucherRequest struct {
+	DiscountType        string       `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+	DiscountValue       *int64       `json:"discount_value" binding:"omitempty,min=1"`
+	MaxUses             *int         `json:"max_uses" binding:"omitempty,min=0"`
+	ExpiresAt           NullableTime `json:"expires_at"`
+	AllowedUniversities []string     `json:"allowed_universities"`
+	IsActive            *bool        `json:"is_active"`
+}
+
+// NullableTime membedakan field yang tidak dikirim dengan field yang sengaja dikirim null untuk dikosongkan.
+type NullableTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (n *NullableTime) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value time.Time
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	n.Value = &value
+
+	return nil
+}
+
+type VoucherResponse struct {
+	VoucherID           int                         `json:"voucher_id"`
+	Code                string                      `json:"code"`
+	CompetitionID       *int                        `json:"competition_id"`
+	DiscountType        string                      `json:"discount_type"`
+	DiscountValue       int64                       `json:"discount_value"`
+	MaxUses             int                         `json:"max_uses"`
+	UsedCount           int                         `json:"used_count"`
+	ExpiresAt           *time.Time                  `json:"expires_at"`
+	AllowedUniversities []string                    `json:"allowed_universities"`
+	IsActive            bool                        `json:"is_active"`
+	TotalDiscount       int64                       `json:"total_discount"`
+	TotalRevenue        int64                       `json:"total_revenue"`
+	CreatedAt           time.Time                   `json:"created_at"`
+	Redemptions         []VoucherRedemptionResponse `json:"redemptions,omitempty"`
+}
+
+type VoucherRedemptionResponse struct {
+	TeamID        uuid.UUID `json:"team_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	CompetitionID int       `json:"competition_id"`
+	BasePrice     int64     `json:"base_price"`
+	Discount      int64     `json:"discount"`
+	FinalPrice    int64     `json:"final_price"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type RegistrationPriceResponse struct {
+	CompetitionID int    `json:"competition_id"`
+	BasePrice     int64  `json:"base_price"`
+	IsEarlyBird   bool   `json:"is_early_bird"`
+	VoucherCode   string `json:"voucher_code"`
+	Discount      int64  `json:"discount"`
+	FinalPrice    int64  `json:"final_price"`
+}
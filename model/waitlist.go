@@ -0,0 +1,28 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRegistrationNotOpen = errors.New("registration is not open yet")
+	ErrRegistrationClosed  = errors.New("registration is closed")
+	ErrCompetitionFull     = errors.New("competition is full")
+	ErrRegistrationWindow  = errors.New("registration open time must be before its close time")
+	ErrWaitlistNotFound    = errors.New("team is not on any waitlist")
+)
+
+type WaitlistResponse struct {
+	WaitlistID      int        `json:"waitlist_id"`
+	CompetitionID   int        `json:"competition_id"`
+	CompetitionName string     `json:"competition_name"`
+	TeamID          uuid.UUID  `json:"team_id"`
+	TeamName        string     `json:"team_name"`
+	Position        int        `json:"position"`
+	Status          string     `json:"status"`
+	PromotedAt      *time.Time `json:"promoted_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
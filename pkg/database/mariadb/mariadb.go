@@ -12,7 +12,8 @@ var Connection *gorm.DB
 
 func ConnectDatabase() (*gorm.DB, error) {
 	db, err := gorm.Open(mysql.Open(config.LoadDataSourceName()), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
 	})
 
 	if err != nil {
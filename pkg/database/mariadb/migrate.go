@@ -2,25 +2,240 @@ package mariadb
 
 import (
 	"itfest-2025/entity"
+	"itfest-2025/pkg/payment"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// legacyCompetitionRules adalah aturan yang dulu ditulis langsung di kode, lomba 2 (UI/UX) wajib bayar sejak stage 1
+// sedangkan lomba 3 (Business Plan) gratis di stage 1 dan wajib bayar mulai stage 2.
+var legacyCompetitionRules = []entity.CompetitionRule{
+	{CompetitionID: 2, PaymentRequired: true, PaymentStageOrder: 1, MaxTeamMembers: 2},
+	{CompetitionID: 3, PaymentRequired: true, PaymentStageOrder: 2, MaxTeamMembers: 2, FreeFirstStage: true},
+}
+
 func Migrate(db *gorm.DB) error {
-	err := db.AutoMigrate(
+	err := backfillReferenceCodes(db)
+	if err != nil {
+		return err
+	}
+
+	err = db.AutoMigrate(
+		&entity.Permission{},
 		&entity.Role{},
 		&entity.User{},
 		&entity.OtpCode{},
+		&entity.RefreshToken{},
 		&entity.Competition{},
+		&entity.CompetitionRule{},
 		&entity.Stages{},
 		&entity.Team{},
 		&entity.Announcement{},
+		&entity.AnnouncementRevision{},
 		&entity.TeamProgress{},
 		&entity.TeamMember{},
+		&entity.RubricCriterion{},
+		&entity.JudgeAssignment{},
+		&entity.JudgeScore{},
+		&entity.SubmissionVersion{},
+		&entity.Payment{},
+		&entity.PaymentHistory{},
+		&entity.PaymentCharge{},
+		&entity.Voucher{},
+		&entity.VoucherRedemption{},
+		&entity.ReconciliationBatch{},
+		&entity.ReconciliationLine{},
+		&entity.Receipt{},
+		&entity.ReceiptSequence{},
+		&entity.TeamInvitation{},
+		&entity.MemberDocument{},
+		&entity.TeamLifecycleHistory{},
+		&entity.CompetitionWaitlist{},
+		&entity.RegistrationField{},
+		&entity.RegistrationAnswer{},
+		&entity.EmailOutbox{},
 	)
 	if err != nil {
 		return err
 	}
 
+	err = backfillTeamLifecycle(db)
+	if err != nil {
+		return err
+	}
+
+	err = backfillCompetitionRules(db)
+	if err != nil {
+		return err
+	}
+
+	err = backfillPayments(db)
+	if err != nil {
+		return err
+	}
+
+	err = backfillLeaderDocuments(db)
+	if err != nil {
+		return err
+	}
+
+	err = restoreParticipantRoles(db)
+	if err != nil {
+		return err
+	}
+
+	return Seed(db)
+}
+
+// backfillTeamLifecycle mengisi tahapan tim lama yang belum pernah berpindah tahapan sejak kolom lifecycle_status ada.
+func backfillTeamLifecycle(db *gorm.DB) error {
+	err := db.Model(&entity.Team{}).
+		Where("lifecycle_changed_at IS NULL AND lifecycle_status = ? AND competition_id <> ?", entity.TeamDraft, entity.UnassignedCompetitionID).
+		Update("lifecycle_status", entity.TeamRegistered).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Model(&entity.Team{}).
+		Where("lifecycle_changed_at IS NULL AND lifecycle_status = ? AND team_status = ?", entity.TeamRegistered, "terverifikasi").
+		Update("lifecycle_status", entity.TeamLocked).Error
+}
+
+// backfillCompetitionRules membuat aturan untuk lomba lama yang belum punya aturan agar alur pembayarannya tidak berubah.
+// Aturan yang sudah diubah admin tidak ditimpa.
+func backfillCompetitionRules(db *gorm.DB) error {
+	for _, rule := range legacyCompetitionRules {
+		var count int64
+		err := db.Model(&entity.Competition{}).Where("competition_id = ?", rule.CompetitionID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			continue
+		}
+
+		err = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rule).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillPayments membuat payment untuk tim yang sudah terdaftar sebelum payment dibuat saat pendaftaran.
+func backfillPayments(db *gorm.DB) error {
+	var teams []entity.Team
+	err := db.Where("competition_id <> ? AND team_id NOT IN (?)", entity.UnassignedCompetitionID,
+		db.Model(&entity.Payment{}).Select("team_id")).
+		Find(&teams).Error
+	if err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		var leader entity.User
+		err = db.Where("user_id = ?", team.UserID).First(&leader).Error
+		if err != nil {
+			return err
+		}
+
+		code, err := payment.NewReferenceCode()
+		if err != nil {
+			return err
+		}
+
+		err = db.Create(&entity.Payment{
+			PaymentID:     uuid.New(),
+			TeamID:        team.TeamID,
+			CompetitionID: team.CompetitionID,
+			ReferenceCode: code,
+			ProofFile:     leader.PaymentTransc,
+			Status:        team.TeamStatus,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillLeaderDocuments mencatat KTM ketua yang diunggah sebelum ada verifikasi dokumen sebagai dokumen pending.
+func backfillLeaderDocuments(db *gorm.DB) error {
+	var rows []struct {
+		TeamID          uuid.UUID
+		UserID          uuid.UUID
+		StudentCardLink string
+	}
+	err := db.Model(&entity.Team{}).
+		Select("teams.team_id, teams.user_id, users.student_card_link").
+		Joins("JOIN users ON users.user_id = teams.user_id").
+		Where("users.student_card_link <> '' AND teams.user_id NOT IN (?)",
+			db.Model(&entity.MemberDocument{}).Select("owner_id").Where("document_type = ?", entity.DocumentKTM)).
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, v := range rows {
+		err = db.Create(&entity.MemberDocument{
+			DocumentID:   uuid.New(),
+			TeamID:       v.TeamID,
+			OwnerID:      v.UserID,
+			OwnerType:    entity.DocumentOwnerLeader,
+			DocumentType: entity.DocumentKTM,
+			FileKey:      v.StudentCardLink,
+			Status:       "pending",
+			UploadedBy:   v.UserID,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreParticipantRoles mengembalikan role utama peserta yang dulu tergantikan role tambahan, role tambahannya
+// tetap tersimpan di user_roles.
+func restoreParticipantRoles(db *gorm.DB) error {
+	return db.Model(&entity.User{}).
+		Where("role_id <> ? AND role_id IN (SELECT role_id FROM user_roles WHERE user_roles.user_id = users.user_id)", entity.RoleParticipant).
+		Where("user_id IN (?) OR user_id IN (?)",
+			db.Model(&entity.Team{}).Select("user_id"),
+			db.Model(&entity.TeamMember{}).Select("user_id").Where("user_id IS NOT NULL")).
+		Update("role_id", entity.RoleParticipant).Error
+}
+
+// backfillReferenceCodes mengisi ulang kode referensi payment lama yang kosong atau kembar sebelum unique index dibuat.
+func backfillReferenceCodes(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&entity.Payment{}, "ReferenceCode") {
+		return nil
+	}
+
+	var paymentIDs []string
+	err := db.Model(&entity.Payment{}).
+		Where("reference_code IS NULL OR reference_code = '' OR reference_code IN (?)",
+			db.Model(&entity.Payment{}).Select("reference_code").Group("reference_code").Having("COUNT(*) > 1")).
+		Pluck("payment_id", &paymentIDs).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range paymentIDs {
+		code, err := payment.NewReferenceCode()
+		if err != nil {
+			return err
+		}
+
+		err = db.Model(&entity.Payment{}).Where("payment_id = ?", id).Update("reference_code", code).Error
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
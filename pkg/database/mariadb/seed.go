@@ -0,0 +1,79 @@
+package mariadb
+
+import (
+	"itfest-2025/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var defaultRoles = []entity.Role{
+	{RoleID: entity.RoleSuperAdmin, RoleName: "super admin"},
+	{RoleID: entity.RoleParticipant, RoleName: "participant"},
+	{RoleID: entity.RolePaymentVerifier, RoleName: "payment verifier"},
+	{RoleID: entity.RoleJudge, RoleName: "judge"},
+	{RoleID: entity.RoleViewer, RoleName: "viewer"},
+}
+
+var defaultRolePermissions = map[int][]string{
+	entity.RoleSuperAdmin: {
+		entity.PermissionTeamsRead,
+		entity.PermissionTeamsWrite,
+		entity.PermissionPaymentsRead,
+		entity.PermissionPaymentsVerify,
+		entity.PermissionSubmissionsRead,
+		entity.PermissionSubmissionsGrade,
+		entity.PermissionSubmissionsDecide,
+		entity.PermissionCompetitionsRead,
+		entity.PermissionCompetitionsManage,
+		entity.PermissionAnnouncementsManage,
+		entity.PermissionExportsRead,
+		entity.PermissionUsersManage,
+		entity.PermissionRolesManage,
+	},
+	entity.RolePaymentVerifier: {
+		entity.PermissionTeamsRead,
+		entity.PermissionPaymentsRead,
+		entity.PermissionPaymentsVerify,
+		entity.PermissionExportsRead,
+	},
+	entity.RoleJudge: {
+		entity.PermissionSubmissionsRead,
+		entity.PermissionSubmissionsGrade,
+	},
+	entity.RoleViewer: {
+		entity.PermissionTeamsRead,
+		entity.PermissionPaymentsRead,
+		entity.PermissionSubmissionsRead,
+		entity.PermissionCompetitionsRead,
+		entity.PermissionExportsRead,
+	},
+}
+
+// Seed memastikan role dan permission bawaan tersedia tanpa menimpa data yang sudah ada.
+func Seed(db *gorm.DB) error {
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaultRoles).Error
+	if err != nil {
+		return err
+	}
+
+	for roleID, names := range defaultRolePermissions {
+		for _, name := range names {
+			permission := entity.Permission{Name: name}
+			err = db.Where(entity.Permission{Name: name}).FirstOrCreate(&permission).Error
+			if err != nil {
+				return err
+			}
+
+			err = db.Table("role_permissions").Clauses(clause.OnConflict{DoNothing: true}).Create(map[string]interface{}{
+				"role_id":       roleID,
+				"permission_id": permission.PermissionID,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"itfest-2025/entity"
 	"log"
@@ -14,39 +17,60 @@ import (
 )
 
 type Interface interface {
-	CreateJWTToken(userID uuid.UUID, isAdmin bool) (string, error)
-	ValidateToken(tokenString string) (uuid.UUID, error)
+	CreateJWTToken(user *entity.User, sessionID uuid.UUID) (string, error)
+	CreateResetToken(user *entity.User) (string, error)
+	ValidateToken(tokenString string) (*Claims, error)
 	GetLoginUser(c *gin.Context) (*entity.User, error)
+	CreateRefreshToken() (string, string, error)
+	HashRefreshToken(token string) string
+	AccessExpiredTime() time.Duration
+	RefreshExpiredTime() time.Duration
 }
 
 type jsonWebToken struct {
-	SecretKey   string
-	ExpiredTime time.Duration
+	SecretKey         string
+	ExpiredTime       time.Duration
+	RefreshExpiredTTL time.Duration
 }
 
+// PurposePasswordReset menandai token yang hanya boleh dipakai untuk mengganti password.
+const PurposePasswordReset = "password_reset"
+
 type Claims struct {
-	UserID  uuid.UUID
-	IsAdmin bool
+	UserID       uuid.UUID
+	TokenVersion int
+	SessionID    uuid.UUID
+	Purpose      string `json:",omitempty"`
 	jwt.RegisteredClaims
 }
 
 func Init() Interface {
 	secretKey := os.Getenv("JWT_SECRET_KEY")
-	expiredTime, err := strconv.Atoi(os.Getenv("JWT_EXP_TIME"))
+	refreshExpiredTime, err := strconv.Atoi(os.Getenv("JWT_EXP_TIME"))
 	if err != nil {
 		log.Fatalf("error init jwt %v", err)
 	}
 
+	accessExpiredTime := 15
+	if os.Getenv("JWT_ACCESS_EXP_TIME") != "" {
+		accessExpiredTime, err = strconv.Atoi(os.Getenv("JWT_ACCESS_EXP_TIME"))
+		if err != nil {
+			log.Fatalf("error init jwt %v", err)
+		}
+	}
+
 	return &jsonWebToken{
-		SecretKey:   secretKey,
-		ExpiredTime: time.Duration(expiredTime) * time.Hour,
+		SecretKey:         secretKey,
+		ExpiredTime:       time.Duration(accessExpiredTime) * time.Minute,
+		RefreshExpiredTTL: time.Duration(refreshExpiredTime) * time.Hour,
 	}
 }
 
-func (j *jsonWebToken) CreateJWTToken(userID uuid.UUID, isAdmin bool) (string, error) {
+func (j *jsonWebToken) CreateJWTToken(user *entity.User, sessionID uuid.UUID) (string, error) {
 	claims := &Claims{
-		UserID:  userID,
-		IsAdmin: isAdmin,
+		UserID:       user.UserID,
+		TokenVersion: user.TokenVersion,
+		SessionID:    sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.ExpiredTime)),
 		},
@@ -61,26 +85,43 @@ func (j *jsonWebToken) CreateJWTToken(userID uuid.UUID, isAdmin bool) (string, e
 	return tokenString, nil
 }
 
-func (j *jsonWebToken) ValidateToken(tokenString string) (uuid.UUID, error) {
-	var (
-		claim  Claims
-		userID uuid.UUID
-	)
+// CreateResetToken membuat token reset password berumur pendek tanpa sesi.
+// Token terikat ke TokenVersion user sehingga tidak berlaku lagi setelah password diganti.
+func (j *jsonWebToken) CreateResetToken(user *entity.User) (string, error) {
+	claims := &Claims{
+		UserID:       user.UserID,
+		TokenVersion: user.TokenVersion,
+		Purpose:      PurposePasswordReset,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.ExpiredTime)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(j.SecretKey))
+	if err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+func (j *jsonWebToken) ValidateToken(tokenString string) (*Claims, error) {
+	var claim Claims
 
 	token, err := jwt.ParseWithClaims(tokenString, &claim, func(t *jwt.Token) (interface{}, error) {
 		return []byte(j.SecretKey), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
-		return userID, err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return userID, errors.New("token is not valid")
+		return nil, errors.New("token is not valid")
 	}
 
-	userID = claim.UserID
-	return userID, nil
+	return &claim, nil
 }
 
 func (j *jsonWebToken) GetLoginUser(c *gin.Context) (*entity.User, error) {
@@ -91,3 +132,28 @@ func (j *jsonWebToken) GetLoginUser(c *gin.Context) (*entity.User, error) {
 
 	return user.(*entity.User), nil
 }
+
+// CreateRefreshToken menghasilkan refresh token acak beserta hash yang disimpan di database.
+func (j *jsonWebToken) CreateRefreshToken() (string, string, error) {
+	buffer := make([]byte, 32)
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return "", "", err
+	}
+
+	token := hex.EncodeToString(buffer)
+	return token, j.HashRefreshToken(token), nil
+}
+
+func (j *jsonWebToken) HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func (j *jsonWebToken) AccessExpiredTime() time.Duration {
+	return j.ExpiredTime
+}
+
+func (j *jsonWebToken) RefreshExpiredTime() time.Duration {
+	return j.RefreshExpiredTTL
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File menulis setiap email sebagai file .eml, dipakai saat development tanpa server SMTP.
+type File struct {
+	dir  string
+	from string
+}
+
+func NewFile() *File {
+	dir := os.Getenv("MAIL_FILE_PATH")
+	if dir == "" {
+		dir = "mails"
+	}
+
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "noreply@localhost"
+	}
+
+	return &File{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (f *File) Send(message Message) error {
+	err := os.MkdirAll(f.dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000"), GenerateRandomString(8))
+
+	return os.WriteFile(filepath.Join(f.dir, name), Build(f.from, message), 0o644)
+}
@@ -1,38 +1,109 @@
 package mail
 
 import (
+	"encoding/base64"
 	"fmt"
+	"log"
 	"math/rand"
-	"net/smtp"
+	"mime"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
-func SendEmail(to, subject, message string) error {
-	SMTP_HOST := os.Getenv("SMTP_HOST")
-	SMTP_PORT := os.Getenv("SMTP_PORT")
-	SMTP_USERNAME := os.Getenv("SMTP_USERNAME")
-	SMTP_PASSWORD := os.Getenv("SMTP_PASSWORD")
+type Attachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// Message berisi email HTML pada Body, Text diisi jika email juga punya versi teks biasa.
+type Message struct {
+	To          string
+	Subject     string
+	Body        string
+	Text        string
+	Attachments []Attachment
+}
 
-	addr := fmt.Sprintf("%s:%s", SMTP_HOST, SMTP_PORT)
-	msg := fmt.Sprintf(
+type Interface interface {
+	Send(message Message) error
+}
+
+func Init() Interface {
+	driver := os.Getenv("MAIL_DRIVER")
+	switch driver {
+	case "", "smtp":
+		return NewSMTP()
+	case "file":
+		return NewFile()
+	case "memory":
+		return NewMemory()
+	default:
+		log.Fatalf("error init mail driver %s", driver)
+		return nil
+	}
+}
+
+// Build menyusun email lengkap dengan header, versi teks dikirim sebagai multipart/alternative
+// dan lampiran sebagai multipart/mixed.
+func Build(from string, message Message) []byte {
+	header := fmt.Sprintf(
 		"From: No Reply <%s>\r\n"+
 			"To: %s\r\n"+
 			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: text/html; charset=\"UTF-8\"\r\n"+
-			"\r\n%s", // body setelah header
-		SMTP_USERNAME, to, subject, message)
-	err := smtp.SendMail(addr,
-		smtp.PlainAuth("", SMTP_USERNAME, SMTP_PASSWORD, SMTP_HOST),
-		SMTP_USERNAME, []string{to}, []byte(msg))
-
-	if err != nil {
-		return err
+			"Date: %s\r\n"+
+			"MIME-Version: 1.0\r\n",
+		from, message.To, mime.QEncoding.Encode("UTF-8", message.Subject), time.Now().Format(time.RFC1123Z))
+
+	contentType, body := bodyPart(message)
+	if len(message.Attachments) == 0 {
+		return []byte(header + "Content-Type: " + contentType + "\r\n\r\n" + body) // body setelah header
+	}
+
+	return []byte(header + multipartBody(contentType, body, message.Attachments))
+}
+
+func bodyPart(message Message) (string, string) {
+	htmlType := "text/html; charset=\"UTF-8\""
+	if message.Text == "" {
+		return htmlType, message.Body
+	}
+
+	boundary := "itfest-alt-" + GenerateRandomString(24)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\n%s\r\n", boundary, message.Text)
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: %s\r\n\r\n%s\r\n", boundary, htmlType, message.Body)
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	return fmt.Sprintf("multipart/alternative; boundary=\"%s\"", boundary), b.String()
+}
+
+func multipartBody(contentType string, body string, attachments []Attachment) string {
+	boundary := "itfest-" + GenerateRandomString(24)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=\"%s\"\r\n\r\n", boundary)
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: %s\r\n\r\n%s\r\n", boundary, contentType, body)
+
+	for _, v := range attachments {
+		fmt.Fprintf(&b, "--%s\r\n", boundary)
+		fmt.Fprintf(&b, "Content-Type: %s; name=\"%s\"\r\n", v.ContentType, v.FileName)
+		fmt.Fprintf(&b, "Content-Disposition: attachment; filename=\"%s\"\r\n", v.FileName)
+		b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+		encoded := base64.StdEncoding.EncodeToString(v.Content)
+		for len(encoded) > 76 {
+			b.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		b.WriteString(encoded + "\r\n")
 	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
 
-	return nil
+	return b.String()
 }
 
 func GenerateCode() string {
@@ -0,0 +1,55 @@
+package mail
+
+import "sync"
+
+// Memory menyimpan email yang dikirim di memori agar bisa diperiksa oleh test.
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Send(message Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+
+	return nil
+}
+
+// Messages mengembalikan salinan semua email yang sudah dikirim.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]Message, len(m.messages))
+	copy(messages, m.messages)
+
+	return messages
+}
+
+// SentTo mengembalikan email yang dikirim ke alamat tertentu.
+func (m *Memory) SentTo(to string) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var messages []Message
+	for _, v := range m.messages {
+		if v.To == to {
+			messages = append(messages, v)
+		}
+	}
+
+	return messages
+}
+
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
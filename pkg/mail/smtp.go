@@ -0,0 +1,241 @@
+package mail
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	EncryptionStartTLS = "starttls"
+	EncryptionTLS      = "tls"
+	EncryptionNone     = "none"
+)
+
+var (
+	ErrStartTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+	ErrAuthUnsupported     = errors.New("smtp server does not support AUTH but SMTP_USERNAME is set")
+)
+
+// SMTP mengirim email lewat server SMTP. Setiap pengiriman memakai koneksinya sendiri dari pool sehingga
+// beberapa worker bisa mengirim bersamaan, koneksi dipakai ulang selama belum melewati idle timeout.
+type SMTP struct {
+	host        string
+	port        string
+	username    string
+	password    string
+	from        string
+	encryption  string
+	idleTimeout time.Duration
+
+	mu   sync.Mutex
+	idle []*smtpConn
+}
+
+type smtpConn struct {
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func NewSMTP() *SMTP {
+	port := os.Getenv("SMTP_PORT")
+
+	// tanpa SMTP_ENCRYPTION, port 465 memakai TLS langsung dan port lain memakai STARTTLS jika server mendukung
+	encryption := os.Getenv("SMTP_ENCRYPTION")
+	if encryption == "" && port == "465" {
+		encryption = EncryptionTLS
+	}
+
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USERNAME")
+	}
+
+	idleTimeout, err := strconv.Atoi(os.Getenv("SMTP_IDLE_TIMEOUT"))
+	if err != nil || idleTimeout <= 0 {
+		idleTimeout = 30
+	}
+
+	return &SMTP{
+		host:        os.Getenv("SMTP_HOST"),
+		port:        port,
+		username:    os.Getenv("SMTP_USERNAME"),
+		password:    os.Getenv("SMTP_PASSWORD"),
+		from:        from,
+		encryption:  encryption,
+		idleTimeout: time.Duration(idleTimeout) * time.Second,
+	}
+}
+
+func (s *SMTP) Send(message Message) error {
+	conn, reused, err := s.acquire()
+	if err != nil {
+		return err
+	}
+
+	err = s.deliver(conn.client, message)
+	if err != nil {
+		closeClient(conn.client)
+
+		// koneksi lama bisa saja sudah diputus server, coba sekali lagi dengan koneksi baru
+		if !reused {
+			return err
+		}
+
+		client, err := s.dial()
+		if err != nil {
+			return err
+		}
+
+		err = s.deliver(client, message)
+		if err != nil {
+			closeClient(client)
+			return err
+		}
+		conn = &smtpConn{client: client}
+	}
+
+	s.release(conn)
+
+	return nil
+}
+
+// Close menutup semua koneksi yang sedang menganggur di pool.
+func (s *SMTP) Close() error {
+	s.mu.Lock()
+	idle := s.idle
+	s.idle = nil
+	s.mu.Unlock()
+
+	for _, v := range idle {
+		closeClient(v.client)
+	}
+
+	return nil
+}
+
+// acquire mengambil koneksi menganggur dari pool atau membuka koneksi baru, lock hanya dipegang saat
+// mengambil dari pool sehingga I/O ke server tidak menahan pengiriman lain.
+func (s *SMTP) acquire() (*smtpConn, bool, error) {
+	for {
+		s.mu.Lock()
+		var conn *smtpConn
+		if n := len(s.idle); n > 0 {
+			conn = s.idle[n-1]
+			s.idle = s.idle[:n-1]
+		}
+		s.mu.Unlock()
+
+		if conn == nil {
+			break
+		}
+		if time.Since(conn.lastUsed) < s.idleTimeout && conn.client.Reset() == nil {
+			return conn, true, nil
+		}
+		closeClient(conn.client)
+	}
+
+	client, err := s.dial()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &smtpConn{client: client}, false, nil
+}
+
+func (s *SMTP) release(conn *smtpConn) {
+	conn.lastUsed = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idle = append(s.idle, conn)
+}
+
+func (s *SMTP) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.host, s.port)
+	tlsConfig := &tls.Config{ServerName: s.host}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	var conn net.Conn
+	var err error
+	if s.encryption == EncryptionTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if s.encryption != EncryptionTLS && s.encryption != EncryptionNone {
+		ok, _ := client.Extension("STARTTLS")
+		if ok {
+			err = client.StartTLS(tlsConfig)
+		} else if s.encryption == EncryptionStartTLS {
+			err = ErrStartTLSUnsupported
+		}
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	if s.username != "" {
+		ok, _ := client.Extension("AUTH")
+		if ok {
+			err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host))
+		} else {
+			err = ErrAuthUnsupported
+		}
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+func (s *SMTP) deliver(client *smtp.Client, message Message) error {
+	err := client.Mail(s.from)
+	if err != nil {
+		return err
+	}
+
+	err = client.Rcpt(message.To)
+	if err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(Build(s.from, message))
+	if err != nil {
+		w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+func closeClient(client *smtp.Client) {
+	err := client.Quit()
+	if err != nil {
+		client.Close()
+	}
+}
@@ -0,0 +1,212 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	TemplateOtpVerification  = "otp_verification"
+	TemplatePasswordReset    = "password_reset"
+	TemplateAnnouncement     = "announcement"
+	TemplateTeamInvitation   = "team_invitation"
+	TemplateWaitlistPromoted = "waitlist_promoted"
+	TemplatePaymentVerified  = "payment_verified"
+	TemplatePaymentRejected  = "payment_rejected"
+)
+
+var ErrTemplateNotFound = errors.New("email template not found")
+
+//go:embed templates
+var templateFS embed.FS
+
+type OtpData struct {
+	Code string
+}
+
+type AnnouncementData struct {
+	Title   string
+	Message string
+	// Updated menandai email yang dikirim ulang karena pengumumannya diubah
+	Updated bool
+}
+
+type TeamInvitationData struct {
+	LeaderName string
+	TeamName   string
+	ExpiresAt  string
+}
+
+type WaitlistPromotedData struct {
+	LeaderName      string
+	CompetitionName string
+	TeamName        string
+}
+
+type PaymentVerifiedData struct {
+	LeaderName    string
+	TeamName      string
+	Amount        string
+	ReceiptNumber string
+}
+
+type PaymentRejectedData struct {
+	Name     string
+	TeamName string
+	Reason   string
+}
+
+// samples dipakai untuk preview template oleh admin, setiap template wajib punya contoh data.
+var samples = map[string]any{
+	TemplateOtpVerification: OtpData{Code: "123456"},
+	TemplatePasswordReset:   OtpData{Code: "654321"},
+	TemplateAnnouncement: AnnouncementData{
+		Title:   "Technical Meeting",
+		Message: "Technical meeting akan dilaksanakan pada hari Sabtu pukul 09.00 WIB.\nLink zoom akan dibagikan melalui grup peserta.",
+	},
+	TemplateTeamInvitation: TeamInvitationData{
+		LeaderName: "Budi Santoso",
+		TeamName:   "Tim Contoh",
+		ExpiresAt:  "17 August 2025 23:59",
+	},
+	TemplateWaitlistPromoted: WaitlistPromotedData{
+		LeaderName:      "Budi Santoso",
+		CompetitionName: "UI/UX Design",
+		TeamName:        "Tim Contoh",
+	},
+	TemplatePaymentVerified: PaymentVerifiedData{
+		LeaderName:    "Budi Santoso",
+		TeamName:      "Tim Contoh",
+		Amount:        "Rp150.000",
+		ReceiptNumber: "ITF/2025/00001",
+	},
+	TemplatePaymentRejected: PaymentRejectedData{
+		Name:     "Budi Santoso",
+		TeamName: "Tim Contoh",
+		Reason:   "Nominal transfer tidak sesuai dengan biaya pendaftaran.",
+	},
+}
+
+// icons berisi gambar yang ditampilkan di atas judul email, template tanpa ikon cukup tidak didaftarkan.
+var icons = map[string]string{
+	TemplateOtpVerification: "https://i.imgur.com/dgvL3Gf.png",
+	TemplatePasswordReset:   "https://i.imgur.com/dgvL3Gf.png",
+	TemplateAnnouncement:    "https://i.imgur.com/2pvNmnv.png",
+}
+
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var templates = parseTemplates()
+
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// parseTemplates membaca seluruh template yang di-embed, setiap template digabung dengan layout bersama.
+func parseTemplates() map[string]emailTemplate {
+	result := make(map[string]emailTemplate)
+	for name := range samples {
+		icon := icons[name]
+		html := htmltemplate.Must(htmltemplate.New(name).Funcs(htmltemplate.FuncMap{
+			"icon":  func() string { return icon },
+			"lines": lines,
+		}).ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html"))
+
+		text := texttemplate.Must(texttemplate.New(name).ParseFS(templateFS, "templates/layout.txt", "templates/"+name+".txt"))
+
+		result[name] = emailTemplate{
+			html: html,
+			text: text,
+		}
+	}
+
+	return result
+}
+
+func Templates() []string {
+	var names []string
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func Render(name string, data any) (*Rendered, error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	var subject, text, html bytes.Buffer
+	err := tmpl.text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tmpl.text.ExecuteTemplate(&text, "layout", data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tmpl.html.ExecuteTemplate(&html, "layout", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// RenderSample merender template dengan contoh data untuk kebutuhan preview.
+func RenderSample(name string) (*Rendered, error) {
+	data, ok := samples[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	return Render(name, data)
+}
+
+func NewMessage(to, name string, data any, attachments ...Attachment) (Message, error) {
+	rendered, err := Render(name, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:          to,
+		Subject:     rendered.Subject,
+		Body:        rendered.HTML,
+		Text:        rendered.Text,
+		Attachments: attachments,
+	}, nil
+}
+
+// SendTemplate merender template lalu mengirimnya lewat driver yang dipakai.
+func SendTemplate(m Interface, to, name string, data any, attachments ...Attachment) error {
+	message, err := NewMessage(to, name, data, attachments...)
+	if err != nil {
+		return err
+	}
+
+	return m.Send(message)
+}
+
+func lines(value string) []string {
+	return strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
+}
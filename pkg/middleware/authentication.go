@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"itfest-2025/model"
 	"itfest-2025/pkg/response"
 	"net/http"
 	"strings"
@@ -18,16 +17,14 @@ func (m *middleware) AuthenticateUser(c *gin.Context) {
 	}
 
 	token := strings.Split(bearer, " ")[1]
-	userID, err := m.jwtAuth.ValidateToken(token)
+	claims, err := m.jwtAuth.ValidateToken(token)
 	if err != nil {
 		response.Error(c, http.StatusUnauthorized, "failed to validate token", err)
 		c.Abort()
 		return
 	}
 
-	user, err := m.service.UserService.GetUser(model.UserParam{
-		UserID: userID,
-	})
+	user, err := m.service.AuthService.Authenticate(claims)
 	if err != nil {
 		response.Error(c, http.StatusUnauthorized, "failed to get user", err)
 		c.Abort()
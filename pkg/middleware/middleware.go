@@ -9,7 +9,7 @@ import (
 
 type Interface interface {
 	AuthenticateUser(c *gin.Context)
-	OnlyAdmin(c *gin.Context)
+	RequirePermission(permissions ...string) gin.HandlerFunc
 	Timeout() gin.HandlerFunc
 	Cors() gin.HandlerFunc
 }
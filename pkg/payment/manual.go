@@ -0,0 +1,26 @@
+package payment
+
+import "net/http"
+
+// Manual dipakai saat gateway tidak dikonfigurasi, peserta membayar lewat transfer dan mengunggah bukti bayar.
+type Manual struct{}
+
+func NewManual() *Manual {
+	return &Manual{}
+}
+
+func (m *Manual) Name() string {
+	return "manual"
+}
+
+func (m *Manual) CreateCharge(charge Charge) (*ChargeResult, error) {
+	return nil, ErrGatewayDisabled
+}
+
+func (m *Manual) ExpireCharge(reference string) error {
+	return nil
+}
+
+func (m *Manual) ParseWebhook(header http.Header, body []byte) (*Notification, error) {
+	return nil, ErrGatewayDisabled
+}
@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	StatusPaid    = "paid"
+	StatusFailed  = "failed"
+	StatusExpired = "expired"
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	ErrGatewayDisabled  = errors.New("payment gateway is disabled, upload the payment proof instead")
+)
+
+type Charge struct {
+	OrderID       string
+	Amount        int64
+	Description   string
+	CustomerName  string
+	CustomerEmail string
+}
+
+type ChargeResult struct {
+	Reference   string
+	RedirectURL string
+	ExpiresAt   time.Time
+}
+
+type Notification struct {
+	OrderID   string
+	Reference string
+	Status    string
+	Amount    int64
+	PaidAt    time.Time
+}
+
+type Interface interface {
+	Name() string
+	CreateCharge(charge Charge) (*ChargeResult, error)
+	ExpireCharge(reference string) error
+	ParseWebhook(header http.Header, body []byte) (*Notification, error)
+}
+
+// Simulator diimplementasikan provider yang bisa menyelesaikan pembayaran tanpa gateway sungguhan.
+type Simulator interface {
+	Simulate(orderID string, amount int64, status string) (http.Header, []byte, error)
+}
+
+// Init memakai gateway Xendit jika PAYMENT_SECRET_KEY diisi, tanpa kredensial gateway pembayaran hanya lewat unggah bukti.
+// Simulator hanya aktif jika PAYMENT_PROVIDER=simulator di luar production.
+func Init() Interface {
+	provider := os.Getenv("PAYMENT_PROVIDER")
+	if provider == "" {
+		provider = "xendit"
+		if os.Getenv("PAYMENT_SECRET_KEY") == "" {
+			provider = "manual"
+		}
+	}
+
+	switch provider {
+	case "manual":
+		log.Print("payment gateway is disabled, only manual payment proof uploads are accepted")
+		return NewManual()
+	case "xendit":
+		secretKey := os.Getenv("PAYMENT_SECRET_KEY")
+		if secretKey == "" {
+			log.Fatal("PAYMENT_SECRET_KEY is required for xendit payment provider")
+		}
+		return NewXendit(secretKey, webhookSecret())
+	case "simulator":
+		if !SimulatorEnabled() {
+			log.Fatal("payment simulator cannot be used in production")
+		}
+		webhookSecret()
+		return NewLocalSimulator()
+	default:
+		log.Fatalf("error init payment provider %s", provider)
+		return nil
+	}
+}
+
+func webhookSecret() string {
+	secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if secret == "" {
+		log.Fatal("PAYMENT_WEBHOOK_SECRET is required")
+	}
+
+	return secret
+}
+
+// SimulatorEnabled menentukan apakah provider simulator dan endpoint-nya boleh dipasang.
+func SimulatorEnabled() bool {
+	return os.Getenv("PAYMENT_PROVIDER") == "simulator" && os.Getenv("APP_ENV") != "production"
+}
@@ -0,0 +1,21 @@
+package payment
+
+import "crypto/rand"
+
+// referenceCharset tidak memuat karakter yang mirip (0/O, 1/I) dan panjangnya 32 agar byte%32 tidak bias.
+const referenceCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewReferenceCode membuat kode referensi transfer dari crypto/rand, contoh ITFK7Q2MX.
+func NewReferenceCode() (string, error) {
+	b := make([]byte, 6)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = referenceCharset[int(b[i])%len(referenceCharset)]
+	}
+
+	return "ITF" + string(b), nil
+}
@@ -0,0 +1,97 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const signatureHeader = "X-Simulator-Signature"
+
+type LocalSimulator struct {
+	baseURL string
+	secret  string
+}
+
+type simulatorPayload struct {
+	OrderID   string    `json:"order_id"`
+	Reference string    `json:"reference"`
+	Status    string    `json:"status"`
+	Amount    int64     `json:"amount"`
+	PaidAt    time.Time `json:"paid_at"`
+}
+
+func NewLocalSimulator() *LocalSimulator {
+	return &LocalSimulator{
+		baseURL: strings.TrimSuffix(os.Getenv("APP_URL"), "/"),
+		secret:  os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+	}
+}
+
+func (l *LocalSimulator) Name() string {
+	return "simulator"
+}
+
+func (l *LocalSimulator) CreateCharge(charge Charge) (*ChargeResult, error) {
+	return &ChargeResult{
+		Reference:   "SIM-" + charge.OrderID,
+		RedirectURL: fmt.Sprintf("%s/api/v1/payments/simulator/%s", l.baseURL, charge.OrderID),
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	}, nil
+}
+
+func (l *LocalSimulator) ExpireCharge(reference string) error {
+	return nil
+}
+
+func (l *LocalSimulator) ParseWebhook(header http.Header, body []byte) (*Notification, error) {
+	if !hmac.Equal([]byte(l.sign(body)), []byte(header.Get(signatureHeader))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload simulatorPayload
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		OrderID:   payload.OrderID,
+		Reference: payload.Reference,
+		Status:    payload.Status,
+		Amount:    payload.Amount,
+		PaidAt:    payload.PaidAt,
+	}, nil
+}
+
+// Simulate membuat body dan header webhook bertanda tangan seperti yang akan dikirim gateway.
+func (l *LocalSimulator) Simulate(orderID string, amount int64, status string) (http.Header, []byte, error) {
+	body, err := json.Marshal(simulatorPayload{
+		OrderID:   orderID,
+		Reference: "SIM-" + orderID,
+		Status:    status,
+		Amount:    amount,
+		PaidAt:    time.Now(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	header.Set(signatureHeader, l.sign(body))
+
+	return header, body, nil
+}
+
+func (l *LocalSimulator) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(l.secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,170 @@
+package payment
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	xenditInvoiceURL     = "https://api.xendit.co/v2/invoices"
+	xenditExpireURL      = "https://api.xendit.co/invoices/%s/expire!"
+	xenditCallbackHeader = "X-Callback-Token"
+	xenditInvoiceTTL     = 24 * time.Hour
+)
+
+// Xendit membuat invoice lewat Xendit Invoice API, webhook diverifikasi dengan callback token dari dashboard Xendit.
+type Xendit struct {
+	secretKey     string
+	callbackToken string
+	client        *http.Client
+}
+
+type xenditInvoiceRequest struct {
+	ExternalID      string `json:"external_id"`
+	Amount          int64  `json:"amount"`
+	Description     string `json:"description"`
+	PayerEmail      string `json:"payer_email,omitempty"`
+	InvoiceDuration int    `json:"invoice_duration"`
+	Customer        struct {
+		GivenNames string `json:"given_names,omitempty"`
+		Email      string `json:"email,omitempty"`
+	} `json:"customer"`
+}
+
+type xenditInvoice struct {
+	ID         string    `json:"id"`
+	ExternalID string    `json:"external_id"`
+	Status     string    `json:"status"`
+	Amount     float64   `json:"amount"`
+	InvoiceURL string    `json:"invoice_url"`
+	ExpiryDate time.Time `json:"expiry_date"`
+	PaidAt     time.Time `json:"paid_at"`
+	Message    string    `json:"message"`
+}
+
+func NewXendit(secretKey, callbackToken string) *Xendit {
+	return &Xendit{
+		secretKey:     secretKey,
+		callbackToken: callbackToken,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (x *Xendit) Name() string {
+	return "xendit"
+}
+
+func (x *Xendit) CreateCharge(charge Charge) (*ChargeResult, error) {
+	payload := xenditInvoiceRequest{
+		ExternalID:      charge.OrderID,
+		Amount:          charge.Amount,
+		Description:     charge.Description,
+		PayerEmail:      charge.CustomerEmail,
+		InvoiceDuration: int(xenditInvoiceTTL.Seconds()),
+	}
+	payload.Customer.GivenNames = charge.CustomerName
+	payload.Customer.Email = charge.CustomerEmail
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, xenditInvoiceURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(x.secretKey, "")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := x.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var invoice xenditInvoice
+	err = json.Unmarshal(resBody, &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("xendit create invoice failed with status %d: %s", res.StatusCode, invoice.Message)
+	}
+
+	return &ChargeResult{
+		Reference:   invoice.ID,
+		RedirectURL: invoice.InvoiceURL,
+		ExpiresAt:   invoice.ExpiryDate,
+	}, nil
+}
+
+// ExpireCharge menutup invoice yang digantikan tagihan baru agar tidak bisa dibayar lagi.
+func (x *Xendit) ExpireCharge(reference string) error {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(xenditExpireURL, url.PathEscape(reference)), nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(x.secretKey, "")
+
+	res, err := x.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var invoice xenditInvoice
+		_ = json.Unmarshal(resBody, &invoice)
+		return fmt.Errorf("xendit expire invoice failed with status %d: %s", res.StatusCode, invoice.Message)
+	}
+
+	return nil
+}
+
+func (x *Xendit) ParseWebhook(header http.Header, body []byte) (*Notification, error) {
+	if subtle.ConstantTimeCompare([]byte(header.Get(xenditCallbackHeader)), []byte(x.callbackToken)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	var invoice xenditInvoice
+	err := json.Unmarshal(body, &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	// status selain PAID, SETTLED dan EXPIRED (misalnya PENDING) tidak mengubah tagihan
+	status := strings.ToUpper(invoice.Status)
+	switch status {
+	case "PAID", "SETTLED":
+		status = StatusPaid
+	case "EXPIRED":
+		status = StatusExpired
+	}
+
+	return &Notification{
+		OrderID:   invoice.ExternalID,
+		Reference: invoice.ID,
+		Status:    status,
+		Amount:    int64(invoice.Amount),
+		PaidAt:    invoice.PaidAt,
+	}, nil
+}
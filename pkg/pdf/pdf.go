@@ -0,0 +1,98 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Ukuran halaman A4 dalam satuan point.
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+type Font string
+
+const (
+	Regular Font = "F1"
+	Bold    Font = "F2"
+)
+
+// Document adalah dokumen PDF satu halaman yang hanya berisi teks dan garis
+// dengan font standar Helvetica, sehingga tidak perlu menyematkan file font.
+type Document struct {
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// Text menulis teks dengan titik awal (x, y) dari kiri bawah halaman.
+func (d *Document) Text(x, y, size float64, font Font, text string) {
+	fmt.Fprintf(&d.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&d.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// Rect menggambar kotak berisi warna abu-abu dengan tingkat gray 0 (hitam) sampai 1 (putih).
+func (d *Document) Rect(x, y, width, height, gray float64) {
+	fmt.Fprintf(&d.content, "q %.2f g %.2f %.2f %.2f %.2f re f Q\n", gray, x, y, width, height)
+}
+
+func (d *Document) Bytes() []byte {
+	content := d.content.Bytes()
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", PageWidth, PageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, v := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, v)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, v := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", v)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape mengubah teks ke WinAnsi, karakter di luar Latin-1 diganti tanda tanya.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20:
+			continue
+		case r < 0x80:
+			b.WriteRune(r)
+		case r < 0x100:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
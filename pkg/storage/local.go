@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Local struct {
+	root    string
+	baseURL string
+	secret  string
+}
+
+func NewLocal() (*Local, error) {
+	root := os.Getenv("STORAGE_LOCAL_PATH")
+	if root == "" {
+		root = "storage"
+	}
+
+	secret := os.Getenv("STORAGE_SIGNING_KEY")
+	if secret == "" {
+		return nil, errors.New("STORAGE_SIGNING_KEY is required")
+	}
+
+	return &Local{
+		root:    root,
+		baseURL: strings.TrimSuffix(os.Getenv("APP_URL"), "/"),
+		secret:  secret,
+	}, nil
+}
+
+func (l *Local) Upload(file *multipart.FileHeader, folder string, contentType string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	key := fmt.Sprintf("%s/%s%s", folder, uuid.NewString(), filepath.Ext(file.Filename))
+
+	path, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (l *Local) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (l *Local) Open(key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrFileNotFound
+	}
+
+	return file, err
+}
+
+func (l *Local) SignedURL(key string, expiresIn time.Duration) (string, error) {
+	expires := strconv.FormatInt(time.Now().Add(expiresIn).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", l.sign(key, expires))
+
+	return fmt.Sprintf("%s/api/v1/files/%s?%s", l.baseURL, key, query.Encode()), nil
+}
+
+func (l *Local) Verify(key string, expires string, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(l.sign(key, expires)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (l *Local) sign(key string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(l.secret))
+	mac.Write([]byte(key + ":" + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path memastikan key tidak keluar dari root storage.
+func (l *Local) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", ErrFileNotFound
+	}
+
+	return filepath.Join(l.root, cleaned), nil
+}
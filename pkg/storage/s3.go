@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type S3 struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3() (*S3, error) {
+	client, err := minio.New(os.Getenv("STORAGE_S3_ENDPOINT"), &minio.Options{
+		Creds:  credentials.NewStaticV4(os.Getenv("STORAGE_S3_ACCESS_KEY"), os.Getenv("STORAGE_S3_SECRET_KEY"), ""),
+		Secure: os.Getenv("STORAGE_S3_USE_SSL") == "true",
+		Region: os.Getenv("STORAGE_S3_REGION"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3{
+		client: client,
+		bucket: os.Getenv("STORAGE_S3_BUCKET"),
+	}, nil
+}
+
+func (s *S3) Upload(file *multipart.FileHeader, folder string, contentType string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	key := fmt.Sprintf("%s/%s%s", folder, uuid.NewString(), filepath.Ext(file.Filename))
+
+	_, err = s.client.PutObject(context.Background(), s.bucket, key, src, file.Size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (s *S3) Delete(key string) error {
+	return s.client.RemoveObject(context.Background(), s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3) Open(key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(context.Background(), s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = object.Stat()
+	if err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func (s *S3) SignedURL(key string, expiresIn time.Duration) (string, error) {
+	signedURL, err := s.client.PresignedGetObject(context.Background(), s.bucket, key, expiresIn, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return signedURL.String(), nil
+}
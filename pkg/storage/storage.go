@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime/multipart"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrFileNotFound     = errors.New("file not found")
+	ErrInvalidSignature = errors.New("signed url is invalid or expired")
+)
+
+type Interface interface {
+	Upload(file *multipart.FileHeader, folder string, contentType string) (string, error)
+	Delete(key string) error
+	Open(key string) (io.ReadCloser, error)
+	SignedURL(key string, expiresIn time.Duration) (string, error)
+}
+
+// Verifier diimplementasikan driver yang melayani sendiri signed URL-nya (misalnya driver local).
+type Verifier interface {
+	Verify(key string, expires string, signature string) error
+}
+
+func Init() Interface {
+	var driver Interface
+	var err error
+
+	switch os.Getenv("STORAGE_DRIVER") {
+	case "local":
+		driver, err = NewLocal()
+	case "s3":
+		driver, err = NewS3()
+	default:
+		driver, err = NewSupabase()
+	}
+	if err != nil {
+		log.Fatalf("error init storage %v", err)
+	}
+
+	return driver
+}
+
+// SignedURLExpiry membaca masa berlaku signed URL dalam menit dari STORAGE_SIGNED_URL_EXP_TIME, default 15 menit.
+func SignedURLExpiry() time.Duration {
+	expiresIn, err := strconv.Atoi(os.Getenv("STORAGE_SIGNED_URL_EXP_TIME"))
+	if err != nil || expiresIn <= 0 {
+		expiresIn = 15
+	}
+
+	return time.Duration(expiresIn) * time.Minute
+}
+
+// IsKey membedakan key storage dengan URL lama yang tersimpan sebelum storage memakai key.
+func IsKey(value string) bool {
+	return value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://")
+}
+
+// URL mengubah nilai yang tersimpan di database menjadi URL yang bisa dibuka, URL lama dikembalikan apa adanya.
+func URL(s Interface, value string, expiresIn time.Duration) (string, error) {
+	if !IsKey(value) {
+		return value, nil
+	}
+
+	return s.SignedURL(value, expiresIn)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	storage_go "github.com/supabase-community/storage-go"
+)
+
+type Supabase struct {
+	client *storage_go.Client
+	bucket string
+}
+
+// NewSupabase hanya memakai bucket privat, bucket publik tidak boleh dipakai karena berisi KTM dan bukti pembayaran.
+func NewSupabase() (*Supabase, error) {
+	url := fmt.Sprintf("%s/storage/v1", os.Getenv("SUPABASE_URL"))
+
+	bucket := os.Getenv("SUPABASE_PRIVATE_BUCKET")
+	if bucket == "" {
+		return nil, errors.New("SUPABASE_PRIVATE_BUCKET is required")
+	}
+
+	return &Supabase{
+		client: storage_go.NewClient(url, os.Getenv("SUPABASE_TOKEN"), nil),
+		bucket: bucket,
+	}, nil
+}
+
+func (s *Supabase) Upload(file *multipart.FileHeader, folder string, contentType string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	key := fmt.Sprintf("%s/%s%s", folder, uuid.NewString(), filepath.Ext(file.Filename))
+
+	_, err = s.client.UploadFile(
+		s.bucket,
+		key,
+		src,
+		storage_go.FileOptions{
+			ContentType: &contentType,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (s *Supabase) Delete(key string) error {
+	_, err := s.client.RemoveFile(s.bucket, []string{key})
+	return err
+}
+
+func (s *Supabase) Open(key string) (io.ReadCloser, error) {
+	data, err := s.client.DownloadFile(s.bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *Supabase) SignedURL(key string, expiresIn time.Duration) (string, error) {
+	res, err := s.client.CreateSignedUrl(s.bucket, key, int(expiresIn.Seconds()))
+	if err != nil {
+		return "", err
+	}
+
+	return res.SignedURL, nil
+}